@@ -0,0 +1,38 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/viant/datly/cmd/options"
+	"github.com/viant/datly/repository/drift"
+)
+
+// validateDrift re-discovers config routes views columns and reports added, removed and type changed columns,
+// only removed and type changed columns fail validation
+func (s *Service) validateDrift(ctx context.Context, validate *options.Validate) error {
+	_, repo, err := newLocalRepository(ctx, validate.Config, validate.Connectors)
+	if err != nil {
+		return err
+	}
+	breaking, failed := 0, 0
+	for _, report := range drift.New(nil).Check(ctx, repo) {
+		if report.Breaking() {
+			breaking++
+		}
+		if report.Error != "" {
+			failed++
+			fmt.Printf("FAILED %v\n", report)
+			continue
+		}
+		fmt.Printf("DRIFT %v\n", report)
+	}
+	switch {
+	case breaking > 0:
+		return fmt.Errorf("breaking schema drift detected in %v route(s)", breaking)
+	case failed > 0:
+		return fmt.Errorf("failed to check schema drift of %v route(s)", failed)
+	}
+	fmt.Printf("no breaking schema drift detected\n")
+	return nil
+}
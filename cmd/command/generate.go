@@ -37,6 +37,12 @@ func (s *Service) Generate(ctx context.Context, options *options.Options) error
 }
 
 func (s *Service) generate(ctx context.Context, options *options.Options) error {
+	if options.Generate.ProtoURL != "" {
+		return s.generateProto(ctx, options.Generate)
+	}
+	if options.Generate.Migrate {
+		return s.generateMigration(ctx, options.Generate)
+	}
 
 	ruleOption := options.Rule()
 	if _, err := s.loadPlugin(ctx, options); err != nil {
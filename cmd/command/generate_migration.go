@@ -0,0 +1,207 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	nurl "net/url"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/viant/afs/file"
+	"github.com/viant/afs/url"
+	"github.com/viant/datly/cmd/options"
+	"github.com/viant/datly/repository"
+	"github.com/viant/datly/service"
+	"github.com/viant/datly/service/dbms/migration"
+	"github.com/viant/datly/service/dbms/provider"
+	"github.com/viant/datly/service/dbms/provider/bigquery"
+	"github.com/viant/datly/service/dbms/provider/mysql"
+	"github.com/viant/datly/view"
+	"github.com/viant/datly/view/state"
+	"github.com/viant/sqlx/io"
+	"github.com/viant/sqlx/io/config"
+)
+
+type (
+	// migrationTarget represents table written by executor component entity
+	migrationTarget struct {
+		connector  *view.Connector
+		table      string
+		recordType reflect.Type
+	}
+
+	// migrationSource represents dialect specific DDL source
+	migrationSource interface {
+		provider.SqlSource
+		migration.Dialect
+		ColumnType(column io.Column) (string, bool, error)
+	}
+)
+
+// errUnsupportedMigration represents connector driver without migration dialect, only MySQL and BigQuery are supported
+var errUnsupportedMigration = errors.New("unsupported migration database")
+
+// generateMigration writes up and down migration scripts for tables written by executor components of datly config
+func (s *Service) generateMigration(ctx context.Context, gen *options.Generate) error {
+	_, repo, err := newLocalRepository(ctx, gen.Configs.URL(), gen.Repository.Connectors)
+	if err != nil {
+		return err
+	}
+	targets, err := migrationTargets(ctx, repo)
+	if err != nil {
+		return err
+	}
+	stamp := time.Now().UTC().Format("20060102150405")
+	for _, target := range targets {
+		aMigration, err := target.migration(ctx, gen.DropCols)
+		if errors.Is(err, errUnsupportedMigration) {
+			fmt.Printf("[WARN] skipped %v: %v\n", target.table, err)
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to generate %v migration: %w", target.table, err)
+		}
+		fmt.Println(aMigration.Report())
+		if gen.DryRun || len(aMigration.Up) == 0 {
+			continue
+		}
+		baseURL := url.Join(gen.Migration, stamp+"_"+strings.ToLower(strings.ReplaceAll(target.table, ".", "_")))
+		if err = s.fs.Upload(ctx, baseURL+".up.sql", file.DefaultFileOsMode, strings.NewReader(aMigration.UpScript())); err != nil {
+			return err
+		}
+		if err = s.fs.Upload(ctx, baseURL+".down.sql", file.DefaultFileOsMode, strings.NewReader(aMigration.DownScript())); err != nil {
+			return err
+		}
+		fmt.Printf("[INFO] generated %v.up.sql\n", baseURL)
+	}
+	return nil
+}
+
+// migrationTargets returns tables of views used by executor components
+func migrationTargets(ctx context.Context, repo *repository.Service) ([]*migrationTarget, error) {
+	container := repo.Container()
+	if container == nil {
+		return nil, nil
+	}
+	var ret []*migrationTarget
+	indexed := map[string]bool{}
+	var collect func(aView *view.View)
+	collect = func(aView *view.View) {
+		if aView.Table != "" && aView.Connector != nil && aView.Schema != nil {
+			key := aView.Connector.Name + ":" + strings.ToLower(aView.Table)
+			rType := aView.Schema.CompType()
+			for rType != nil && rType.Kind() == reflect.Ptr {
+				rType = rType.Elem()
+			}
+			if rType != nil && rType.Kind() == reflect.Struct && !indexed[key] {
+				indexed[key] = true
+				ret = append(ret, &migrationTarget{connector: aView.Connector, table: aView.Table, recordType: rType})
+			}
+		}
+		for _, relation := range aView.With {
+			if relation.Of != nil {
+				collect(&relation.Of.View)
+			}
+		}
+	}
+	for _, item := range container.Items {
+		for _, aPath := range item.Paths {
+			provider, err := repo.Registry().LookupProvider(ctx, &aPath.Path)
+			if err != nil {
+				return nil, err
+			}
+			component, err := provider.Component(ctx)
+			if err != nil {
+				return nil, err
+			}
+			if component.Service != service.TypeExecutor {
+				continue
+			}
+			resource := component.View.GetResource()
+			for _, parameter := range component.Input.Type.Parameters.FilterByKind(state.KindView) {
+				aView, err := resource.View(parameter.In.Name)
+				if err != nil {
+					return nil, err
+				}
+				collect(aView)
+			}
+		}
+	}
+	return ret, nil
+}
+
+// migration diffs entity struct with live table, missing table is created
+func (t *migrationTarget) migration(ctx context.Context, drop bool) (*migration.Migration, error) {
+	source, table, err := newMigrationSource(ctx, t.connector, t.table)
+	if err != nil {
+		return nil, err
+	}
+	db, err := t.connector.DB()
+	if err != nil {
+		return nil, err
+	}
+	session, err := config.Session(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	tableColumns, err := config.Columns(ctx, session, db, t.table)
+	if err != nil {
+		return nil, err
+	}
+	structColumns, err := io.StructColumns(t.recordType, "sqlx")
+	if err != nil {
+		return nil, err
+	}
+	var declared, actual []*migration.Column
+	for _, column := range structColumns {
+		dataType, nullable, err := source.ColumnType(column)
+		if err != nil {
+			fmt.Printf("[WARN] skipped %v.%v: %v\n", t.table, column.Name(), err)
+			continue
+		}
+		declared = append(declared, &migration.Column{Name: column.Name(), DataType: dataType, Nullable: nullable, Type: column.ScanType()})
+	}
+	for _, column := range tableColumns {
+		nullable := strings.EqualFold(column.Nullable, "YES") || strings.EqualFold(column.Nullable, "true") || column.Nullable == "1"
+		actual = append(actual, &migration.Column{Name: column.Name, DataType: column.Type, Nullable: nullable})
+	}
+	ret := migration.Diff(t.table, declared, actual, source, drop)
+	if len(tableColumns) > 0 {
+		return ret, nil
+	}
+	created, err := source.CreateTable(t.recordType, table, "sqlx", false)
+	if err != nil {
+		return nil, err
+	}
+	ret.Up = []string{created.SQL}
+	createTable := strings.SplitN(created.SQL, " (", 2)[0]
+	ret.Down = []string{strings.Replace(createTable, "CREATE TABLE IF NOT EXISTS", "DROP TABLE IF EXISTS", 1)}
+	return ret, nil
+}
+
+// newMigrationSource returns connector dialect DDL source and table name expected by the source
+func newMigrationSource(ctx context.Context, connector *view.Connector, table string) (migrationSource, string, error) {
+	switch connector.Driver {
+	case "mysql":
+		return mysql.NewSQLSource(), table, nil
+	case "bigquery":
+		dataset := ""
+		if index := strings.LastIndex(table, "."); index != -1 {
+			dataset, table = table[:index], table[index+1:]
+		} else {
+			resolved, err := connector.ResolvedDSN(ctx)
+			if err != nil {
+				return nil, "", err
+			}
+			if DSN, err := nurl.Parse(resolved); err == nil {
+				segments := strings.Split(strings.Trim(DSN.Path, "/"), "/")
+				dataset = segments[len(segments)-1]
+			}
+		}
+		source, err := bigquery.NewSQLSource(dataset)
+		return source, table, err
+	}
+	return nil, "", fmt.Errorf("%w %v", errUnsupportedMigration, connector.Driver)
+}
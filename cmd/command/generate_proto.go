@@ -0,0 +1,37 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/viant/afs/file"
+	"github.com/viant/datly/cmd/options"
+	"github.com/viant/datly/gateway/rpc"
+	"github.com/viant/datly/gateway/runtime/standalone"
+)
+
+// generateProto writes gRPC proto file for all components defined in datly config
+func (s *Service) generateProto(ctx context.Context, gen *options.Generate) error {
+	config, err := standalone.NewConfigFromURL(ctx, gen.Configs.URL())
+	if err != nil {
+		return err
+	}
+	if config.Config.RPC == nil {
+		config.Config.RPC = &rpc.Config{}
+	}
+	srv, _, err := standalone.NewService(ctx, standalone.WithConfig(config), standalone.WithUseSingleton(false))
+	if err != nil {
+		return err
+	}
+	defer srv.Close()
+	data, err := srv.RPC().ProtoFile()
+	if err != nil {
+		return fmt.Errorf("failed to generate proto: %w", err)
+	}
+	if err = s.fs.Upload(ctx, gen.ProtoURL, file.DefaultFileOsMode, bytes.NewReader(data)); err != nil {
+		return err
+	}
+	fmt.Printf("[INFO] generated %v\n", gen.ProtoURL)
+	return nil
+}
@@ -48,7 +48,7 @@ func (s *Service) mcp(ctx context.Context, mcpOption *options.Mcp) error {
 		IssuerURL:       mcpOption.IssuerURL,
 		ResourceURL:     mcpOption.ResourceURL,
 		AuthorizerMode:  mcpOption.AuthorizerMode,
-	})
+	}, mcp.WithCompletions(service.MCPCompletions()), mcp.WithSubscriptions(service.MCPSubscriptions()), mcp.WithToolSchemas(service.MCPToolSchemas()))
 	if err != nil {
 		return err
 	}
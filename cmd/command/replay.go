@@ -0,0 +1,67 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/viant/datly/cmd/options"
+	"github.com/viant/datly/gateway/router/recording"
+	"github.com/viant/datly/internal/replay"
+)
+
+// Replay re-executes recorded requests against candidate repository or datly instance and reports differences
+func (s *Service) Replay(ctx context.Context, opts *options.Replay) error {
+	records, err := recording.Load(ctx, s.fs, opts.Source)
+	if err != nil {
+		return err
+	}
+	var runnerOptions []replay.Option
+	if opts.Target != "" {
+		runnerOptions = append(runnerOptions, replay.WithTarget(opts.Target))
+	} else {
+		_, repo, err := newLocalRepository(ctx, opts.ConfigURL, opts.Connectors)
+		if err != nil {
+			return err
+		}
+		runnerOptions = append(runnerOptions, replay.WithRepository(repo))
+	}
+	for _, header := range opts.Headers {
+		name, value, ok := strings.Cut(header, ":")
+		if !ok {
+			return fmt.Errorf("invalid header: %v, expected name: value", header)
+		}
+		runnerOptions = append(runnerOptions, replay.WithHeader(strings.TrimSpace(name), strings.TrimSpace(value)))
+	}
+	runnerOptions = append(runnerOptions, replay.WithMutating(opts.Mutating))
+	runner := replay.New(runnerOptions...)
+	passed, failed, skipped := 0, 0, 0
+	for _, record := range records {
+		if opts.Filter != "" && !strings.Contains(record.URI, opts.Filter) {
+			continue
+		}
+		result := runner.Run(ctx, record)
+		if result.Skipped {
+			skipped++
+			continue
+		}
+		if result.Passed() {
+			passed++
+			continue
+		}
+		failed++
+		fmt.Printf("DIFF %v %v (%v)\n", record.Method, record.URI, record.ID)
+		if result.Error != nil {
+			fmt.Printf("\t%v\n", result.Error)
+			continue
+		}
+		for _, change := range result.Changes.Changes {
+			fmt.Printf("\t%v %v: %v => %v\n", change.Type, change.Path.String(), change.From, change.To)
+		}
+	}
+	fmt.Printf("%v matched, %v differed, %v skipped\n", passed, failed, skipped)
+	if failed > 0 {
+		return fmt.Errorf("%v recording(s) differed", failed)
+	}
+	return nil
+}
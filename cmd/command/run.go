@@ -6,6 +6,8 @@ import (
 	"github.com/viant/afs/url"
 	"github.com/viant/datly/cmd/options"
 	"github.com/viant/datly/gateway"
+	"github.com/viant/datly/gateway/router/mock"
+	"github.com/viant/datly/gateway/rpc"
 	"github.com/viant/datly/gateway/runtime/standalone"
 	"github.com/viant/datly/internal/setter"
 )
@@ -26,6 +28,11 @@ func (s *Service) Run(ctx context.Context, options *options.Options) (err error)
 			srv.MCP.ListenAndServe()
 		}()
 	}
+	if srv.RPC != nil {
+		go func() {
+			srv.RPC.ListenAndServe()
+		}()
+	}
 	return srv.ListenAndServe()
 }
 
@@ -55,5 +62,14 @@ func (s *Service) run(ctx context.Context, run *options.Run) (*standalone.Server
 		setter.SetStringIfEmpty(&s.config.Config.MCP.ResourceURL, run.MCPResourceURL)
 		setter.SetStringIfEmpty(&s.config.Config.MCP.AuthorizerMode, run.MCPAuthMode)
 	}
+	if run.RPCPort != nil {
+		if s.config.Config.RPC == nil {
+			s.config.Config.RPC = &rpc.Config{}
+		}
+		s.config.Config.RPC.Port = run.RPCPort
+	}
+	if run.Mock {
+		s.config.Config.Mock = &mock.Config{Seed: run.MockSeed, Rows: run.MockRows}
+	}
 	return standalone.New(ctx, standalone.WithConfig(s.config))
 }
@@ -68,6 +68,12 @@ func (s *Service) Exec(ctx context.Context, opts *options.Options) error {
 	if opts.Validate != nil {
 		return s.Validate(ctx, opts)
 	}
+	if opts.Test != nil {
+		return s.Test(ctx, opts.Test)
+	}
+	if opts.Replay != nil {
+		return s.Replay(ctx, opts.Replay)
+	}
 
 	if opts.Mcp != nil {
 		return s.Mcp(ctx, opts)
@@ -0,0 +1,119 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/viant/datly/cmd/options"
+	"github.com/viant/datly/gateway/runtime/standalone"
+	"github.com/viant/datly/internal/testutil/ruletest"
+	"github.com/viant/datly/repository"
+	"github.com/viant/datly/repository/locator/component/dispatcher"
+	"github.com/viant/datly/view"
+)
+
+// Test runs rule contract and snapshot test cases in process
+func (s *Service) Test(ctx context.Context, test *options.Test) error {
+	config, repo, err := newLocalRepository(ctx, test.ConfigURL, test.Connectors)
+	if err != nil {
+		return err
+	}
+	sources := test.Source
+	if len(sources) == 0 {
+		sources = []string{config.RouteURL}
+	}
+	var cases []*ruletest.Case
+	for _, source := range sources {
+		discovered, err := ruletest.Discover(ctx, s.fs, strings.TrimSuffix(source, ruletest.CaseFolderSuffix))
+		if err != nil {
+			return err
+		}
+		cases = append(cases, discovered...)
+	}
+	var overridden []string
+	for _, override := range test.Connectors {
+		overridden = append(overridden, strings.SplitN(override, "|", 2)[0])
+	}
+	runner := ruletest.New(repo, ruletest.WithUpdate(test.Update), ruletest.WithFs(s.fs),
+		ruletest.WithSeedConnectors(overridden...), ruletest.WithSeedAll(test.SeedAll))
+	passed, failed := 0, 0
+	for _, aCase := range cases {
+		if test.Filter != "" && !strings.Contains(aCase.Name, test.Filter) {
+			continue
+		}
+		result := runner.Run(ctx, aCase)
+		for _, updated := range result.Updated {
+			fmt.Printf("updated %v\n", updated)
+		}
+		if result.Passed() {
+			passed++
+			fmt.Printf("PASS %v/%v\n", aCase.Rule, aCase.Name)
+			continue
+		}
+		failed++
+		fmt.Printf("FAIL %v/%v\n", aCase.Rule, aCase.Name)
+		if result.Error != nil {
+			fmt.Printf("\t%v\n", result.Error)
+		}
+		for _, failure := range result.Failures {
+			fmt.Printf("\t%v\n", failure)
+		}
+	}
+	fmt.Printf("%v passed, %v failed\n", passed, failed)
+	if failed > 0 {
+		return fmt.Errorf("%v test case(s) failed", failed)
+	}
+	return nil
+}
+
+// newLocalRepository creates repository for in process component execution
+func newLocalRepository(ctx context.Context, configURL string, connectors []string) (*standalone.Config, *repository.Service, error) {
+	config, err := standalone.NewConfigFromURL(ctx, configURL)
+	if err != nil {
+		return nil, nil, err
+	}
+	repo, err := repository.New(ctx, repository.WithComponentURL(config.RouteURL),
+		repository.WithResourceURL(config.DependencyURL),
+		repository.WithPluginURL(config.PluginsURL),
+		repository.WithApiPrefix(config.APIPrefix),
+		repository.WithJWTSigner(config.JwtSigner),
+		repository.WithJWTVerifier(config.JWTValidator),
+		repository.WithDependencyURL(config.DependencyURL),
+		repository.WithRefreshDisabled(true),
+		repository.WithDispatcher(dispatcher.New),
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to initialise component service: %w", err)
+	}
+	if err = overrideConnectors(repo, connectors); err != nil {
+		return nil, nil, err
+	}
+	return config, repo, nil
+}
+
+// overrideConnectors replaces shared connectors driver and dsn, i.e. to run cases against SQLite or local database stand-in
+func overrideConnectors(repo *repository.Service, overrides []string) error {
+	if len(overrides) == 0 {
+		return nil
+	}
+	resource, err := repo.Resources().Lookup(view.ResourceConnectors)
+	if err != nil {
+		return fmt.Errorf("failed to lookup connectors: %w", err)
+	}
+	for _, override := range overrides {
+		parts := strings.SplitN(override, "|", 3)
+		if len(parts) != 3 {
+			return fmt.Errorf("invalid connector override: %v, expected name|driver|dsn", override)
+		}
+		connector, err := resource.Connector(parts[0])
+		if err != nil {
+			return fmt.Errorf("failed to override connector: %w", err)
+		}
+		connector.Driver = parts[1]
+		connector.DSN = parts[2]
+		connector.Secret = nil
+		connector.Connections = nil
+	}
+	return nil
+}
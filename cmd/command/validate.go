@@ -60,6 +60,9 @@ func (s *Service) Validate(ctx context.Context, opts *options.Options) error {
 	for _, item := range validated {
 		fmt.Printf("validated %s\n", item)
 	}
+	if validate.Drift {
+		return s.validateDrift(ctx, validate)
+	}
 	return nil
 }
 
@@ -16,6 +16,11 @@ type Generate struct {
 	Kind      string `short:"k" long:"kind" description:"execution kind" choice:"dml" choice:"service"`
 	Lang      string `short:"l" long:"lang" description:"lang" choice:"velty" choice:"go"`
 	Translate bool   `short:"t" long:"translate" description:"translate generated DSQL"`
+	ProtoURL  string `long:"proto" description:"gRPC proto file destination generated from components of datly config (-C)"`
+	Migrate   bool   `long:"migrate" description:"generate migrations from executor components entities of datly config (-C) and live tables, only MySQL and BigQuery connectors are supported, others are skipped"`
+	Migration string `long:"migrationDest" description:"migration files destination" default:"migration"`
+	DropCols  bool   `long:"dropColumns" description:"generate drop statements for table columns without entity field"`
+	DryRun    bool   `long:"dryRun" description:"report migration changes without writing files"`
 }
 
 func (g *Generate) HttpMethod() string {
@@ -28,6 +33,12 @@ func (g *Generate) EmbedLocation(URI string, methodFragment string) string {
 }
 
 func (g *Generate) Init() error {
+	if g.ProtoURL != "" {
+		return g.initProto()
+	}
+	if g.Migrate {
+		return g.initMigrate()
+	}
 	if err := g.Rule.Init(); err != nil {
 		return err
 	}
@@ -46,6 +57,32 @@ func (g *Generate) Init() error {
 	return nil
 }
 
+func (g *Generate) initProto() error {
+	if g.Configs.URL() == "" {
+		return fmt.Errorf("datly config was empty")
+	}
+	for i, URL := range g.Configs {
+		g.Configs[i] = ConfigURL(ensureAbsPath(string(URL)))
+	}
+	g.ProtoURL = ensureAbsPath(g.ProtoURL)
+	return nil
+}
+
+func (g *Generate) initMigrate() error {
+	if g.Configs.URL() == "" {
+		return fmt.Errorf("datly config was empty")
+	}
+	for i, URL := range g.Configs {
+		g.Configs[i] = ConfigURL(ensureAbsPath(string(URL)))
+	}
+	if g.Migration == "" {
+		g.Migration = "migration"
+	}
+	g.Migration = ensureAbsPath(g.Migration)
+	g.Repository.Connector.Init()
+	return nil
+}
+
 func (g *Generate) DSQLLocation() string {
 	_, name := url.Split(g.SourceURL(), file.Scheme)
 
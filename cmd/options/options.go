@@ -12,6 +12,8 @@ type Options struct {
 	Translate   *Translate   `command:"translate" description:"translate dql into datly repository rule"`
 	Transcribe  *Transcribe  `command:"transcribe" description:"compile dql with shape pipeline and generate bootstrap artifacts"`
 	Validate    *Validate    `command:"validate" description:"validate DQL and referenced SQL assets with the shape pipeline"`
+	Test        *Test        `command:"test" description:"run rule contract and snapshot tests"`
+	Replay      *Replay      `command:"replay" description:"replay recorded requests and report output differences"`
 	Cache       *CacheWarmup `command:"cache" description:"warmup cache"`
 	Run         *Run         `command:"run" description:"start datly in standalone mode"`
 	Mcp         *Mcp         `command:"mcp" description:"run mcp"`
@@ -80,6 +82,12 @@ func (o *Options) Init(ctx context.Context) error {
 	if o.Validate != nil {
 		return o.Validate.Init(ctx)
 	}
+	if o.Test != nil {
+		return o.Test.Init()
+	}
+	if o.Replay != nil {
+		return o.Replay.Init()
+	}
 	if o.Run != nil {
 		return o.Run.Init()
 	}
@@ -117,6 +125,10 @@ func NewOptions(args Arguments) *Options {
 		ret.Transcribe = &Transcribe{}
 	case "validate":
 		ret.Validate = &Validate{}
+	case "test":
+		ret.Test = &Test{}
+	case "replay":
+		ret.Replay = &Replay{}
 	case "cache":
 		ret.Cache = &CacheWarmup{}
 	case "run":
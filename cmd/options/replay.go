@@ -0,0 +1,31 @@
+package options
+
+import (
+	"fmt"
+)
+
+// Replay defines options for replaying recorded requests
+type Replay struct {
+	ConfigURL  string   `short:"c" long:"conf" description:"candidate datly config, recordings are replayed in process"`
+	Source     string   `short:"s" long:"src" description:"recording JSONL file or folder"`
+	Target     string   `short:"t" long:"target" description:"candidate datly base URL, i.e. http://localhost:8080"`
+	Connectors []string `short:"C" long:"conn" description:"connector override name|driver|dsn"`
+	Headers    []string `short:"H" long:"header" description:"request header override, i.e. 'Authorization: Bearer xxx'"`
+	Filter     string   `short:"n" long:"name" description:"replay only requests with URI containing filter"`
+	Mutating   bool     `long:"mutating" description:"replay POST, PUT, PATCH and DELETE requests, they may modify candidate data"`
+}
+
+func (r *Replay) Init() error {
+	if r.Source == "" {
+		return fmt.Errorf("recording source was empty")
+	}
+	if r.ConfigURL == "" && r.Target == "" {
+		return fmt.Errorf("config and target were empty")
+	}
+	r.Source = ensureAbsPath(r.Source)
+	r.ConfigURL = ensureAbsPath(r.ConfigURL)
+	for i, connector := range r.Connectors {
+		r.Connectors[i] = expandHomeDir(connector)
+	}
+	return nil
+}
@@ -16,6 +16,10 @@ type Run struct {
 	MCPIssuerURL   string   `long:"mcpIssuerURL" description:"issuer url for MCP server"`
 	MCPResourceURL string   `long:"mcpResourceURL" description:"protected resource identifier for MCP server"`
 	MCPAuthMode    string   `long:"mcpAuth" description:"authorizer S - server authorizer, F fallback authorizer" choice:"F" choice:"S"`
+	RPCPort        *int     `long:"rpcPort" description:"enable gRPC/Connect server on the specified port"`
+	Mock           bool     `long:"mock" description:"serve components with generated data, executors validate and echo input"`
+	MockSeed       int64    `long:"mockSeed" description:"mock data seed"`
+	MockRows       int      `long:"mockRows" description:"mock rows per read view" default:"100"`
 	PluginInfo     string
 	Version        string
 }
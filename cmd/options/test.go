@@ -0,0 +1,29 @@
+package options
+
+import (
+	"fmt"
+)
+
+// Test defines options for rule contract and snapshot tests
+type Test struct {
+	ConfigURL  string   `short:"c" long:"conf" description:"datly config"`
+	Source     []string `short:"s" long:"src" description:"rule or rule folder location(s) with <rule>.test cases, defaults to config route folder"`
+	Connectors []string `short:"C" long:"conn" description:"connector override name|driver|dsn, i.e. dev|sqlite3|/tmp/dev.db"`
+	Filter     string   `short:"n" long:"name" description:"run only cases with name containing filter"`
+	Update     bool     `short:"u" long:"update" description:"update output and SQL snapshots"`
+	SeedAll    bool     `long:"seedAll" description:"allow fixtures to seed connectors without override, seeding deletes table rows"`
+}
+
+func (t *Test) Init() error {
+	if t.ConfigURL == "" {
+		return fmt.Errorf("config was empty")
+	}
+	t.ConfigURL = ensureAbsPath(t.ConfigURL)
+	for i, connector := range t.Connectors {
+		t.Connectors[i] = expandHomeDir(connector)
+	}
+	for i := range t.Source {
+		t.Source[i] = ensureAbsPath(t.Source[i])
+	}
+	return nil
+}
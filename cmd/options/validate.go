@@ -14,6 +14,8 @@ type Validate struct {
 	Source  []string `short:"s" long:"src" description:"DQL source file(s)"`
 	Project string   `short:"p" long:"proj" description:"project location"`
 	Strict  bool     `long:"strict" description:"enable strict compile mode"`
+	Drift   bool     `long:"drift" description:"check config routes views columns against live database"`
+	Config  string   `long:"conf" description:"datly config used with --drift"`
 }
 
 func (v *Validate) Init(ctx context.Context) error {
@@ -23,7 +25,13 @@ func (v *Validate) Init(ctx context.Context) error {
 	}
 	v.Project = ensureAbsPath(v.Project)
 	v.Connector.Init()
-	if len(v.Source) == 0 {
+	if v.Drift {
+		if v.Config == "" {
+			return fmt.Errorf("validate: --conf is required with --drift")
+		}
+		v.Config = ensureAbsPath(v.Config)
+	}
+	if len(v.Source) == 0 && !v.Drift {
 		return fmt.Errorf("validate: at least one --src is required")
 	}
 	for i := range v.Source {
@@ -3,10 +3,13 @@ package gateway
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"github.com/viant/afs/option"
 	"github.com/viant/afs/storage"
 	"github.com/viant/afs/url"
+	"github.com/viant/datly/service/jobs"
 	"github.com/viant/xdatly/handler/async"
+	"github.com/viant/xdatly/handler/logger"
 	"log"
 	"sync"
 	"time"
@@ -100,3 +103,25 @@ func (r *Service) handleJobEvent(ctx context.Context, object storage.Object, rou
 	job.EventURL = object.URL()
 	return router.HandleJob(ctx, job)
 }
+
+// startJobPool starts local worker pool executing durable queue jobs, pool stops on Close
+func (r *Service) startJobPool(ctx context.Context) {
+	ctx, r.jobCancel = context.WithCancel(ctx)
+	handler := func(ctx context.Context, job *async.Job) error {
+		router, _ := r.Router()
+		if router == nil {
+			return fmt.Errorf("router was nil")
+		}
+		return router.HandleJob(ctx, job)
+	}
+	var poolLogger logger.Logger
+	if aRouter := r.mainRouter; aRouter != nil {
+		poolLogger = aRouter.logger
+	}
+	r.jobPool = jobs.NewPool(handler,
+		jobs.WithLogger(poolLogger),
+		jobs.WithWorkers(r.Config.JobWorkers),
+		jobs.WithVisibilityTimeout(time.Duration(r.Config.JobVisibilityMs)*time.Millisecond),
+	)
+	r.jobPool.Start(ctx)
+}
@@ -5,11 +5,18 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/viant/afs"
+	"github.com/viant/datly/gateway/router/mock"
+	"github.com/viant/datly/gateway/router/recording"
+	"github.com/viant/datly/gateway/rpc"
 	"github.com/viant/datly/gateway/runtime/meta"
+	"github.com/viant/datly/repository/drift"
 	"github.com/viant/datly/repository/logging"
 	"github.com/viant/datly/repository/path"
 	"github.com/viant/datly/service/auth/config"
 	"github.com/viant/datly/service/auth/secret"
+	"github.com/viant/datly/service/jobs"
+	"github.com/viant/datly/view"
+	"github.com/viant/scy"
 	"github.com/viant/toolbox"
 	"gopkg.in/yaml.v3"
 	"strings"
@@ -23,7 +30,9 @@ type (
 	}
 
 	SensitiveConfig struct {
-		APIKeys path.APIKeys
+		APIKeys        path.APIKeys
+		MaskSecret     *scy.Resource `json:",omitempty" yaml:",omitempty"` //HMAC secret of hash masked columns
+		JobTokenSecret *scy.Resource `json:",omitempty" yaml:",omitempty"` //HMAC secret of anonymous job access tokens
 	}
 
 	ExposableConfig struct {
@@ -38,6 +47,9 @@ type (
 		JobURL              string
 		FailedJobURL        string
 		MaxJobs             int
+		JobWorkers          int      // durable job queue workers, 4 by default
+		JobVisibilityMs     int      // durable job lease duration, 5 min by default
+		JobAdminRoles       []string // JWT scope roles allowed to access any job
 		UseCacheFS          bool
 		SyncFrequencyMs     int
 		config.Config
@@ -50,6 +62,11 @@ type (
 		Version              string
 		CORS                 *path.Cors //Default CORS configuration
 		MCP                  *ModelContextProtocol
+		RPC                  *rpc.Config       //gRPC/Connect front door
+		MessagesURL          string            //folder with <locale>.yaml validation and error message catalogs
+		Mock                 *mock.Config      //serves components with generated records, executors validate and echo input
+		Recording            *recording.Config //samples route requests to JSONL files for datly replay
+		Drift                *drift.Config     //checks views columns against live database on startup and periodically
 	}
 
 	ModelContextProtocol struct {
@@ -60,6 +77,8 @@ type (
 		AuthorizerMode    string
 		BFFExchangeHeader string
 		BFFRedirectURI    string
+		// SubscriptionIntervalMs defines subscribed resources polling interval
+		SubscriptionIntervalMs int
 	}
 
 	ChangeDetection struct {
@@ -100,6 +119,14 @@ func (m *ModelContextProtocol) ProtectedResourceURL() string {
 	return defaultMCPProtectedResource
 }
 
+// SubscriptionInterval returns subscribed resources polling interval
+func (m *ModelContextProtocol) SubscriptionInterval() time.Duration {
+	if m == nil {
+		return 0
+	}
+	return time.Duration(m.SubscriptionIntervalMs) * time.Millisecond
+}
+
 const (
 	DQLBootstrapPrecedenceRoutesWins   = "routes_wins"
 	DQLBootstrapPrecedenceDQLWins      = "dql_wins"
@@ -179,9 +206,39 @@ func (c *Config) Init(ctx context.Context) error {
 		return err
 	}
 
+	if err := c.initMaskSecret(ctx); err != nil {
+		return err
+	}
+	if err := c.initJobTokenSecret(ctx); err != nil {
+		return err
+	}
 	return c.initSecrets(ctx)
 }
 
+func (c *Config) initMaskSecret(ctx context.Context) error {
+	if c.MaskSecret == nil {
+		return nil
+	}
+	secret, err := scy.New().Load(ctx, c.MaskSecret)
+	if err != nil {
+		return fmt.Errorf("failed to load mask secret: %w", err)
+	}
+	view.SetMaskSecret([]byte(secret.String()))
+	return nil
+}
+
+func (c *Config) initJobTokenSecret(ctx context.Context) error {
+	if c.JobTokenSecret == nil {
+		return nil
+	}
+	secret, err := scy.New().Load(ctx, c.JobTokenSecret)
+	if err != nil {
+		return fmt.Errorf("failed to load job token secret: %w", err)
+	}
+	jobs.SetTokenSecret([]byte(secret.String()))
+	return nil
+}
+
 func (c *Config) initSecrets(ctx context.Context) error {
 	if len(c.Secrets) == 0 {
 		return nil
@@ -0,0 +1,20 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/viant/datly/repository"
+	"github.com/viant/datly/repository/drift"
+)
+
+// startDriftMonitor checks routes schema drift on startup and starts periodic checks, only removed or type changed columns block startup
+func startDriftMonitor(ctx context.Context, service *repository.Service, config *drift.Config) (*drift.Monitor, error) {
+	monitor := drift.New(config)
+	breaking := drift.Log(monitor.Check(ctx, service))
+	if config.Block && breaking > 0 {
+		return nil, fmt.Errorf("breaking schema drift detected in %v route(s)", breaking)
+	}
+	monitor.Start(context.Background(), service)
+	return monitor, nil
+}
@@ -14,9 +14,11 @@ import (
 	"strings"
 
 	furl "github.com/viant/afs/url"
+	"github.com/viant/datly/gateway/router"
 	"github.com/viant/datly/gateway/router/proxy"
 	"github.com/viant/datly/repository"
 	dpath "github.com/viant/datly/repository/path"
+	"github.com/viant/datly/view"
 	"github.com/viant/datly/view/state"
 	"github.com/viant/jsonrpc"
 	"github.com/viant/mcp-protocol/authorization"
@@ -34,7 +36,7 @@ func (r *Router) buildToolsIntegration(item *dpath.Item, aPath *dpath.Path, aRou
 	if err != nil {
 		return fmt.Errorf("failed to get component from provider: %w", err)
 	}
-	toolInputType := r.buildToolInputType(component)
+	toolInputType := r.buildToolInputType(context.Background(), component)
 	meta := aPath.Meta.Build(component.View.Name, component.View.Table, &aPath.Path)
 	mcpTool := schema.Tool{
 		Name:        strings.ReplaceAll(meta.Name, " ", ""),
@@ -48,6 +50,13 @@ func (r *Router) buildToolsIntegration(item *dpath.Item, aPath *dpath.Path, aRou
 	if err != nil {
 		return err
 	}
+	if r.toolSchemas != nil && hasAuthBodyFields(component) {
+		r.toolSchemas.Register(mcpTool.Name, func(ctx context.Context) (*schema.ToolInputSchema, error) {
+			inputSchema := &schema.ToolInputSchema{}
+			inputType := r.buildToolInputType(r.mcpGrantsContext(ctx), component)
+			return inputSchema, inputSchema.Load(reflect.New(inputType).Interface())
+		})
+	}
 	handler := r.mcpToolCallHandler(component, aRoute)
 	tool := &serverproto.ToolEntry{
 		Metadata: mcpTool,
@@ -60,44 +69,7 @@ func (r *Router) buildToolsIntegration(item *dpath.Item, aPath *dpath.Path, aRou
 func (r *Router) mcpToolCallHandler(component *repository.Component, aRoute *Route) serverproto.ToolHandlerFunc {
 	return func(ctx context.Context, req *schema.CallToolRequest) (*schema.CallToolResult, *jsonrpc.Error) {
 		params := req.Params
-		uri := r.matchToolCallComponentURI(aRoute, component, params)
-		baseURL := fmt.Sprintf("http://localhost/%v", strings.TrimLeft(uri, "/")) // replace with actual service URL when available
-
-		values := url.Values{}
-		var body io.Reader
-		uniquePath := map[string]bool{}
-		uniqueQuery := map[string]bool{}
-
-		// 1) Collect parameters (component + selector pagination)
-		allParams := r.collectToolParameters(component)
-
-		// 2) Apply parameters to request URL/query/body
-		for _, p := range allParams {
-			value := toolArgumentValue(p, params.Arguments)
-			pType := p.Schema.Type()
-			if pType.Kind() == reflect.Ptr {
-				pType = pType.Elem()
-			}
-			value = r.coerceNumericValue(value, pType)
-			var rpcErr *jsonrpc.Error
-			baseURL, body, rpcErr = r.applyParamToRequest(baseURL, values, p, value, uniquePath, uniqueQuery, body)
-			if rpcErr != nil {
-				return nil, rpcErr
-			}
-		}
-
-		// 3) Finalize URL with query string
-		finalURL := baseURL
-		if enc := values.Encode(); enc != "" {
-			if strings.Contains(finalURL, "?") {
-				finalURL += "&" + enc
-			} else {
-				finalURL += "?" + enc
-			}
-		}
-
-		// 4) Build HTTP request and route
-		httpReq, rpcErr := r.newToolHTTPRequest(ctx, aRoute.Path.Method, finalURL, body)
+		httpReq, matched, finalURL, rpcErr := r.newComponentRequest(ctx, aRoute, component, params.Arguments, nil)
 		if rpcErr != nil {
 			return nil, rpcErr
 		}
@@ -106,24 +78,78 @@ func (r *Router) mcpToolCallHandler(component *repository.Component, aRoute *Rou
 		// NEW: map MCP view sync flag argument to Sync-Read header
 		r.addSyncReadHeaderIfPresent(ctx, component, &params, httpReq)
 
-		httpReq.RequestURI = httpReq.URL.RequestURI()
-		if uri != aRoute.URI() {
-			if matched, _ := r.match(component.Method, uri, httpReq); matched != nil {
-				aRoute = matched
-			}
-		}
 		rw := proxy.NewWriter()
-		aRoute.Handle(rw, httpReq)
+		matched.Handle(rw, httpReq)
 
 		if rw.Code == http.StatusUnauthorized {
 			return nil, r.mcpUnauthorizedError()
 		}
 
 		// 5) Build tool result (text + structured on error)
-		return r.buildToolCallResult(rw, finalURL, aRoute.Path.Method), nil
+		return r.buildToolCallResult(rw, finalURL, matched.Path.Method), nil
 	}
 }
 
+// newComponentRequest builds an HTTP request invoking component route with named arguments, it returns the request, matched route and request URL
+func (r *Router) newComponentRequest(ctx context.Context, aRoute *Route, component *repository.Component, arguments map[string]interface{}, header http.Header) (*http.Request, *Route, string, *jsonrpc.Error) {
+	uri := r.matchToolCallComponentURI(aRoute, component, arguments)
+	baseURL := fmt.Sprintf("http://localhost/%v", strings.TrimLeft(uri, "/")) // replace with actual service URL when available
+
+	values := url.Values{}
+	var body io.Reader
+	uniquePath := map[string]bool{}
+	uniqueQuery := map[string]bool{}
+
+	// 1) Collect parameters (component + selector pagination)
+	allParams := r.collectToolParameters(component)
+
+	// 2) Apply parameters to request URL/query/body
+	for _, p := range allParams {
+		value := toolArgumentValue(p, arguments)
+		pType := p.Schema.Type()
+		if pType.Kind() == reflect.Ptr {
+			pType = pType.Elem()
+		}
+		value = r.coerceNumericValue(value, pType)
+		var rpcErr *jsonrpc.Error
+		baseURL, body, rpcErr = r.applyParamToRequest(baseURL, values, p, value, uniquePath, uniqueQuery, body)
+		if rpcErr != nil {
+			return nil, nil, "", rpcErr
+		}
+	}
+
+	// 3) Finalize URL with query string
+	finalURL := baseURL
+	if enc := values.Encode(); enc != "" {
+		if strings.Contains(finalURL, "?") {
+			finalURL += "&" + enc
+		} else {
+			finalURL += "?" + enc
+		}
+	}
+
+	// 4) Build HTTP request and route
+	httpReq, rpcErr := r.newToolHTTPRequest(ctx, aRoute.Path.Method, finalURL, body)
+	if rpcErr != nil {
+		return nil, nil, "", rpcErr
+	}
+	for key, values := range header {
+		if httpReq.Header.Get(key) != "" {
+			continue
+		}
+		for _, value := range values {
+			httpReq.Header.Add(key, value)
+		}
+	}
+	httpReq.RequestURI = httpReq.URL.RequestURI()
+	if uri != aRoute.URI() {
+		if matched, _ := r.match(component.Method, uri, httpReq); matched != nil {
+			aRoute = matched
+		}
+	}
+	return httpReq, aRoute, finalURL, nil
+}
+
 func (r *Router) addSyncReadHeaderIfPresent(
 	ctx context.Context,
 	component *repository.Component,
@@ -371,15 +397,14 @@ func (r *Router) buildToolCallResult(responseWriter *proxy.Writer, URL, method s
 	return result
 }
 
-func (r *Router) matchToolCallComponentURI(aRoute *Route, component *repository.Component, params schema.CallToolRequestParams) string {
+func (r *Router) matchToolCallComponentURI(aRoute *Route, component *repository.Component, arguments map[string]interface{}) string {
 	URI := furl.Path(aRoute.Path.URI)
 	for _, parameter := range component.Input.Type.Parameters {
 		if parameter.URI == "" {
 			continue
 		}
-		paramName := strings.Title(parameter.Name)
-		value, ok := params.Arguments[paramName]
-		if !ok || value == nil || value == "" {
+		value := toolArgumentValue(parameter, arguments)
+		if value == nil || value == "" {
 			continue
 		}
 		URI = furl.Path(parameter.URI)
@@ -399,6 +424,13 @@ func (r *Router) addAuthTokenIfPresent(ctx context.Context, httpRequest *http.Re
 	}
 }
 
+// mcpGrantsContext returns context with grants of MCP caller token
+func (r *Router) mcpGrantsContext(ctx context.Context) context.Context {
+	request := &http.Request{Header: http.Header{}}
+	r.addAuthTokenIfPresent(ctx, request)
+	return router.RequestGrants(ctx, request)
+}
+
 func (r *Router) mcpUnauthorizedError() *jsonrpc.Error {
 	if r == nil || r.config == nil || r.config.MCP == nil {
 		return jsonrpc.NewError(schema.Unauthorized, "Unauthorized", nil)
@@ -417,7 +449,9 @@ func (r *Router) mcpUnauthorizedError() *jsonrpc.Error {
 	})
 }
 
-func (r *Router) buildToolInputType(components *repository.Component) reflect.Type {
+// buildToolInputType builds tool input type, anonymous body fields restricted by column authorization are included
+// only when context grants satisfy them
+func (r *Router) buildToolInputType(ctx context.Context, components *repository.Component) reflect.Type {
 	var inputFields []reflect.StructField
 	var uniqueFieldName = make(map[string]bool)
 	var uniqueQuery = make(map[string]bool)
@@ -453,7 +487,7 @@ func (r *Router) buildToolInputType(components *repository.Component) reflect.Ty
 			appendField(name, parameter.Schema.Type(), tag)
 		case state.KindRequestBody:
 			if parameter.IsAnonymous() {
-				appendAnonymousBodyFields(&inputFields, uniqueFieldName, parameter.Schema.Type())
+				appendAnonymousBodyFields(ctx, &inputFields, uniqueFieldName, parameter.Schema.Type())
 				continue
 			}
 			tag := buildMCPFieldTag(parameter, false)
@@ -607,7 +641,26 @@ func splitIdentifierParts(value string) []string {
 	return result
 }
 
-func appendAnonymousBodyFields(fields *[]reflect.StructField, unique map[string]bool, bodyType reflect.Type) {
+// hasAuthBodyFields returns true if anonymous body has fields restricted by column authorization
+func hasAuthBodyFields(component *repository.Component) bool {
+	for _, parameter := range component.Input.Type.Parameters {
+		if parameter.In.Kind != state.KindRequestBody || !parameter.IsAnonymous() {
+			continue
+		}
+		bodyType := indirectType(parameter.Schema.Type())
+		if bodyType == nil || bodyType.Kind() != reflect.Struct {
+			continue
+		}
+		for i := 0; i < bodyType.NumField(); i++ {
+			if _, ok := bodyType.Field(i).Tag.Lookup(view.AuthTag); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func appendAnonymousBodyFields(ctx context.Context, fields *[]reflect.StructField, unique map[string]bool, bodyType reflect.Type) {
 	bodyType = indirectType(bodyType)
 	if bodyType == nil || bodyType.Kind() != reflect.Struct {
 		return
@@ -617,6 +670,9 @@ func appendAnonymousBodyFields(fields *[]reflect.StructField, unique map[string]
 		if !field.IsExported() {
 			continue
 		}
+		if !view.FieldAllowed(ctx, field) {
+			continue
+		}
 		if unique[field.Name] {
 			continue
 		}
@@ -723,6 +779,7 @@ func (r *Router) buildTemplateResourceIntegration(item *dpath.Item, aPath *dpath
 
 	// Build the integration for the resource
 	r.mcpRegistry.RegisterResourceTemplate(mcpResourceTemplate, handler)
+	r.bindMcpResourceTables(mcpResourceTemplate.UriTemplate, provider)
 	return nil
 }
 
@@ -779,6 +836,7 @@ func (r *Router) buildResourceIntegration(item *dpath.Item, aPath *dpath.Path, a
 	}
 	// Build the integration for the mcpResource
 	r.mcpRegistry.RegisterResource(mcpResource, handler)
+	r.bindMcpResourceTables(mcpResource.Uri, provider)
 	return nil
 }
 
@@ -0,0 +1,260 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/viant/datly/gateway/router/proxy"
+	mcpext "github.com/viant/datly/mcp/extension"
+	"github.com/viant/datly/repository"
+	"github.com/viant/datly/repository/contract"
+	dpath "github.com/viant/datly/repository/path"
+	"github.com/viant/jsonrpc"
+	"github.com/viant/mcp-protocol/schema"
+	serverproto "github.com/viant/mcp-protocol/server"
+)
+
+// maxCompletionValues defines MCP completion values limit
+const maxCompletionValues = 100
+
+func (r *Router) buildPromptsIntegration(aPath *dpath.Path, aRoute *Route, provider *repository.Provider) error {
+	if aPath.Internal {
+		return nil
+	}
+	component, err := provider.Component(context.Background())
+	if err != nil {
+		return fmt.Errorf("failed to get component from provider: %w", err)
+	}
+	meta := aPath.Meta.Build(component.View.Name, component.View.Table, &aPath.Path)
+	for _, prompt := range aPath.MCPPrompts {
+		if prompt.Template == "" {
+			return fmt.Errorf("mcp prompt template was empty: %v", aPath.URI)
+		}
+		name := strings.ReplaceAll(prompt.Name, " ", "")
+		if name == "" {
+			name = strings.ReplaceAll(meta.Name, " ", "") + "Prompt"
+		}
+		if _, ok := r.mcpRegistry.Prompts.Get(name); ok {
+			continue //already registered
+		}
+		description := prompt.Description
+		if description == "" {
+			description = meta.Description
+		}
+		mcpPrompt := &schema.Prompt{Name: name, Description: &description}
+		hasCompletion := false
+		for _, argument := range prompt.PromptArguments() {
+			promptArgument := schema.PromptArgument{Name: argument.Name}
+			if argument.Description != "" {
+				promptArgument.Description = &argument.Description
+			}
+			if argument.Required {
+				required := true
+				promptArgument.Required = &required
+			}
+			hasCompletion = hasCompletion || argument.Completion != nil
+			mcpPrompt.Arguments = append(mcpPrompt.Arguments, promptArgument)
+		}
+		r.mcpRegistry.RegisterPrompts(mcpPrompt, r.mcpPromptHandler(prompt, component, aRoute))
+		if hasCompletion && r.completions != nil {
+			r.completions.Register(mcpext.RefPrompt, name, r.mcpCompletionHandler(prompt, component, aRoute))
+		}
+	}
+	return nil
+}
+
+func (r *Router) mcpPromptHandler(prompt *contract.MCPPrompt, component *repository.Component, aRoute *Route) serverproto.PromptHandlerFunc {
+	return func(ctx context.Context, params *schema.GetPromptRequestParams) (*schema.GetPromptResult, *jsonrpc.Error) {
+		text := prompt.Render(params.Arguments)
+		result := &schema.GetPromptResult{
+			Messages: []schema.PromptMessage{
+				{Role: schema.RoleUser, Content: schema.TextContent{Type: "text", Text: text}},
+			},
+		}
+		if !prompt.EmbedResult {
+			return result, nil
+		}
+		arguments := make(map[string]interface{}, len(params.Arguments))
+		for k, v := range params.Arguments {
+			arguments[k] = v
+		}
+		data, URL, rpcErr := r.invokeMCPComponent(ctx, component, aRoute, arguments)
+		if rpcErr != nil {
+			return nil, rpcErr
+		}
+		mimeType := "application/json"
+		result.Messages = append(result.Messages, schema.PromptMessage{
+			Role: schema.RoleUser,
+			Content: schema.EmbeddedResource{
+				Type:     "resource",
+				Resource: schema.EmbeddedResourceResource{Uri: URL, MimeType: &mimeType, Text: string(data)},
+			},
+		})
+		return result, nil
+	}
+}
+
+// mcpCompletionHandler suggests prompt argument values from distinct column values returned by a reader component;
+// the component is invoked with the caller token, thus suggestions respect route authorization
+func (r *Router) mcpCompletionHandler(prompt *contract.MCPPrompt, component *repository.Component, aRoute *Route) mcpext.CompletionHandlerFunc {
+	return func(ctx context.Context, params *schema.CompleteRequestParams) (*schema.CompleteResult, *jsonrpc.Error) {
+		result := &schema.CompleteResult{Completion: schema.CompleteResultCompletion{Values: []string{}}}
+		var completion *contract.MCPCompletion
+		for _, argument := range prompt.PromptArguments() {
+			if argument.Name == params.Argument.Name {
+				completion = argument.Completion
+			}
+		}
+		if completion == nil {
+			return result, nil
+		}
+		sourceRoute, sourceComponent := aRoute, component
+		if completion.URI != "" {
+			var err error
+			if sourceRoute, err = r.match(http.MethodGet, completion.URI, nil); err != nil {
+				return nil, jsonrpc.NewInternalError(fmt.Sprintf("failed to match completion route %v: %v", completion.URI, err), nil)
+			}
+			if len(sourceRoute.Providers) == 0 {
+				return nil, jsonrpc.NewInternalError(fmt.Sprintf("completion route %v has no component", completion.URI), nil)
+			}
+			if sourceComponent, err = sourceRoute.Providers[0].Component(ctx); err != nil {
+				return nil, jsonrpc.NewInternalError(err.Error(), nil)
+			}
+		}
+		arguments := map[string]interface{}{}
+		if params.Context != nil {
+			for k, v := range params.Context.Arguments {
+				arguments[k] = v
+			}
+		}
+		limit := completion.Limit
+		if limit <= 0 || limit > maxCompletionValues {
+			limit = maxCompletionValues
+		}
+		if completion.Parameter != "" { //prefix is matched by the view query, not only within the first page
+			arguments[completion.Parameter] = params.Argument.Value
+		}
+		if aView := sourceComponent.View; aView != nil && aView.Selector != nil && aView.Selector.LimitParameter != nil {
+			selector := aView.Selector
+			arguments[selector.LimitParameter.Name] = limit + 1
+		}
+		data, _, rpcErr := r.invokeMCPComponent(ctx, sourceComponent, sourceRoute, arguments)
+		if rpcErr != nil {
+			return nil, rpcErr
+		}
+		column := completion.Column
+		if column == "" {
+			column = params.Argument.Name
+		}
+		values := completionValues(data, column, params.Argument.Value)
+		total := len(values)
+		if total > limit {
+			hasMore := true
+			values = values[:limit]
+			result.Completion.HasMore = &hasMore
+		}
+		result.Completion.Values = values
+		result.Completion.Total = &total
+		return result, nil
+	}
+}
+
+// invokeMCPComponent invokes component route with caller token, it returns decoded response body and request URL
+func (r *Router) invokeMCPComponent(ctx context.Context, component *repository.Component, aRoute *Route, arguments map[string]interface{}) ([]byte, string, *jsonrpc.Error) {
+	httpReq, matched, URL, rpcErr := r.newComponentRequest(ctx, aRoute, component, arguments, nil)
+	if rpcErr != nil {
+		return nil, "", rpcErr
+	}
+	r.addAuthTokenIfPresent(ctx, httpReq)
+	rw := proxy.NewWriter()
+	matched.Handle(rw, httpReq)
+	if rw.Code == http.StatusUnauthorized {
+		return nil, "", r.mcpUnauthorizedError()
+	}
+	data, err := decodeToolResponseBody(rw)
+	if err != nil {
+		return nil, "", jsonrpc.NewInternalError(err.Error(), nil)
+	}
+	if rw.Code >= http.StatusBadRequest {
+		return nil, "", jsonrpc.NewInvalidRequest(strings.TrimSpace(string(data)), nil)
+	}
+	return data, URL, nil
+}
+
+// completionValues returns sorted distinct column values matching prefix
+func completionValues(data []byte, column, prefix string) []string {
+	var output interface{}
+	if err := json.Unmarshal(data, &output); err != nil {
+		return []string{}
+	}
+	prefix = strings.ToLower(prefix)
+	unique := map[string]bool{}
+	values := []string{}
+	for _, row := range completionRows(output) {
+		value, ok := completionColumnValue(row, column)
+		if !ok || value == nil {
+			continue
+		}
+		text := fmt.Sprintf("%v", value)
+		if unique[text] || !strings.HasPrefix(strings.ToLower(text), prefix) {
+			continue
+		}
+		unique[text] = true
+		values = append(values, text)
+	}
+	sort.Strings(values)
+	return values
+}
+
+// completionRows returns output rows, either top level collection or first collection of a structured output
+func completionRows(output interface{}) []map[string]interface{} {
+	var items []interface{}
+	switch actual := output.(type) {
+	case []interface{}:
+		items = actual
+	case map[string]interface{}:
+		keys := make([]string, 0, len(actual))
+		for key := range actual {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if collection, ok := actual[key].([]interface{}); ok {
+				items = collection
+				break
+			}
+		}
+		if items == nil {
+			items = []interface{}{actual}
+		}
+	}
+	var result []map[string]interface{}
+	for _, item := range items {
+		if row, ok := item.(map[string]interface{}); ok {
+			result = append(result, row)
+		}
+	}
+	return result
+}
+
+// completionColumnValue matches column ignoring case and separators, thus VENDOR_ID matches vendorId
+func completionColumnValue(row map[string]interface{}, column string) (interface{}, bool) {
+	if value, ok := row[column]; ok {
+		return value, true
+	}
+	normalized := normalizeColumnName(column)
+	for key, value := range row {
+		if normalizeColumnName(key) == normalized {
+			return value, true
+		}
+	}
+	return nil, false
+}
+
+func normalizeColumnName(name string) string {
+	return strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(name))
+}
@@ -0,0 +1,93 @@
+package gateway
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	mcpext "github.com/viant/datly/mcp/extension"
+	"github.com/viant/datly/repository"
+	"github.com/viant/datly/repository/contract"
+	dpath "github.com/viant/datly/repository/path"
+	"github.com/viant/datly/repository/version"
+	"github.com/viant/datly/view"
+	"github.com/viant/datly/view/state"
+	"github.com/viant/mcp-protocol/authorization"
+	"github.com/viant/mcp-protocol/schema"
+	serverproto "github.com/viant/mcp-protocol/server"
+)
+
+func TestRouter_buildPromptsIntegration(t *testing.T) {
+	aPath := contract.Path{Method: http.MethodGet, URI: "/v1/api/dev/vendors"}
+	component := &repository.Component{
+		Path: aPath,
+		View: &view.View{Name: "vendor", Table: "VENDOR"},
+	}
+	component.Input.Type.Parameters = state.Parameters{
+		state.NewParameter("namePrefix", state.NewQueryLocation("namePrefix"), state.WithParameterSchema(state.NewSchema(reflect.TypeOf("")))),
+	}
+	provider := repository.NewProvider(aPath, &version.Control{}, func(ctx context.Context, opts ...repository.Option) (*repository.Component, error) {
+		return component, nil
+	})
+	var authHeader, namePrefix string
+	route := &Route{
+		Path: &aPath,
+		Handler: func(ctx context.Context, response http.ResponseWriter, req *http.Request) {
+			authHeader = req.Header.Get("Authorization")
+			namePrefix = req.URL.Query().Get("namePrefix")
+			response.WriteHeader(http.StatusOK)
+			_, _ = response.Write([]byte(`[{"ID":1,"NAME":"Acme"},{"ID":2,"NAME":"Abc"},{"ID":3,"NAME":"Zeta"},{"ID":4,"NAME":"Acme"}]`))
+		},
+	}
+	registry := serverproto.NewRegistry()
+	completions := mcpext.NewCompletions()
+	router := &Router{mcpRegistry: registry, completions: completions}
+
+	err := router.buildPromptsIntegration(&dpath.Path{
+		Path: aPath,
+		ModelContextProtocol: contract.ModelContextProtocol{
+			MCPPrompts: []*contract.MCPPrompt{
+				{
+					Name:     "summarizeVendor",
+					Template: "Summarize orders for vendor {vendorName}",
+					Arguments: []*contract.MCPPromptArgument{
+						{Name: "vendorName", Required: true, Completion: &contract.MCPCompletion{Column: "name", Parameter: "namePrefix"}},
+					},
+				},
+			},
+		},
+		View: &dpath.ViewRef{Ref: "vendor"},
+	}, route, provider)
+	require.NoError(t, err)
+
+	entry, ok := registry.Prompts.Get("summarizeVendor")
+	require.True(t, ok)
+	require.Len(t, entry.Prompt.Arguments, 1)
+	assert.Equal(t, "vendorName", entry.Prompt.Arguments[0].Name)
+
+	result, rpcErr := entry.Handler(context.Background(), &schema.GetPromptRequestParams{Arguments: map[string]string{"vendorName": "Acme"}})
+	require.Nil(t, rpcErr)
+	require.Len(t, result.Messages, 1)
+	assert.Equal(t, "Summarize orders for vendor Acme", result.Messages[0].Content.(schema.TextContent).Text)
+
+	handler, ok := completions.Lookup(&schema.CompleteRequestParamsRef{Type: mcpext.RefPrompt, Name: "summarizeVendor"})
+	require.True(t, ok)
+	ctx := context.WithValue(context.Background(), authorization.TokenKey, &authorization.Token{Token: "abc"})
+	completion, rpcErr := handler(ctx, &schema.CompleteRequestParams{Argument: schema.CompleteRequestParamsArgument{Name: "vendorName", Value: "a"}})
+	require.Nil(t, rpcErr)
+	assert.Equal(t, []string{"Abc", "Acme"}, completion.Completion.Values)
+	assert.Equal(t, "Bearer abc", authHeader)
+	assert.Equal(t, "a", namePrefix)
+}
+
+func TestMCPPrompt_PromptArguments(t *testing.T) {
+	prompt := &contract.MCPPrompt{Template: "Orders for vendor {id} since { since }, vendor {id}"}
+	arguments := prompt.PromptArguments()
+	require.Len(t, arguments, 2)
+	assert.Equal(t, "id", arguments[0].Name)
+	assert.Equal(t, "since", arguments[1].Name)
+	assert.Equal(t, "Orders for vendor 1 since 2024, vendor 1", prompt.Render(map[string]string{"id": "1", "since": "2024"}))
+}
@@ -99,7 +99,7 @@ func TestRouter_buildToolInputType_FlattensAnonymousBody(t *testing.T) {
 		},
 	}
 
-	rType := (&Router{}).buildToolInputType(component)
+	rType := (&Router{}).buildToolInputType(context.Background(), component)
 	require.Equal(t, reflect.Struct, rType.Kind())
 	_, ok := rType.FieldByName("Report")
 	assert.False(t, ok)
@@ -555,7 +555,7 @@ func TestRouter_buildToolInputType_UsesParameterMetadataTags(t *testing.T) {
 		},
 	}
 
-	rType := (&Router{}).buildToolInputType(component)
+	rType := (&Router{}).buildToolInputType(context.Background(), component)
 	field, ok := rType.FieldByName("Field")
 	require.True(t, ok)
 	assert.Equal(t, "Targeting field key.", field.Tag.Get("description"))
@@ -617,7 +617,7 @@ func TestRouter_buildToolInputType_UsesBuiltReportComponentParameters(t *testing
 	require.NotNil(t, reportComponent)
 	require.Len(t, reportComponent.Input.Type.Parameters, 1)
 
-	rType := (&Router{}).buildToolInputType(reportComponent)
+	rType := (&Router{}).buildToolInputType(context.Background(), reportComponent)
 	require.Equal(t, reflect.Struct, rType.Kind())
 	_, ok := rType.FieldByName("Report")
 	assert.False(t, ok)
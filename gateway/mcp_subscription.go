@@ -0,0 +1,71 @@
+package gateway
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/viant/datly/repository"
+	"github.com/viant/datly/view"
+)
+
+// bindMcpResourceTables binds MCP resource URI to component view tables, thus executor writes refresh subscriptions
+func (r *Router) bindMcpResourceTables(URI string, provider *repository.Provider) {
+	if r.subscriptions == nil || provider == nil {
+		return
+	}
+	component, err := provider.Component(context.Background())
+	if err != nil || component.View == nil {
+		return
+	}
+	r.subscriptions.BindTables(URI, viewTables(component.View, nil)...)
+}
+
+// touchMcpSubscriptionsOnWrite refreshes subscribed resources reading tables modified by a writer route
+func (r *Router) touchMcpSubscriptionsOnWrite(aRoute *Route, provider *repository.Provider) {
+	handler := aRoute.Handler
+	subscriptions := r.subscriptions
+	aRoute.Handler = func(ctx context.Context, response http.ResponseWriter, req *http.Request) {
+		writer := &statusWriter{ResponseWriter: response, statusCode: http.StatusOK}
+		handler(ctx, writer, req)
+		if writer.statusCode < http.StatusOK || writer.statusCode >= http.StatusMultipleChoices {
+			return //failed write did not modify tables
+		}
+		component, err := provider.Component(ctx)
+		if err != nil || component.View == nil {
+			return
+		}
+		subscriptions.Touch(viewTables(component.View, nil)...)
+	}
+}
+
+func viewTables(aView *view.View, tables []string) []string {
+	if aView == nil {
+		return tables
+	}
+	if aView.Table != "" {
+		tables = append(tables, aView.Table)
+	}
+	for _, relation := range aView.With {
+		if relation.Of != nil {
+			tables = viewTables(&relation.Of.View, tables)
+		}
+	}
+	return tables
+}
+
+// statusWriter captures response status code
+type statusWriter struct {
+	http.ResponseWriter
+	statusCode int
+}
+
+func (w *statusWriter) WriteHeader(statusCode int) {
+	w.statusCode = statusCode
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *statusWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
@@ -3,7 +3,9 @@ package gateway
 import (
 	"context"
 	"encoding/json"
+	"hash/fnv"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -14,6 +16,7 @@ import (
 	"github.com/viant/datly/repository/contract"
 	"github.com/viant/datly/repository/logging"
 	"github.com/viant/datly/repository/path"
+	"github.com/viant/datly/view"
 	vcontext "github.com/viant/datly/view/context"
 	"github.com/viant/xdatly/handler/exec"
 
@@ -55,6 +58,7 @@ func (r *Route) Handle(res http.ResponseWriter, req *http.Request) int {
 	execContext := exec.NewContext(req.Method, req.RequestURI, req.Header, r.Version)
 	ctx = vcontext.WithValue(ctx, exec.ContextKey, execContext)
 	ctx = requesttrace.Ensure(ctx, execContext.TraceID)
+	ctx = view.WithConnectorSession(ctx, connectorSession(req, execContext.TraceID))
 	req = req.WithContext(ctx)
 	var onDone func(time.Time, ...interface{}) int64 = nil
 	var start time.Time
@@ -98,8 +102,7 @@ func (r *Route) Handle(res http.ResponseWriter, req *http.Request) int {
 
 func (r *Route) CanHandle(req *http.Request) bool {
 	for _, key := range r.ApiKeys {
-		actualValue := req.Header.Get(key.Header)
-		if key.Value != actualValue {
+		if !key.Matches(req.Header.Get(key.Header)) {
 			return false
 		}
 	}
@@ -196,3 +199,15 @@ func (h *HttpError) Message() string {
 	marshal, _ := json.Marshal(routesError)
 	return string(marshal)
 }
+
+// connectorSession returns caller identity used for read-your-writes pinning, requests without
+// credentials are pinned within the request only
+func connectorSession(req *http.Request, traceID string) string {
+	authorization := req.Header.Get("Authorization")
+	if authorization == "" {
+		return traceID
+	}
+	hash := fnv.New64a()
+	_, _ = hash.Write([]byte(authorization))
+	return strconv.FormatUint(hash.Sum64(), 36)
+}
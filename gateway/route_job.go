@@ -0,0 +1,111 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/viant/datly/gateway/router"
+	"github.com/viant/datly/repository/contract"
+	"github.com/viant/datly/service/jobs"
+	"github.com/viant/datly/view"
+	"github.com/viant/scy/auth/jwt"
+	"github.com/viant/xdatly/handler/async"
+)
+
+// JobTokenHeader defines request header carrying job access token, token is exposed to the submitter with job.token parameter
+const JobTokenHeader = "X-Datly-Job-Token"
+
+// NewJobRoutes creates durable job queue routes: GET returns job queue state, DELETE cancels job;
+// job with recorded principal is accessible only to the caller with matching JWT user id or email,
+// anonymous job only with its job token, job admin roles grant access to any job
+func (r *Router) NewJobRoutes(URI string) []*Route {
+	URI = strings.TrimRight(URI, "/")
+	handler := func(ctx context.Context, response http.ResponseWriter, req *http.Request) {
+		statusCode, content := r.handleJob(ctx, req, URI)
+		setContentType(response, statusCode, "application/json")
+		write(response, statusCode, content)
+	}
+	var routes []*Route
+	for _, method := range []string{http.MethodGet, http.MethodDelete} {
+		routes = append(routes, &Route{
+			Path:    contract.NewPath(method, URI+"/{id}"),
+			Handler: handler,
+			Config:  r.config.Logging,
+			Version: r.config.Version,
+		})
+	}
+	return routes
+}
+
+func (r *Router) handleJob(ctx context.Context, req *http.Request, URI string) (int, []byte) {
+	ID := strings.Trim(strings.TrimPrefix(req.URL.Path, URI), "/")
+	if ID == "" {
+		return http.StatusBadRequest, jobError(fmt.Errorf("job id was empty"))
+	}
+	for _, queue := range jobs.Queues() {
+		entry, err := queue.Entry(ctx, ID)
+		if err != nil {
+			return http.StatusInternalServerError, jobError(err)
+		}
+		if entry == nil {
+			continue
+		}
+		job, err := queue.Service().JobById(ctx, ID)
+		if err != nil {
+			return http.StatusInternalServerError, jobError(err)
+		}
+		if !r.canAccessJob(ID, job, req.Header.Get(JobTokenHeader), router.RequestClaims(ctx, req)) {
+			return http.StatusForbidden, jobError(fmt.Errorf("job %v access denied", ID))
+		}
+		if req.Method == http.MethodDelete {
+			cancelled, err := queue.Cancel(ctx, ID)
+			if err != nil {
+				return http.StatusInternalServerError, jobError(err)
+			}
+			if !cancelled {
+				return http.StatusConflict, jobError(fmt.Errorf("job %v can not be cancelled, status: %v", ID, entry.Status))
+			}
+			entry.Status = jobs.QueueStatusCancelled
+		}
+		data, err := json.Marshal(entry)
+		if err != nil {
+			return http.StatusInternalServerError, jobError(err)
+		}
+		return http.StatusOK, data
+	}
+	return http.StatusNotFound, jobError(fmt.Errorf("job %v not found", ID))
+}
+
+// canAccessJob returns true if caller presents valid job token, has job admin role or owns the job
+func (r *Router) canAccessJob(ID string, job *async.Job, token string, claims *jwt.Claims) bool {
+	if jobs.ValidToken(ID, token) {
+		return true
+	}
+	if claims == nil {
+		return false
+	}
+	if len(r.config.JobAdminRoles) > 0 && (&view.ColumnAuth{Roles: r.config.JobAdminRoles}).Allowed(view.NewGrants(claims.Scope)) {
+		return true
+	}
+	return isJobOwner(job, claims)
+}
+
+// isJobOwner returns true if job recorded principal matches caller claims, anonymous job has no owner
+func isJobOwner(job *async.Job, claims *jwt.Claims) bool {
+	if job == nil || claims == nil {
+		return false
+	}
+	if job.UserID != nil && *job.UserID != "" && *job.UserID == strconv.Itoa(claims.UserID) {
+		return true
+	}
+	return job.UserEmail != nil && *job.UserEmail != "" && strings.EqualFold(*job.UserEmail, claims.Email)
+}
+
+func jobError(err error) []byte {
+	data, _ := json.Marshal(map[string]string{"error": err.Error()})
+	return data
+}
@@ -0,0 +1,38 @@
+package gateway
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/datly/service/jobs"
+	"github.com/viant/scy/auth/jwt"
+	"github.com/viant/xdatly/handler/async"
+)
+
+func TestRouter_canAccessJob(t *testing.T) {
+	jobs.SetTokenSecret([]byte("secret"))
+	defer jobs.SetTokenSecret(nil)
+	email := "dev@viantinc.com"
+	owned := &async.Job{ID: "job-1", Principal: async.Principal{UserEmail: &email}}
+	anonymous := &async.Job{ID: "job-2"}
+	router := &Router{config: &Config{ExposableConfig: ExposableConfig{JobAdminRoles: []string{"admin"}}}}
+	var testCases = []struct {
+		description string
+		job         *async.Job
+		token       string
+		claims      *jwt.Claims
+		expect      bool
+	}{
+		{description: "owner", job: owned, claims: &jwt.Claims{Email: email}, expect: true},
+		{description: "other user", job: owned, claims: &jwt.Claims{Email: "other@viantinc.com"}, expect: false},
+		{description: "anonymous job without token", job: anonymous, expect: false},
+		{description: "anonymous job with any user", job: anonymous, claims: &jwt.Claims{Email: email}, expect: false},
+		{description: "anonymous job with token", job: anonymous, token: jobs.Token("job-2"), expect: true},
+		{description: "anonymous job with other job token", job: anonymous, token: jobs.Token("job-1"), expect: false},
+		{description: "admin", job: anonymous, claims: &jwt.Claims{Scope: "read admin"}, expect: true},
+	}
+	for _, testCase := range testCases {
+		actual := router.canAccessJob(testCase.job.ID, testCase.job, testCase.token, testCase.claims)
+		assert.Equal(t, testCase.expect, actual, testCase.description)
+	}
+}
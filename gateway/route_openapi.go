@@ -3,6 +3,7 @@ package gateway
 import (
 	"context"
 	"encoding/json"
+	"github.com/viant/datly/gateway/router"
 	"github.com/viant/datly/gateway/router/openapi"
 	"github.com/viant/datly/repository"
 	"github.com/viant/datly/repository/contract"
@@ -28,6 +29,7 @@ func (r *Router) NewOpenAPIRoute(URL string, components *repository.Service, pro
 }
 
 func (r *Router) handleOpenAPI(ctx context.Context, components *repository.Service, res http.ResponseWriter, request *http.Request, provider []*repository.Provider) {
+	ctx = router.RequestGrants(ctx, request)
 	statusCode, content := r.generateOpenAPI(ctx, components, provider)
 	setContentType(res, statusCode, "text/yaml")
 	write(res, statusCode, content)
@@ -68,6 +70,7 @@ func (r *Router) NewOpenAPIAggregateRoute(URL string, components *repository.Ser
 
 func (r *Router) handleOpenAPIAggregate(ctx context.Context, components *repository.Service, res http.ResponseWriter, request *http.Request, providers []*repository.Provider) {
 	asYAML := wantsYAML(request)
+	ctx = router.RequestGrants(ctx, request)
 	statusCode, content, contentType := r.generateOpenAPIWithFormat(ctx, components, providers, asYAML)
 	setContentType(res, statusCode, contentType)
 	write(res, statusCode, content)
@@ -0,0 +1,59 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/viant/datly/gateway/router"
+	"github.com/viant/datly/internal/queryplan"
+	"github.com/viant/datly/repository/contract"
+)
+
+// NewPlanRoutes creates slow query plan routes: {URI} returns recent plans, {URI}/{traceId} returns request trace plans;
+// routes require JWT authorization and plan arguments are redacted
+func (r *Router) NewPlanRoutes(URI string) []*Route {
+	URI = strings.TrimRight(URI, "/")
+	handler := func(ctx context.Context, response http.ResponseWriter, req *http.Request) {
+		statusCode, content := http.StatusUnauthorized, jobError(fmt.Errorf("query plans require authorization"))
+		if router.RequestClaims(ctx, req) != nil {
+			statusCode, content = r.handlePlans(req, URI)
+		}
+		setContentType(response, statusCode, "application/json")
+		write(response, statusCode, content)
+	}
+	var routes []*Route
+	for _, pathURI := range []string{URI, URI + "/{traceId}"} {
+		routes = append(routes, &Route{
+			Path:    contract.NewPath(http.MethodGet, pathURI),
+			Handler: handler,
+			Config:  r.config.Logging,
+			Version: r.config.Version,
+		})
+	}
+	return routes
+}
+
+func (r *Router) handlePlans(req *http.Request, URI string) (int, []byte) {
+	var plans []*queryplan.Plan
+	if traceID := strings.Trim(strings.TrimPrefix(req.URL.Path, URI), "/"); traceID != "" {
+		plans = queryplan.Default().ByTrace(traceID)
+	} else {
+		query := req.URL.Query()
+		limit, _ := strconv.Atoi(query.Get("limit"))
+		flagged, _ := strconv.ParseBool(query.Get("flagged"))
+		plans = queryplan.Default().Recent(limit, flagged)
+	}
+	redacted := make([]*queryplan.Plan, 0, len(plans))
+	for _, plan := range plans {
+		redacted = append(redacted, plan.Redacted())
+	}
+	data, err := json.Marshal(redacted)
+	if err != nil {
+		return http.StatusInternalServerError, jobError(err)
+	}
+	return http.StatusOK, data
+}
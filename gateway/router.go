@@ -11,10 +11,15 @@ import (
 	"github.com/viant/cloudless/gateway/matcher"
 	"github.com/viant/datly/gateway/router"
 	"github.com/viant/datly/gateway/router/openapi/openapi3"
+	"github.com/viant/datly/gateway/router/recording"
+	"github.com/viant/datly/gateway/rpc"
 	"github.com/viant/datly/gateway/runtime/meta"
 	"github.com/viant/datly/gateway/warmup"
+	mcpext "github.com/viant/datly/mcp/extension"
 	"github.com/viant/datly/repository"
 	"github.com/viant/datly/repository/contract"
+	"github.com/viant/datly/repository/drift"
+	"github.com/viant/datly/repository/locale"
 	"github.com/viant/datly/repository/path"
 	"github.com/viant/datly/service/operator"
 	"github.com/viant/datly/service/session"
@@ -47,8 +52,18 @@ type (
 		statusHandler http.Handler
 		paths         []*contract.Path
 		mcpRegistry   *serverproto.Registry
+		completions   *mcpext.Completions
+		subscriptions *mcpext.Subscriptions
+		toolSchemas   *mcpext.ToolSchemas
+		rpcRegistry   *rpc.Registry
+		rpcMethods    map[string]*rpc.Method
+		recording     *recording.Service
+		drift         *drift.Monitor
 	}
 
+	// RouterOption represents router option
+	RouterOption func(r *Router)
+
 	AvailableRoutesError struct {
 		Message string
 		Paths   []*contract.Path
@@ -72,8 +87,50 @@ func (a *AvailableRoutesError) Error() string {
 	return string(marshal)
 }
 
+// WithMCPCompletions sets MCP argument completion handlers
+func WithMCPCompletions(completions *mcpext.Completions) RouterOption {
+	return func(r *Router) {
+		r.completions = completions
+	}
+}
+
+// WithMCPSubscriptions sets MCP resource subscriptions tracker
+func WithMCPSubscriptions(subscriptions *mcpext.Subscriptions) RouterOption {
+	return func(r *Router) {
+		r.subscriptions = subscriptions
+	}
+}
+
+// WithMCPToolSchemas sets caller dependent MCP tool input schemas
+func WithMCPToolSchemas(toolSchemas *mcpext.ToolSchemas) RouterOption {
+	return func(r *Router) {
+		r.toolSchemas = toolSchemas
+	}
+}
+
+// WithRPCRegistry sets gRPC/Connect method registry
+func WithRPCRegistry(registry *rpc.Registry) RouterOption {
+	return func(r *Router) {
+		r.rpcRegistry = registry
+	}
+}
+
+// WithRecording sets request recording service
+func WithRecording(service *recording.Service) RouterOption {
+	return func(r *Router) {
+		r.recording = service
+	}
+}
+
+// WithDrift sets schema drift monitor
+func WithDrift(monitor *drift.Monitor) RouterOption {
+	return func(r *Router) {
+		r.drift = monitor
+	}
+}
+
 // NewRouter creates new router
-func NewRouter(ctx context.Context, components *repository.Service, config *Config, metrics *gmetric.Service, statusHandler http.Handler, mcpRegistry *serverproto.Registry) (*Router, error) {
+func NewRouter(ctx context.Context, components *repository.Service, config *Config, metrics *gmetric.Service, statusHandler http.Handler, mcpRegistry *serverproto.Registry, opts ...RouterOption) (*Router, error) {
 	r := &Router{
 		config:        config,
 		metrics:       metrics,
@@ -88,6 +145,9 @@ func NewRouter(ctx context.Context, components *repository.Service, config *Conf
 			Version: config.Version,
 		},
 	}
+	for _, opt := range opts {
+		opt(r)
+	}
 	return r, r.init(ctx)
 }
 
@@ -326,8 +386,18 @@ func (r *Router) extractCacheableViews(ctx context.Context, providers ...*reposi
 }
 
 func (r *Router) init(ctx context.Context) (err error) {
-	r.routeMatcher, r.paths, err = r.newMatcher(ctx)
-	return err
+	if URL := r.config.MessagesURL; URL != "" {
+		if err = locale.Messages().Load(ctx, fs, URL); err != nil {
+			return err
+		}
+	}
+	if r.routeMatcher, r.paths, err = r.newMatcher(ctx); err != nil {
+		return err
+	}
+	if r.rpcRegistry != nil {
+		r.rpcRegistry.Replace(r.rpcMethods)
+	}
+	return nil
 }
 
 func (r *Router) newMatcher(ctx context.Context) (*matcher.Matcher, []*contract.Path, error) {
@@ -379,7 +449,16 @@ func (r *Router) newMatcher(ctx context.Context) (*matcher.Matcher, []*contract.
 				}
 
 				r.EnsureCors(aPath)
-				aRoute := r.NewRouteHandler(router.New(aPath, provider, r.repository.Registry(), r.repository.Auth(), r.config.Version, r.config.Logging, r.logger))
+				handler := router.New(aPath, provider, r.repository.Registry(), r.repository.Auth(), r.config.Version, r.config.Logging, r.logger)
+				if r.config.Mock != nil {
+					handler.Mock = mock.New(r.config.Mock)
+				}
+				handler.Recording = r.recording
+				handler.Drift = r.drift
+				aRoute := r.NewRouteHandler(handler)
+				if r.subscriptions != nil && aPath.Method != http.MethodGet {
+					r.touchMcpSubscriptionsOnWrite(aRoute, provider)
+				}
 				routes = append(routes, aRoute)
 				if aPath.Cors != nil {
 					optionsPaths[aPath.URI] = append(optionsPaths[aPath.URI], aPath)
@@ -418,6 +497,17 @@ func (r *Router) newMatcher(ctx context.Context) (*matcher.Matcher, []*contract.
 							return nil, nil, fmt.Errorf("failed to build tool integration: %w", err)
 						}
 					}
+					if len(aPath.MCPPrompts) > 0 {
+						if err = r.buildPromptsIntegration(aPath, aRoute, provider); err != nil {
+							return nil, nil, fmt.Errorf("failed to build prompt integration: %w", err)
+						}
+					}
+				}
+
+				if r.rpcRegistry != nil {
+					if err = r.buildRPCIntegration(aPath, aRoute, provider); err != nil {
+						return nil, nil, fmt.Errorf("failed to build rpc integration: %w", err)
+					}
 				}
 
 				routes = append(routes, r.NewViewMetaHandler(r.routeURL(r.config.Meta.ViewURI, aPath.URI), provider))
@@ -473,6 +563,12 @@ func (r *Router) newMatcher(ctx context.Context) (*matcher.Matcher, []*contract.
 	if strings.TrimSpace(r.config.Meta.MetricURI) != "" {
 		routes = append(routes, r.NewGlobalMetricRoutes(r.config.Meta.MetricURI)...)
 	}
+	if strings.TrimSpace(r.config.Meta.JobURI) != "" {
+		routes = append(routes, r.NewJobRoutes(r.config.Meta.JobURI)...)
+	}
+	if strings.TrimSpace(r.config.Meta.PlanURI) != "" {
+		routes = append(routes, r.NewPlanRoutes(r.config.Meta.PlanURI)...)
+	}
 
 	matchables := make([]matcher.Matchable, 0, len(routes))
 	for _, route := range routes {
@@ -0,0 +1,37 @@
+package router
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/viant/datly/view"
+	"github.com/viant/datly/view/extension"
+	"github.com/viant/scy/auth/jwt"
+)
+
+// RequestGrants returns context with caller grants decoded from request JWT authorization,
+// it is used by routes that do not run component authentication, i.e. OpenAPI
+func RequestGrants(ctx context.Context, request *http.Request) context.Context {
+	if claims := RequestClaims(ctx, request); claims != nil {
+		return view.WithGrants(ctx, view.NewGrants(claims.Scope))
+	}
+	return ctx
+}
+
+// RequestClaims returns caller claims decoded from request JWT authorization or nil
+func RequestClaims(ctx context.Context, request *http.Request) *jwt.Claims {
+	authorization := request.Header.Get("Authorization")
+	if authorization == "" {
+		return nil
+	}
+	jwtCodec, _ := extension.Config.LookupCodec(extension.CodecKeyJwtClaim)
+	if jwtCodec == nil {
+		return nil
+	}
+	claim, err := jwtCodec.Instance.Value(ctx, authorization)
+	if err != nil {
+		return nil
+	}
+	claims, _ := claim.(*jwt.Claims)
+	return claims
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	goJson "encoding/json"
+	"errors"
 	"fmt"
 	"github.com/google/uuid"
 	"github.com/viant/afs"
@@ -12,11 +13,15 @@ import (
 	acontent "github.com/viant/afs/option/content"
 	"github.com/viant/afs/url"
 	"github.com/viant/datly/internal/requesttrace"
+	"github.com/viant/datly/gateway/router/mock"
 	"github.com/viant/datly/gateway/router/openapi"
+	"github.com/viant/datly/gateway/router/recording"
 	"github.com/viant/datly/gateway/router/status"
 	"github.com/viant/datly/repository"
 	"github.com/viant/datly/repository/content"
 	"github.com/viant/datly/repository/contract"
+	"github.com/viant/datly/repository/drift"
+	"github.com/viant/datly/repository/locale"
 	"github.com/viant/datly/repository/logging"
 	"github.com/viant/datly/repository/path"
 	"github.com/viant/datly/service"
@@ -58,6 +63,9 @@ type (
 		auth       *auth.Service
 		logging    logging.Config
 		logger     logger.Logger
+		Mock       *mock.Generator    //serves generated records and echoes validated executor input when set
+		Recording  *recording.Service //samples requests for replay when set
+		Drift      *drift.Monitor     //rejects requests to drifted routes in unhealthy mode
 	}
 )
 
@@ -74,6 +82,16 @@ func (r *Handler) HandleRequest(ctx context.Context, response http.ResponseWrite
 	if r.Path.Cors != nil {
 		CorsHandler(request, r.Path.Cors)(response)
 	}
+	if err = r.Drift.Err(r.Path.Method, r.Path.URI); err != nil {
+		httputils.WriteError(response, err)
+		return
+	}
+	if timeout := r.Path.Timeout(); timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+		request = request.WithContext(ctx)
+	}
 	r.Handle(ctx, response, request)
 
 }
@@ -84,7 +102,7 @@ func (r *Handler) AuthorizeRequest(request *http.Request, aPath *path.Path) erro
 		return nil
 	}
 	key := request.Header.Get(apiKey.Header)
-	if key != apiKey.Value {
+	if !apiKey.Matches(key) {
 		return response.NewError(http.StatusUnauthorized, "")
 	}
 
@@ -189,6 +207,8 @@ func (r *Handler) ServeHTTP(writer http.ResponseWriter, req *http.Request) {
 }
 
 func (r *Handler) Handle(ctx context.Context, writer http.ResponseWriter, request *http.Request) {
+	ctx, writer, recorded := r.startRecording(ctx, writer, request)
+	defer recorded()
 	aComponent, err := r.Provider.Component(ctx)
 	if err != nil {
 		http.Error(writer, err.Error(), http.StatusInternalServerError)
@@ -198,8 +218,13 @@ func (r *Handler) Handle(ctx context.Context, writer http.ResponseWriter, reques
 		http.Error(writer, "component not available", http.StatusServiceUnavailable)
 		return
 	}
+	ctx = locale.WithLocalizer(ctx, aComponent.Localizer(request))
+	ctx = r.withMock(ctx)
 	aResponse, err := r.safelyHandleComponent(ctx, request, aComponent)
 	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil && status.BudgetStatusCode(err) == 0 {
+			err = errors.Join(ctxErr, err)
+		}
 		r.writeErrorResponse(ctx, writer, aComponent, err, http.StatusBadRequest)
 		return
 	}
@@ -239,6 +264,9 @@ func extractPanicInfo(lines []string) interface{} {
 
 func (r *Handler) writeErrorResponse(ctx context.Context, w http.ResponseWriter, aComponent *repository.Component, err error, statusCode int) {
 	statusCode, message, anObjectErr := status.NormalizeErr(err, statusCode)
+	localizer := locale.LocalizerOf(ctx)
+	message = localizer.Message(message)
+	localizer.Localize(anObjectErr)
 	if statusCode < http.StatusBadRequest {
 		statusCode = http.StatusBadRequest
 	}
@@ -416,6 +444,13 @@ func (r *Handler) handleComponent(ctx context.Context, request *http.Request, aC
 	unmarshal := aComponent.UnmarshalFunc(request)
 	locatorOptions := append(aComponent.LocatorOptions(request, hstate.NewForm(), unmarshal))
 	locatorOptions = append(locatorOptions, locator.WithLogger(r.logger))
+	selectors, err := aComponent.HypermediaQuerySelectors(request)
+	if err != nil {
+		return nil, response.NewError(http.StatusBadRequest, err.Error(), response.WithError(err))
+	}
+	if len(selectors) > 0 {
+		locatorOptions = append(locatorOptions, locator.WithQuerySelectors(selectors))
+	}
 	aSession := session.New(aComponent.View,
 		session.WithAuth(r.auth),
 		session.WithLogger(r.logger),
@@ -425,7 +460,7 @@ func (r *Handler) handleComponent(ctx context.Context, request *http.Request, aC
 		session.WithRegistry(r.registry),
 
 		session.WithOperate(anOperator.Operate))
-	err := aSession.InitKinds(state.KindComponent, state.KindHeader, state.KindRequestBody, state.KindForm, state.KindQuery)
+	err = aSession.InitKinds(state.KindComponent, state.KindHeader, state.KindRequestBody, state.KindForm, state.KindQuery)
 	if err != nil {
 		return nil, err
 	}
@@ -435,7 +470,8 @@ func (r *Handler) handleComponent(ctx context.Context, request *http.Request, aC
 	if err := aSession.Populate(ctx); err != nil {
 		return nil, err
 	}
-	output, operationErr := r.dispatcher.Operate(ctx, aSession, aComponent)
+	output, operationErr := r.operate(ctx, aSession, aComponent)
+	r.recordInput(ctx, aComponent, aSession)
 	if operationErr != nil && output == nil {
 		return nil, operationErr
 	}
@@ -470,6 +506,7 @@ func (r *Handler) handleComponent(ctx context.Context, request *http.Request, aC
 
 	options := &response.Options{}
 	options.AdjustStatusCode(output, operationErr)
+	locale.LocalizerOf(ctx).Localize(output)
 	if output == nil {
 		return response.NewBuffered(options.Options()...), nil
 	}
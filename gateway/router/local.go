@@ -0,0 +1,38 @@
+package router
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/viant/datly/repository"
+	"github.com/viant/datly/repository/contract"
+	"github.com/viant/datly/repository/logging"
+	"github.com/viant/datly/repository/path"
+)
+
+// NewLocal creates handler for repository component matching method and URI, it is used to run components in process, i.e. by test and replay commands
+func NewLocal(ctx context.Context, service *repository.Service, method, URI string) (*Handler, *repository.Component, error) {
+	registry := service.Registry()
+	provider, err := registry.LookupProvider(ctx, &contract.Path{Method: method, URI: URI})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to locate component for %v %v: %w", method, URI, err)
+	}
+	component, err := provider.Component(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	return New(lookupPath(service, component), provider, registry, service.Auth(), "", logging.Config{}, nil), component, nil
+}
+
+func lookupPath(service *repository.Service, component *repository.Component) *path.Path {
+	if container := service.Container(); container != nil {
+		for _, item := range container.Items {
+			for _, candidate := range item.Paths {
+				if candidate.Method == component.Method && candidate.URI == component.URI {
+					return candidate
+				}
+			}
+		}
+	}
+	return &path.Path{Path: component.Path}
+}
@@ -0,0 +1,32 @@
+package hypermedia
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/viant/datly/repository/content"
+)
+
+func init() {
+	for _, name := range []string{JSONAPIFormat, HALFormat} {
+		if err := content.RegisterFormat(NewFormat(name)); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// NewFormat creates content format rendering component output as hypermedia document, component format resource has to be *Resource
+func NewFormat(name string) *content.Format {
+	name = strings.ToLower(name)
+	return &content.Format{
+		Name:         name,
+		ContentTypes: []string{ContentType(name)},
+		ComponentMarshaller: func(component *content.FormatComponent) (content.RequestMarshaller, error) {
+			resource, ok := component.Resource.(*Resource)
+			if !ok || resource == nil {
+				return nil, fmt.Errorf("%v resource was empty", name)
+			}
+			return New(name, resource, component.JSON), nil
+		},
+	}
+}
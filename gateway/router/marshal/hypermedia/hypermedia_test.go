@@ -0,0 +1,113 @@
+package hypermedia
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/viant/datly/repository/content"
+)
+
+type testDept struct {
+	ID   int
+	Name string
+}
+
+type testEmp struct {
+	ID     int
+	Name   string
+	DeptID int
+	Dept   *testDept
+}
+
+type testOutput struct {
+	Data   []*testEmp
+	Status string `json:"status"`
+}
+
+func testResource() *Resource {
+	dept := &Resource{Type: "dept", Key: "ID", Attributes: []*Attribute{{Name: "ID", Field: "ID"}, {Name: "NAME", Field: "Name"}}}
+	return &Resource{
+		Type:       "emp",
+		Key:        "ID",
+		Limit:      2,
+		Attributes: []*Attribute{{Name: "ID", Field: "ID"}, {Name: "NAME", Field: "Name"}, {Name: "DEPT_ID", Field: "DeptID"}},
+		Relations:  []*Relation{{Name: "Dept", Resource: dept}},
+	}
+}
+
+func TestQuerySelectors(t *testing.T) {
+	query, err := url.ParseQuery("fields[emp]=name&include=dept&fields[dept]=name&sort=-name,id&page[limit]=10&page[offset]=20")
+	require.NoError(t, err)
+	selectors, err := QuerySelectors(testResource(), query)
+	require.NoError(t, err)
+
+	emp := selectors.Find("emp")
+	require.NotNil(t, emp)
+	assert.Equal(t, []string{"NAME", "Dept"}, emp.Fields)
+	assert.Equal(t, "NAME desc,ID asc", emp.OrderBy)
+	assert.Equal(t, 10, emp.Limit)
+	assert.Equal(t, 20, emp.Offset)
+	dept := selectors.Find("dept")
+	require.NotNil(t, dept)
+	assert.Equal(t, []string{"NAME"}, dept.Fields)
+
+	query, _ = url.ParseQuery("include=")
+	selectors, err = QuerySelectors(testResource(), query)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ID", "NAME", "DEPT_ID"}, selectors.Find("emp").Fields)
+
+	for _, invalid := range []string{"fields[x]=id", "fields[emp]=salary", "include=manager", "sort=salary", "page[limit]=abc"} {
+		query, _ = url.ParseQuery(invalid)
+		_, err = QuerySelectors(testResource(), query)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestMarshaller_JSONAPI(t *testing.T) {
+	dept := &testDept{ID: 10, Name: "Sales"}
+	output := &testOutput{Status: "ok", Data: []*testEmp{
+		{ID: 1, Name: "Ann", DeptID: 10, Dept: dept},
+		{ID: 2, Name: "Bob", DeptID: 10, Dept: dept},
+	}}
+	request, _ := http.NewRequest(http.MethodGet, "http://localhost/v1/api/emp?_format=jsonapi", nil)
+	data, err := New(JSONAPIFormat, testResource(), nil).Marshal(output, "Data", request)
+	require.NoError(t, err)
+	expected := `{"data":[` +
+		`{"type":"emp","id":"1","attributes":{"Name":"Ann","DeptID":10},"relationships":{"Dept":{"data":{"type":"dept","id":"10"}}}},` +
+		`{"type":"emp","id":"2","attributes":{"Name":"Bob","DeptID":10},"relationships":{"Dept":{"data":{"type":"dept","id":"10"}}}}],` +
+		`"included":[{"type":"dept","id":"10","attributes":{"Name":"Sales"}}],` +
+		`"links":{"self":"/v1/api/emp?_format=jsonapi","first":"/v1/api/emp?_format=jsonapi&page%5Blimit%5D=2&page%5Boffset%5D=0","next":"/v1/api/emp?_format=jsonapi&page%5Blimit%5D=2&page%5Boffset%5D=2"},` +
+		`"meta":{"page":{"limit":2,"offset":0,"size":2},"status":"ok"},"jsonapi":{"version":"1.1"}}`
+	assert.JSONEq(t, expected, string(data))
+}
+
+func TestMarshaller_HAL(t *testing.T) {
+	output := []*testEmp{{ID: 1, Name: "Ann", DeptID: 10, Dept: &testDept{ID: 10, Name: "Sales"}}}
+	request, _ := http.NewRequest(http.MethodGet, "http://localhost/v1/api/emp?_limit=1&_offset=1", nil)
+	data, err := New(HALFormat, testResource(), nil).Marshal(output, "", request)
+	require.NoError(t, err)
+	expected := `{"_links":{"self":{"href":"/v1/api/emp?_limit=1&_offset=1"},"first":{"href":"/v1/api/emp?_limit=1&_offset=0"},"prev":{"href":"/v1/api/emp?_limit=1&_offset=0"},"next":{"href":"/v1/api/emp?_limit=1&_offset=2"}},` +
+		`"_embedded":{"emp":[{"ID":1,"Name":"Ann","DeptID":10,"_embedded":{"Dept":{"ID":10,"Name":"Sales"}}}]},` +
+		`"page":{"limit":1,"offset":1,"size":1}}`
+	assert.JSONEq(t, expected, string(data))
+}
+
+func TestNewFormat(t *testing.T) {
+	format, ok := content.Formats().Lookup(JSONAPIFormat)
+	require.True(t, ok)
+	assert.Equal(t, JSONAPIContentType, format.ContentType())
+	format, ok = content.Formats().LookupContentType(HALContentType)
+	require.True(t, ok)
+	assert.Equal(t, HALFormat, format.Name)
+
+	_, err := format.ComponentMarshaller(&content.FormatComponent{})
+	assert.Error(t, err)
+	marshaller, err := format.ComponentMarshaller(&content.FormatComponent{Resource: testResource()})
+	require.NoError(t, err)
+	data, err := marshaller.Marshal([]*testEmp{{ID: 1, Name: "John"}}, "", nil)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"_embedded"`)
+}
@@ -0,0 +1,480 @@
+package hypermedia
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const jsonAPIVersion = "1.1"
+
+type (
+	// Marshaller renders reader output as JSON:API or HAL document
+	Marshaller struct {
+		format   string
+		resource *Resource
+		marshal  func(src interface{}) ([]byte, error)
+	}
+
+	document struct {
+		*Marshaller
+		includes map[string]bool
+		included []json.RawMessage
+		seen     map[string]bool
+	}
+
+	object struct {
+		keys   []string
+		values []json.RawMessage
+	}
+)
+
+// New creates hypermedia marshaller, marshal renders individual records so that attributes follow component JSON settings
+func New(format string, resource *Resource, marshal func(src interface{}) ([]byte, error)) *Marshaller {
+	if marshal == nil {
+		marshal = json.Marshal
+	}
+	return &Marshaller{format: strings.ToLower(format), resource: resource, marshal: marshal}
+}
+
+// Marshal renders output, field is output data field name, empty if output is data itself
+func (m *Marshaller) Marshal(src interface{}, field string, request *http.Request) ([]byte, error) {
+	if m.resource == nil {
+		return nil, fmt.Errorf("hypermedia resource was empty")
+	}
+	var query url.Values
+	if request != nil && request.URL != nil {
+		query = request.URL.Query()
+	}
+	aPage, err := pageOf(query, m.format, m.resource.Limit)
+	if err != nil {
+		return nil, err
+	}
+	doc := &document{Marshaller: m, seen: map[string]bool{}}
+	if values, ok := query[IncludeQuery]; ok && m.format == JSONAPIFormat {
+		if doc.includes, err = includePaths(m.resource, splitValues(values)); err != nil {
+			return nil, err
+		}
+	}
+	data, meta, err := m.split(src, field)
+	if err != nil {
+		return nil, err
+	}
+	size := 0
+	if data.IsValid() && data.Kind() == reflect.Slice {
+		size = data.Len()
+	}
+	pageInfo := &object{}
+	if aPage.limit > 0 {
+		pageInfo.put("limit", []byte(strconv.Itoa(aPage.limit)))
+	}
+	pageInfo.put("offset", []byte(strconv.Itoa(aPage.offset)))
+	pageInfo.put("size", []byte(strconv.Itoa(size)))
+	links := m.links(request, aPage, size)
+	if m.format == HALFormat {
+		return doc.hal(data, meta, links, pageInfo)
+	}
+	return doc.jsonAPI(data, meta, links, pageInfo)
+}
+
+// split returns output data and remaining output fields
+func (m *Marshaller) split(src interface{}, field string) (reflect.Value, *object, error) {
+	meta := &object{}
+	rValue := reflect.ValueOf(src)
+	if field == "" {
+		return rValue, meta, nil
+	}
+	for rValue.IsValid() && rValue.Kind() == reflect.Ptr {
+		if rValue.IsNil() {
+			return reflect.Value{}, meta, nil
+		}
+		rValue = rValue.Elem()
+	}
+	if !rValue.IsValid() || rValue.Kind() != reflect.Struct {
+		return rValue, meta, nil
+	}
+	rType := rValue.Type()
+	var data reflect.Value
+	for i := 0; i < rType.NumField(); i++ {
+		aField := rType.Field(i)
+		if aField.PkgPath != "" {
+			continue
+		}
+		if aField.Name == field {
+			data = rValue.Field(i)
+			continue
+		}
+		value := rValue.Field(i)
+		if value.IsZero() {
+			continue
+		}
+		name, ok := jsonName(aField)
+		if !ok {
+			continue
+		}
+		encoded, err := m.marshal(value.Interface())
+		if err != nil {
+			return data, nil, err
+		}
+		meta.put(name, encoded)
+	}
+	return data, meta, nil
+}
+
+func (d *document) jsonAPI(data reflect.Value, meta, links, pageInfo *object) ([]byte, error) {
+	ret := &object{}
+	primary, err := d.primaryData(data)
+	if err != nil {
+		return nil, err
+	}
+	ret.put("data", primary)
+	if len(d.included) > 0 || d.includes != nil {
+		included := d.included
+		if included == nil {
+			included = []json.RawMessage{}
+		}
+		ret.put("included", encode(included))
+	}
+	if links != nil {
+		ret.put("links", encode(links))
+	}
+	meta.keys = append([]string{"page"}, meta.keys...)
+	meta.values = append([]json.RawMessage{encode(pageInfo)}, meta.values...)
+	ret.put("meta", encode(meta))
+	ret.put("jsonapi", encode(map[string]string{"version": jsonAPIVersion}))
+	return ret.MarshalJSON()
+}
+
+func (d *document) primaryData(data reflect.Value) (json.RawMessage, error) {
+	data = indirect(data)
+	if !data.IsValid() {
+		return []byte("null"), nil
+	}
+	if data.Kind() != reflect.Slice {
+		return d.resourceObject(d.resource, data, "")
+	}
+	for i := 0; i < data.Len(); i++ { //primary resources are never repeated in included
+		if record := indirect(data.Index(i)); record.IsValid() {
+			d.seen[d.resource.Type+"/"+d.resource.id(record)] = true
+		}
+	}
+	var items = make([]json.RawMessage, 0, data.Len())
+	for i := 0; i < data.Len(); i++ {
+		item, err := d.resourceObject(d.resource, data.Index(i), "")
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return encode(items), nil
+}
+
+func (d *document) resourceObject(resource *Resource, record reflect.Value, aPath string) (json.RawMessage, error) {
+	record = indirect(record)
+	if !record.IsValid() {
+		return []byte("null"), nil
+	}
+	fields, err := d.fields(record)
+	if err != nil {
+		return nil, err
+	}
+	ret := &object{}
+	ret.put("type", encode(resource.Type))
+	ret.put("id", encode(resource.id(record)))
+	attributes := &object{}
+	relationships := &object{}
+	for i, key := range fields.keys {
+		if relation := resource.Relation(key); relation != nil {
+			relPath := joinPath(aPath, normalize(relation.Name))
+			if d.includes != nil && !d.includes[relPath] {
+				continue
+			}
+			linkage, err := d.relationship(relation, record.FieldByName(relation.Name), relPath)
+			if err != nil {
+				return nil, err
+			}
+			relationships.put(key, linkage)
+			continue
+		}
+		if resource.isKey(key) {
+			continue
+		}
+		attributes.put(key, fields.values[i])
+	}
+	ret.put("attributes", encode(attributes))
+	if len(relationships.keys) > 0 {
+		ret.put("relationships", encode(relationships))
+	}
+	return encode(ret), nil
+}
+
+func (d *document) relationship(relation *Relation, value reflect.Value, aPath string) (json.RawMessage, error) {
+	ret := &object{}
+	value = indirect(value)
+	if !value.IsValid() {
+		if relation.Many {
+			ret.put("data", []byte("[]"))
+		} else {
+			ret.put("data", []byte("null"))
+		}
+		return encode(ret), nil
+	}
+	if value.Kind() != reflect.Slice {
+		identifier, err := d.include(relation.Resource, value, aPath)
+		if err != nil {
+			return nil, err
+		}
+		ret.put("data", identifier)
+		return encode(ret), nil
+	}
+	var identifiers = make([]json.RawMessage, 0, value.Len())
+	for i := 0; i < value.Len(); i++ {
+		identifier, err := d.include(relation.Resource, value.Index(i), aPath)
+		if err != nil {
+			return nil, err
+		}
+		identifiers = append(identifiers, identifier)
+	}
+	ret.put("data", encode(identifiers))
+	return encode(ret), nil
+}
+
+// include adds related resource to included section once per type and id, it returns resource identifier
+func (d *document) include(resource *Resource, record reflect.Value, aPath string) (json.RawMessage, error) {
+	record = indirect(record)
+	if !record.IsValid() {
+		return []byte("null"), nil
+	}
+	id := resource.id(record)
+	identifier := &object{}
+	identifier.put("type", encode(resource.Type))
+	identifier.put("id", encode(id))
+	key := resource.Type + "/" + id
+	if !d.seen[key] {
+		d.seen[key] = true
+		item, err := d.resourceObject(resource, record, aPath)
+		if err != nil {
+			return nil, err
+		}
+		d.included = append(d.included, item)
+	}
+	return encode(identifier), nil
+}
+
+func (d *document) hal(data reflect.Value, meta, links, pageInfo *object) ([]byte, error) {
+	data = indirect(data)
+	ret := &object{}
+	if data.IsValid() && data.Kind() != reflect.Slice {
+		item, err := d.halObject(d.resource, data)
+		if err != nil {
+			return nil, err
+		}
+		ret = item
+	}
+	if links != nil {
+		ret.keys = append([]string{"_links"}, ret.keys...)
+		ret.values = append([]json.RawMessage{encode(links)}, ret.values...)
+	}
+	if !data.IsValid() || data.Kind() == reflect.Slice {
+		var items = []json.RawMessage{}
+		for i := 0; data.IsValid() && i < data.Len(); i++ {
+			item, err := d.halObject(d.resource, data.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, encode(item))
+		}
+		embedded := &object{}
+		embedded.put(d.resource.Type, encode(items))
+		ret.put("_embedded", encode(embedded))
+		ret.put("page", encode(pageInfo))
+	}
+	for i, key := range meta.keys {
+		ret.put(key, meta.values[i])
+	}
+	return ret.MarshalJSON()
+}
+
+func (d *document) halObject(resource *Resource, record reflect.Value) (*object, error) {
+	record = indirect(record)
+	if !record.IsValid() {
+		return &object{}, nil
+	}
+	fields, err := d.fields(record)
+	if err != nil {
+		return nil, err
+	}
+	ret := &object{}
+	embedded := &object{}
+	for i, key := range fields.keys {
+		relation := resource.Relation(key)
+		if relation == nil {
+			ret.put(key, fields.values[i])
+			continue
+		}
+		value := indirect(record.FieldByName(relation.Name))
+		if !value.IsValid() {
+			continue
+		}
+		if value.Kind() != reflect.Slice {
+			item, err := d.halObject(relation.Resource, value)
+			if err != nil {
+				return nil, err
+			}
+			embedded.put(key, encode(item))
+			continue
+		}
+		var items = make([]json.RawMessage, 0, value.Len())
+		for j := 0; j < value.Len(); j++ {
+			item, err := d.halObject(relation.Resource, value.Index(j))
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, encode(item))
+		}
+		embedded.put(key, encode(items))
+	}
+	if len(embedded.keys) > 0 {
+		ret.put("_embedded", encode(embedded))
+	}
+	return ret, nil
+}
+
+// fields returns record JSON members in marshalling order
+func (d *document) fields(record reflect.Value) (*object, error) {
+	var src interface{}
+	if record.CanAddr() {
+		src = record.Addr().Interface()
+	} else {
+		src = record.Interface()
+	}
+	data, err := d.marshal(src)
+	if err != nil {
+		return nil, err
+	}
+	return parseObject(data)
+}
+
+func (m *Marshaller) links(request *http.Request, aPage *page, size int) *object {
+	if request == nil || request.URL == nil {
+		return nil
+	}
+	ret := &object{}
+	ret.put("self", m.link(request.URL.RequestURI()))
+	if aPage.limit == 0 {
+		return ret
+	}
+	ret.put("first", m.link(m.pageURI(request.URL, 0, aPage.limit)))
+	if aPage.offset > 0 {
+		prev := aPage.offset - aPage.limit
+		if prev < 0 {
+			prev = 0
+		}
+		ret.put("prev", m.link(m.pageURI(request.URL, prev, aPage.limit)))
+	}
+	if size >= aPage.limit {
+		ret.put("next", m.link(m.pageURI(request.URL, aPage.offset+aPage.limit, aPage.limit)))
+	}
+	return ret
+}
+
+func (m *Marshaller) link(URI string) json.RawMessage {
+	if m.format == HALFormat {
+		return encode(map[string]string{"href": URI})
+	}
+	return encode(URI)
+}
+
+func (m *Marshaller) pageURI(URL *url.URL, offset, limit int) string {
+	query := URL.Query()
+	if m.format == HALFormat {
+		query.Del("_page")
+		query.Set("_offset", strconv.Itoa(offset))
+		query.Set("_limit", strconv.Itoa(limit))
+	} else {
+		query.Del(pageKey(pageNumber))
+		query.Del(pageKey(pageSize))
+		query.Set(pageKey(pageOffset), strconv.Itoa(offset))
+		query.Set(pageKey(pageLimit), strconv.Itoa(limit))
+	}
+	ret := *URL
+	ret.RawQuery = query.Encode()
+	return ret.RequestURI()
+}
+
+func (o *object) put(key string, value json.RawMessage) {
+	o.keys = append(o.keys, key)
+	o.values = append(o.values, value)
+}
+
+// MarshalJSON marshals object preserving member order
+func (o *object) MarshalJSON() ([]byte, error) {
+	buffer := bytes.Buffer{}
+	buffer.WriteByte('{')
+	for i, key := range o.keys {
+		if i > 0 {
+			buffer.WriteByte(',')
+		}
+		buffer.Write(encode(key))
+		buffer.WriteByte(':')
+		buffer.Write(o.values[i])
+	}
+	buffer.WriteByte('}')
+	return buffer.Bytes(), nil
+}
+
+func parseObject(data []byte) (*object, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '{' {
+		return nil, fmt.Errorf("expected JSON object, but had: %s", data)
+	}
+	ret := &object{}
+	for decoder.More() {
+		token, err = decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		key, _ := token.(string)
+		var value json.RawMessage
+		if err = decoder.Decode(&value); err != nil {
+			return nil, err
+		}
+		ret.put(key, value)
+	}
+	return ret, nil
+}
+
+func encode(value interface{}) json.RawMessage {
+	data, _ := json.Marshal(value)
+	return data
+}
+
+func indirect(value reflect.Value) reflect.Value {
+	for value.IsValid() && (value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface) {
+		if value.IsNil() {
+			return reflect.Value{}
+		}
+		value = value.Elem()
+	}
+	return value
+}
+
+func jsonName(field reflect.StructField) (string, bool) {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", false
+	}
+	if name := strings.Split(tag, ",")[0]; name != "" {
+		return name, true
+	}
+	return field.Name, true
+}
@@ -0,0 +1,259 @@
+package hypermedia
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	hstate "github.com/viant/xdatly/handler/state"
+)
+
+const (
+	FieldsQuery  = "fields"
+	IncludeQuery = "include"
+	SortQuery    = "sort"
+	PageQuery    = "page"
+
+	pageLimit  = "limit"
+	pageSize   = "size"
+	pageOffset = "offset"
+	pageNumber = "number"
+)
+
+type selectorBuilder struct {
+	selectors []*hstate.NamedQuerySelector
+}
+
+func (b *selectorBuilder) selector(name string) *hstate.NamedQuerySelector {
+	for _, candidate := range b.selectors {
+		if candidate.Name == name {
+			return candidate
+		}
+	}
+	ret := &hstate.NamedQuerySelector{Name: name}
+	b.selectors = append(b.selectors, ret)
+	return ret
+}
+
+// QuerySelectors maps JSON:API fields[type], include, sort and page[...] query parameters onto view query selectors
+func QuerySelectors(resource *Resource, query url.Values) (hstate.QuerySelectors, error) {
+	if resource == nil {
+		return nil, nil
+	}
+	builder := &selectorBuilder{}
+	resources := map[string]*Resource{}
+	resource.index(resources)
+	for key, values := range query {
+		typeName, ok := bracketKey(key, FieldsQuery)
+		if !ok {
+			continue
+		}
+		target, ok := resources[typeName]
+		if !ok {
+			return nil, fmt.Errorf("unknown resource type: %v", typeName)
+		}
+		selector := builder.selector(target.Type)
+		for _, name := range splitValues(values) {
+			if relation := target.Relation(name); relation != nil {
+				selector.Fields = append(selector.Fields, relation.Name)
+				continue
+			}
+			attribute := target.Attribute(name)
+			if attribute == nil {
+				return nil, fmt.Errorf("unknown %v field: %v", target.Type, name)
+			}
+			selector.Fields = append(selector.Fields, attribute.Name)
+		}
+	}
+	if values, ok := query[IncludeQuery]; ok {
+		includes, err := includePaths(resource, splitValues(values))
+		if err != nil {
+			return nil, err
+		}
+		resource.include("", includes, builder)
+	}
+	if values := splitValues(query[SortQuery]); len(values) > 0 {
+		orderBy, err := resource.orderBy(values)
+		if err != nil {
+			return nil, err
+		}
+		builder.selector(resource.Type).OrderBy = orderBy
+	}
+	if err := pageSelector(query, builder, resource); err != nil {
+		return nil, err
+	}
+	return builder.selectors, nil
+}
+
+func (r *Resource) include(prefix string, includes map[string]bool, builder *selectorBuilder) {
+	if len(r.Relations) == 0 {
+		return
+	}
+	var holders []string
+	for _, relation := range r.Relations {
+		aPath := joinPath(prefix, normalize(relation.Name))
+		if !includes[aPath] {
+			continue
+		}
+		holders = append(holders, relation.Name)
+		relation.Resource.include(aPath, includes, builder)
+	}
+	selector := builder.selector(r.Type)
+	if len(selector.Fields) == 0 {
+		selector.Fields = r.columns()
+	}
+	for _, holder := range holders {
+		if !containsField(selector.Fields, holder) {
+			selector.Fields = append(selector.Fields, holder)
+		}
+	}
+}
+
+func (r *Resource) orderBy(values []string) (string, error) {
+	var items []string
+	for _, value := range values {
+		direction := "asc"
+		if strings.HasPrefix(value, "-") {
+			direction = "desc"
+			value = value[1:]
+		}
+		attribute := r.Attribute(value)
+		if attribute == nil {
+			return "", fmt.Errorf("unknown %v sort field: %v", r.Type, value)
+		}
+		items = append(items, attribute.Name+" "+direction)
+	}
+	return strings.Join(items, ","), nil
+}
+
+func pageSelector(query url.Values, builder *selectorBuilder, resource *Resource) error {
+	page, err := pageOf(query, JSONAPIFormat, 0)
+	if err != nil {
+		return err
+	}
+	if page.limit == 0 && page.offset == 0 && page.number == 0 {
+		return nil
+	}
+	selector := builder.selector(resource.Type)
+	selector.Limit = page.limit
+	if selector.Page = page.number; page.number == 0 {
+		selector.Offset = page.offset
+	}
+	return nil
+}
+
+// includePaths returns normalized include paths, including implicit intermediate paths
+func includePaths(resource *Resource, values []string) (map[string]bool, error) {
+	var ret = map[string]bool{}
+	for _, value := range values {
+		current := resource
+		aPath := ""
+		for _, name := range strings.Split(value, ".") {
+			relation := current.Relation(name)
+			if relation == nil {
+				return nil, fmt.Errorf("unknown %v relationship: %v", current.Type, name)
+			}
+			aPath = joinPath(aPath, normalize(relation.Name))
+			ret[aPath] = true
+			current = relation.Resource
+		}
+	}
+	return ret, nil
+}
+
+type page struct {
+	limit  int
+	offset int
+	number int
+}
+
+func pageOf(query url.Values, format string, defaultLimit int) (*page, error) {
+	ret := &page{}
+	var err error
+	switch format {
+	case HALFormat:
+		if ret.limit, err = intValue(query, "_limit"); err != nil {
+			return nil, err
+		}
+		if ret.offset, err = intValue(query, "_offset"); err != nil {
+			return nil, err
+		}
+		if ret.number, err = intValue(query, "_page"); err != nil {
+			return nil, err
+		}
+	default:
+		if ret.limit, err = intValue(query, pageKey(pageLimit)); err != nil {
+			return nil, err
+		}
+		if ret.limit == 0 {
+			if ret.limit, err = intValue(query, pageKey(pageSize)); err != nil {
+				return nil, err
+			}
+		}
+		if ret.offset, err = intValue(query, pageKey(pageOffset)); err != nil {
+			return nil, err
+		}
+		if ret.number, err = intValue(query, pageKey(pageNumber)); err != nil {
+			return nil, err
+		}
+	}
+	if ret.limit == 0 {
+		ret.limit = defaultLimit
+	}
+	if ret.number > 0 && ret.offset == 0 {
+		ret.offset = (ret.number - 1) * ret.limit
+	}
+	return ret, nil
+}
+
+func intValue(query url.Values, key string) (int, error) {
+	value := strings.TrimSpace(query.Get(key))
+	if value == "" {
+		return 0, nil
+	}
+	ret, err := strconv.Atoi(value)
+	if err != nil || ret < 0 {
+		return 0, fmt.Errorf("invalid %v value: %v", key, value)
+	}
+	return ret, nil
+}
+
+func pageKey(name string) string {
+	return PageQuery + "[" + name + "]"
+}
+
+func bracketKey(key, prefix string) (string, bool) {
+	if !strings.HasPrefix(key, prefix+"[") || !strings.HasSuffix(key, "]") {
+		return "", false
+	}
+	return key[len(prefix)+1 : len(key)-1], true
+}
+
+func splitValues(values []string) []string {
+	var ret []string
+	for _, value := range values {
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				ret = append(ret, item)
+			}
+		}
+	}
+	return ret
+}
+
+func joinPath(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
+
+func containsField(fields []string, name string) bool {
+	for _, field := range fields {
+		if field == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,122 @@
+package hypermedia
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+const (
+	JSONAPIFormat      = "jsonapi"
+	HALFormat          = "hal"
+	JSONAPIContentType = "application/vnd.api+json"
+	HALContentType     = "application/hal+json"
+)
+
+type (
+	// Resource represents view as hypermedia resource
+	Resource struct {
+		Type       string //resource type, view name
+		Key        string //struct field holding resource identifier
+		Limit      int    //view default page size
+		Attributes []*Attribute
+		Relations  []*Relation
+	}
+
+	// Attribute represents resource attribute
+	Attribute struct {
+		Name  string //view column name
+		Field string //struct field name
+	}
+
+	// Relation represents resource relationship
+	Relation struct {
+		Name     string //holder struct field name
+		Many     bool
+		Resource *Resource
+	}
+)
+
+// IsFormat returns true for hypermedia formats
+func IsFormat(format string) bool {
+	switch strings.ToLower(format) {
+	case JSONAPIFormat, HALFormat:
+		return true
+	}
+	return false
+}
+
+// ContentType returns hypermedia format content type
+func ContentType(format string) string {
+	if strings.ToLower(format) == HALFormat {
+		return HALContentType
+	}
+	return JSONAPIContentType
+}
+
+// Attribute returns attribute matching output or column name
+func (r *Resource) Attribute(name string) *Attribute {
+	key := normalize(name)
+	for _, attribute := range r.Attributes {
+		if normalize(attribute.Name) == key || normalize(attribute.Field) == key {
+			return attribute
+		}
+	}
+	return nil
+}
+
+// Relation returns relation matching output or holder name
+func (r *Resource) Relation(name string) *Relation {
+	key := normalize(name)
+	for _, relation := range r.Relations {
+		if normalize(relation.Name) == key {
+			return relation
+		}
+	}
+	return nil
+}
+
+func (r *Resource) columns() []string {
+	var ret = make([]string, 0, len(r.Attributes))
+	for _, attribute := range r.Attributes {
+		ret = append(ret, attribute.Name)
+	}
+	return ret
+}
+
+func (r *Resource) index(dest map[string]*Resource) {
+	if _, ok := dest[r.Type]; ok {
+		return
+	}
+	dest[r.Type] = r
+	for _, relation := range r.Relations {
+		relation.Resource.index(dest)
+	}
+}
+
+func (r *Resource) id(record reflect.Value) string {
+	if r.Key == "" {
+		return ""
+	}
+	value := record.FieldByName(r.Key)
+	for value.IsValid() && value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return ""
+		}
+		value = value.Elem()
+	}
+	if !value.IsValid() {
+		return ""
+	}
+	return fmt.Sprint(value.Interface())
+}
+
+func (r *Resource) isKey(name string) bool {
+	return r.Key != "" && normalize(r.Key) == normalize(name)
+}
+
+func normalize(name string) string {
+	name = strings.ReplaceAll(name, "_", "")
+	name = strings.ReplaceAll(name, "-", "")
+	return strings.ToLower(name)
+}
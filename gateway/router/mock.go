@@ -0,0 +1,32 @@
+package router
+
+import (
+	"context"
+
+	"github.com/viant/datly/gateway/router/mock"
+	"github.com/viant/datly/repository"
+	"github.com/viant/datly/service"
+	"github.com/viant/datly/service/reader"
+	"github.com/viant/datly/service/session"
+	xhandler "github.com/viant/xdatly/handler"
+)
+
+// withMock returns context with mock record generator when mock mode is enabled
+func (r *Handler) withMock(ctx context.Context) context.Context {
+	if r.Mock == nil {
+		return ctx
+	}
+	return reader.WithGenerator(ctx, r.Mock)
+}
+
+// operate runs component, in mock mode executor input is validated and echoed instead of being executed
+func (r *Handler) operate(ctx context.Context, aSession *session.Session, aComponent *repository.Component) (interface{}, error) {
+	if r.Mock == nil || aComponent.Service != service.TypeExecutor {
+		return r.dispatcher.Operate(ctx, aSession, aComponent)
+	}
+	ctx, err := r.dispatcher.EnsureInput(ctx, aComponent, aSession, false)
+	if err != nil {
+		return nil, err
+	}
+	return mock.Echo(ctx, aComponent, ctx.Value(xhandler.InputKey))
+}
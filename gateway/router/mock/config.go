@@ -0,0 +1,17 @@
+package mock
+
+const defaultRows = 100
+
+// Config represents mock mode settings, components are served with generated records instead of database data
+type Config struct {
+	Seed int64 `json:",omitempty" yaml:",omitempty"` //random seed, the same seed produces the same records
+	Rows int   `json:",omitempty" yaml:",omitempty"` //records generated per view, 100 by default
+}
+
+// RowCount returns records generated per view
+func (c *Config) RowCount() int {
+	if c.Rows > 0 {
+		return c.Rows
+	}
+	return defaultRows
+}
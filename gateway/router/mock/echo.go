@@ -0,0 +1,38 @@
+package mock
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/viant/datly/repository"
+	"github.com/viant/datly/view/state"
+	"github.com/viant/govalidator"
+)
+
+var goValidator = govalidator.New()
+
+// Echo validates executor input and returns request body, or whole input when component defines no body parameter
+func Echo(ctx context.Context, aComponent *repository.Component, input interface{}) (interface{}, error) {
+	if input == nil {
+		return nil, nil
+	}
+	validation, err := goValidator.Validate(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	if validation != nil && validation.Failed {
+		return nil, validation
+	}
+	body := aComponent.Input.Type.Parameters.LookupByLocation(state.KindRequestBody, "")
+	if body == nil {
+		return input, nil
+	}
+	rValue := reflect.Indirect(reflect.ValueOf(input))
+	if rValue.Kind() != reflect.Struct {
+		return input, nil
+	}
+	if field := rValue.FieldByName(body.Name); field.IsValid() {
+		return field.Interface(), nil
+	}
+	return input, nil
+}
@@ -0,0 +1,46 @@
+package mock
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/viant/datly/repository"
+	"github.com/viant/datly/view/state"
+)
+
+type echoOrder struct {
+	Name     *string `json:",omitempty" validate:"required"`
+	Quantity int     `json:",omitempty"`
+}
+
+type echoInput struct {
+	Order *echoOrder
+	Trace string
+}
+
+func TestEcho(t *testing.T) {
+	name := "order 1"
+	withBody := &repository.Component{}
+	withBody.Input.Type.Parameters = state.Parameters{state.NewParameter("Order", state.NewBodyLocation(""))}
+	withoutBody := &repository.Component{}
+	withoutBody.Input.Type.Parameters = state.Parameters{state.NewParameter("Trace", state.NewQueryLocation("trace"))}
+
+	order := &echoOrder{Name: &name, Quantity: 2}
+	output, err := Echo(context.Background(), withBody, &echoInput{Order: order, Trace: "t1"})
+	require.NoError(t, err)
+	assert.Equal(t, order, output, "request body should be echoed")
+
+	input := &echoInput{Order: order, Trace: "t1"}
+	output, err = Echo(context.Background(), withoutBody, input)
+	require.NoError(t, err)
+	assert.Equal(t, input, output, "whole input should be echoed without body parameter")
+
+	_, err = Echo(context.Background(), withBody, &echoInput{Order: &echoOrder{Quantity: 2}})
+	assert.Error(t, err, "invalid input should be rejected")
+
+	output, err = Echo(context.Background(), withBody, nil)
+	require.NoError(t, err)
+	assert.Nil(t, output)
+}
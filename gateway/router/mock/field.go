@@ -0,0 +1,41 @@
+package mock
+
+import (
+	"reflect"
+
+	"github.com/viant/datly/gateway/router/openapi"
+	"github.com/viant/tagly/format"
+	ftime "github.com/viant/tagly/format/time"
+)
+
+// fieldsOf returns struct type fields with the same tag metadata as used by OpenAPI schema
+func fieldsOf(rType reflect.Type) fields {
+	if cached, ok := typeCache.Load(rType); ok {
+		return cached.(fields)
+	}
+	var ret fields
+	for i := 0; i < rType.NumField(); i++ {
+		structField := rType.Field(i)
+		if structField.PkgPath != "" {
+			continue
+		}
+		aField := &field{index: structField.Index, name: structField.Name, column: structField.Name}
+		if aTag, err := openapi.ParseTag(structField, structField.Tag, false, ""); err == nil && aTag != nil {
+			aField.example = aTag.Example
+			aField.nullable = aTag.IsNullable
+			aField.ignore = aTag.Ignore
+			if aTag.Column != "" {
+				aField.column = aTag.Column
+			}
+		}
+		if formatTag, err := format.Parse(structField.Tag); err == nil && formatTag != nil {
+			aField.timeLayout = formatTag.TimeLayout
+			if aField.timeLayout == "" && formatTag.DateFormat != "" {
+				aField.timeLayout = ftime.DateFormatToTimeLayout(formatTag.DateFormat)
+			}
+		}
+		ret = append(ret, aField)
+	}
+	typeCache.Store(rType, ret)
+	return ret
+}
@@ -0,0 +1,242 @@
+package mock
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/viant/datly/view"
+	"github.com/viant/datly/view/state"
+)
+
+// Generator generates view records from view schema, it implements reader.Generator
+type Generator struct {
+	config *Config
+}
+
+// Generate emits generated records, selector order by, offset, limit and columns are applied in memory;
+// relation records are generated per parent key, one for to one and up to three for to many cardinality
+func (g *Generator) Generate(ctx context.Context, aView *view.View, selector *view.Statelet, relation *view.Relation, parentKeys []interface{}, newRow func() interface{}, visit func(row interface{}) error) error {
+	skip := holders(aView)
+	var rows []interface{}
+	if relation != nil {
+		link := childLink(relation)
+		for i, key := range parentKeys {
+			count := 1
+			if relation.Cardinality == state.Many {
+				count += newRand(g.config.Seed, aView.Name, "count", key).Intn(3)
+			}
+			for j := 0; j < count; j++ {
+				row := newRow()
+				index := i*3 + j
+				fill(reflect.ValueOf(row), newRand(g.config.Seed, aView.Name, key, j), index, 0, skip)
+				if err := setLink(row, link, key); err != nil {
+					return fmt.Errorf("view %v: %w", aView.Name, err)
+				}
+				rows = append(rows, row)
+			}
+		}
+	} else {
+		for i := 0; i < g.config.RowCount(); i++ {
+			row := newRow()
+			fill(reflect.ValueOf(row), newRand(g.config.Seed, aView.Name, i), i, 0, skip)
+			rows = append(rows, row)
+		}
+	}
+	if selector != nil && selector.OrderBy != "" {
+		sortRows(rows, selector.OrderBy)
+	}
+	if relation == nil {
+		rows = window(rows, aView, selector)
+	}
+	for _, row := range rows {
+		if selector != nil && len(selector.Columns) > 0 {
+			project(row, selector.Columns, skip)
+		}
+		if err := visit(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// holders returns function matching relation holder fields, holders are populated by reader relation processing
+func holders(aView *view.View) func(f *field) bool {
+	index := map[string]bool{}
+	for _, relation := range aView.With {
+		index[strings.ToLower(relation.Holder)] = true
+	}
+	return func(f *field) bool {
+		return index[strings.ToLower(f.name)]
+	}
+}
+
+func childLink(relation *view.Relation) string {
+	if relation.Of == nil || len(relation.Of.On) == 0 {
+		return ""
+	}
+	link := relation.Of.On[0]
+	if link.Column != "" {
+		return link.Column
+	}
+	return link.Field
+}
+
+func setLink(row interface{}, link string, key interface{}) error {
+	if link == "" {
+		return nil
+	}
+	rValue := reflect.ValueOf(row)
+	for rValue.Kind() == reflect.Ptr {
+		rValue = rValue.Elem()
+	}
+	aField := fieldsOf(rValue.Type()).lookup(link)
+	if aField == nil {
+		return nil
+	}
+	target := rValue.FieldByIndex(aField.index)
+	value, ok := convert(key, target.Type())
+	if !ok {
+		return fmt.Errorf("failed to convert relation key %v to %v", key, target.Type())
+	}
+	target.Set(value)
+	return nil
+}
+
+// window applies selector page, offset and limit, view selector limit is used by default
+func window(rows []interface{}, aView *view.View, selector *view.Statelet) []interface{} {
+	limit, offset := 0, 0
+	if selector != nil {
+		limit, offset = selector.Limit, selector.Offset
+	}
+	if limit == 0 && aView.Selector != nil {
+		limit = aView.Selector.Limit
+	}
+	if selector != nil && selector.Page > 0 && limit > 0 {
+		offset = (selector.Page - 1) * limit
+	}
+	if offset >= len(rows) {
+		return nil
+	}
+	rows = rows[offset:]
+	if limit > 0 && limit < len(rows) {
+		rows = rows[:limit]
+	}
+	return rows
+}
+
+// project resets fields not selected by selector columns
+func project(row interface{}, columns []string, skip func(f *field) bool) {
+	rValue := reflect.ValueOf(row)
+	for rValue.Kind() == reflect.Ptr {
+		rValue = rValue.Elem()
+	}
+	selected := map[string]bool{}
+	for _, column := range columns {
+		selected[strings.ToLower(column)] = true
+	}
+	for _, aField := range fieldsOf(rValue.Type()) {
+		if skip(aField) || selected[strings.ToLower(aField.column)] || selected[strings.ToLower(aField.name)] {
+			continue
+		}
+		target := rValue.FieldByIndex(aField.index)
+		target.Set(reflect.Zero(target.Type()))
+	}
+}
+
+// sortRows sorts rows by order by clause, i.e. NAME desc, ID
+func sortRows(rows []interface{}, orderBy string) {
+	if len(rows) == 0 {
+		return
+	}
+	rType := reflect.TypeOf(rows[0])
+	for rType.Kind() == reflect.Ptr {
+		rType = rType.Elem()
+	}
+	type order struct {
+		field *field
+		desc  bool
+	}
+	var orders []*order
+	for _, item := range strings.Split(orderBy, ",") {
+		parts := strings.Fields(item)
+		if len(parts) == 0 {
+			continue
+		}
+		if aField := fieldsOf(rType).lookup(parts[0]); aField != nil {
+			orders = append(orders, &order{field: aField, desc: len(parts) > 1 && strings.EqualFold(parts[1], "desc")})
+		}
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		left, right := reflect.Indirect(reflect.ValueOf(rows[i])), reflect.Indirect(reflect.ValueOf(rows[j]))
+		for _, anOrder := range orders {
+			result := compare(left.FieldByIndex(anOrder.field.index), right.FieldByIndex(anOrder.field.index))
+			if result == 0 {
+				continue
+			}
+			if anOrder.desc {
+				return result > 0
+			}
+			return result < 0
+		}
+		return false
+	})
+}
+
+func compare(left, right reflect.Value) int {
+	if left.Kind() == reflect.Ptr || right.Kind() == reflect.Ptr {
+		switch {
+		case left.IsNil() && right.IsNil():
+			return 0
+		case left.IsNil():
+			return -1
+		case right.IsNil():
+			return 1
+		}
+		return compare(left.Elem(), right.Elem())
+	}
+	switch left.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return sign(float64(left.Int()) - float64(right.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return sign(float64(left.Uint()) - float64(right.Uint()))
+	case reflect.Float32, reflect.Float64:
+		return sign(left.Float() - right.Float())
+	case reflect.String:
+		return strings.Compare(left.String(), right.String())
+	case reflect.Bool:
+		return sign(float64(boolInt(left.Bool()) - boolInt(right.Bool())))
+	}
+	if leftTime, ok := left.Interface().(time.Time); ok {
+		return leftTime.Compare(right.Interface().(time.Time))
+	}
+	return strings.Compare(fmt.Sprint(left.Interface()), fmt.Sprint(right.Interface()))
+}
+
+func sign(value float64) int {
+	switch {
+	case value < 0:
+		return -1
+	case value > 0:
+		return 1
+	}
+	return 0
+}
+
+func boolInt(value bool) int {
+	if value {
+		return 1
+	}
+	return 0
+}
+
+// New creates mock generator
+func New(config *Config) *Generator {
+	if config == nil {
+		config = &Config{}
+	}
+	return &Generator{config: config}
+}
@@ -0,0 +1,75 @@
+package mock
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/viant/datly/view"
+	"github.com/viant/datly/view/state"
+	hstate "github.com/viant/xdatly/handler/state"
+)
+
+func generate(t *testing.T, generator *Generator, aView *view.View, selector *view.Statelet, relation *view.Relation, parentKeys ...interface{}) []interface{} {
+	var rows []interface{}
+	err := generator.Generate(context.Background(), aView, selector, relation, parentKeys, func() interface{} { return &mockComment{} }, func(row interface{}) error {
+		rows = append(rows, row)
+		return nil
+	})
+	require.NoError(t, err)
+	return rows
+}
+
+func TestGenerator_Generate_Relation(t *testing.T) {
+	generator := New(&Config{Seed: 7})
+	aView := &view.View{Name: "comments"}
+	link := view.Links{{Column: "PostId"}}
+	var testCases = []struct {
+		description string
+		cardinality state.Cardinality
+		min, max    int
+	}{
+		{description: "to one", cardinality: state.One, min: 1, max: 1},
+		{description: "to many", cardinality: state.Many, min: 1, max: 3},
+	}
+	for _, testCase := range testCases {
+		relation := &view.Relation{Cardinality: testCase.cardinality, Of: &view.ReferenceView{On: link}}
+		rows := generate(t, generator, aView, nil, relation, 1, 2, 3)
+		perKey := map[int]int{}
+		for _, row := range rows {
+			comment := row.(*mockComment)
+			require.NotNil(t, comment.PostId, testCase.description)
+			perKey[*comment.PostId]++
+		}
+		assert.Len(t, perKey, 3, testCase.description)
+		for key, count := range perKey {
+			assert.GreaterOrEqual(t, count, testCase.min, "%v: key %v", testCase.description, key)
+			assert.LessOrEqual(t, count, testCase.max, "%v: key %v", testCase.description, key)
+		}
+		assert.EqualValues(t, rows, generate(t, generator, aView, nil, relation, 1, 2, 3), testCase.description)
+	}
+}
+
+func TestGenerator_Generate_Window(t *testing.T) {
+	generator := New(&Config{Seed: 1, Rows: 10})
+	aView := &view.View{Name: "comments", Selector: &view.Config{Limit: 4}}
+	var testCases = []struct {
+		description string
+		selector    *view.Statelet
+		expect      []int
+	}{
+		{description: "view limit", selector: &view.Statelet{}, expect: []int{1, 2, 3, 4}},
+		{description: "selector limit and offset", selector: &view.Statelet{QuerySelector: hstate.QuerySelector{Limit: 3, Offset: 8}}, expect: []int{9, 10}},
+		{description: "page", selector: &view.Statelet{QuerySelector: hstate.QuerySelector{Limit: 3, Page: 2}}, expect: []int{4, 5, 6}},
+		{description: "order by", selector: &view.Statelet{QuerySelector: hstate.QuerySelector{Limit: 2, OrderBy: "ID desc"}}, expect: []int{10, 9}},
+		{description: "offset past rows", selector: &view.Statelet{QuerySelector: hstate.QuerySelector{Offset: 10}}, expect: nil},
+	}
+	for _, testCase := range testCases {
+		var ids []int
+		for _, row := range generate(t, generator, aView, testCase.selector, nil) {
+			ids = append(ids, row.(*mockComment).Id)
+		}
+		assert.Equal(t, testCase.expect, ids, testCase.description)
+	}
+}
@@ -0,0 +1,261 @@
+package mock
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+type (
+	// field represents generated struct field metadata
+	field struct {
+		index      []int
+		name       string
+		column     string
+		example    string
+		nullable   bool
+		timeLayout string
+		ignore     bool
+	}
+
+	// fields represents struct type generated fields
+	fields []*field
+)
+
+var (
+	timeType  = reflect.TypeOf(time.Time{})
+	baseTime  = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	words     = []string{"alpha", "bravo", "charlie", "delta", "echo", "foxtrot", "golf", "hotel", "india", "juliet"}
+	names     = []string{"Olivia", "Liam", "Emma", "Noah", "Ava", "Mason", "Sophia", "Lucas", "Mia", "Ethan"}
+	typeCache sync.Map
+)
+
+// maxDepth limits nested struct generation, i.e. for self referencing types
+const maxDepth = 3
+
+// newRand returns deterministic random source for seed and record identity
+func newRand(seed int64, identity ...interface{}) *rand.Rand {
+	hash := fnv.New64a()
+	_, _ = hash.Write([]byte(fmt.Sprint(identity...)))
+	return rand.New(rand.NewSource(seed ^ int64(hash.Sum64())))
+}
+
+// lookup returns field matching column or field name
+func (f fields) lookup(name string) *field {
+	for _, candidate := range f {
+		if strings.EqualFold(candidate.column, name) || strings.EqualFold(candidate.name, name) {
+			return candidate
+		}
+	}
+	return nil
+}
+
+// isKey returns true for identity column
+func (f *field) isKey() bool {
+	return strings.EqualFold(f.column, "id") || strings.EqualFold(f.name, "id")
+}
+
+// fill sets generated values to struct fields, skip returns true for fields populated elsewhere, i.e. relation holders
+func fill(rValue reflect.Value, rnd *rand.Rand, index, depth int, skip func(f *field) bool) {
+	for rValue.Kind() == reflect.Ptr {
+		if rValue.IsNil() {
+			rValue.Set(reflect.New(rValue.Type().Elem()))
+		}
+		rValue = rValue.Elem()
+	}
+	if rValue.Kind() != reflect.Struct {
+		return
+	}
+	for _, aField := range fieldsOf(rValue.Type()) {
+		if aField.ignore || (skip != nil && skip(aField)) {
+			continue
+		}
+		target := rValue.FieldByIndex(aField.index)
+		if value, ok := aField.value(target.Type(), rnd, index, depth); ok {
+			target.Set(value)
+		}
+	}
+}
+
+// value returns generated value, example tag value takes precedence, nullable fields are occasionally left nil
+func (f *field) value(rType reflect.Type, rnd *rand.Rand, index, depth int) (reflect.Value, bool) {
+	if rType.Kind() == reflect.Ptr {
+		if f.nullable && rnd.Intn(10) == 0 {
+			return reflect.Value{}, false
+		}
+		value, ok := f.value(rType.Elem(), rnd, index, depth)
+		if !ok {
+			return reflect.Value{}, false
+		}
+		ptr := reflect.New(rType.Elem())
+		ptr.Elem().Set(value)
+		return ptr, true
+	}
+	if f.example != "" {
+		if value, ok := f.parse(rType, f.example); ok {
+			return value, true
+		}
+	}
+	ret := reflect.New(rType).Elem()
+	switch rType.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if f.isKey() {
+			ret.SetInt(int64(index + 1))
+		} else {
+			ret.SetInt(int64(1 + rnd.Intn(100)))
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if f.isKey() {
+			ret.SetUint(uint64(index + 1))
+		} else {
+			ret.SetUint(uint64(1 + rnd.Intn(100)))
+		}
+	case reflect.Float32, reflect.Float64:
+		ret.SetFloat(float64(rnd.Intn(100000)) / 100)
+	case reflect.Bool:
+		ret.SetBool(rnd.Intn(2) == 1)
+	case reflect.String:
+		ret.SetString(f.text(rnd, index))
+	case reflect.Struct:
+		if rType == timeType {
+			ret.Set(reflect.ValueOf(f.time(rnd)))
+			break
+		}
+		if depth >= maxDepth {
+			return reflect.Value{}, false
+		}
+		fill(ret, rnd, index, depth+1, nil)
+	case reflect.Slice:
+		if rType.Elem().Kind() == reflect.Uint8 {
+			ret.SetBytes([]byte(f.text(rnd, index)))
+			break
+		}
+		size := 1 + rnd.Intn(3)
+		ret = reflect.MakeSlice(rType, 0, size)
+		item := &field{name: f.name}
+		for i := 0; i < size; i++ {
+			if value, ok := item.value(rType.Elem(), rnd, i, depth); ok {
+				ret = reflect.Append(ret, value)
+			}
+		}
+	default:
+		return reflect.Value{}, false
+	}
+	return ret, true
+}
+
+func (f *field) text(rnd *rand.Rand, index int) string {
+	name := strings.ToLower(f.name)
+	switch {
+	case f.isKey():
+		return strconv.Itoa(index + 1)
+	case strings.Contains(name, "email"):
+		return fmt.Sprintf("%v%v@example.com", strings.ToLower(names[rnd.Intn(len(names))]), index+1)
+	case strings.Contains(name, "url"):
+		return fmt.Sprintf("https://example.com/%v/%v", words[rnd.Intn(len(words))], index+1)
+	case strings.Contains(name, "phone"):
+		return fmt.Sprintf("+1-555-%04d", rnd.Intn(10000))
+	case strings.HasSuffix(name, "name"):
+		return names[rnd.Intn(len(names))]
+	case strings.Contains(name, "date") || strings.Contains(name, "time"):
+		return f.time(rnd).Format(f.layout())
+	}
+	return fmt.Sprintf("%v %v", words[rnd.Intn(len(words))], words[rnd.Intn(len(words))])
+}
+
+func (f *field) time(rnd *rand.Rand) time.Time {
+	ret := baseTime.Add(time.Duration(rnd.Intn(365*24*60)) * time.Minute)
+	if f.timeLayout != "" && !strings.Contains(f.timeLayout, "15") && !strings.Contains(f.timeLayout, "04") { //date only layout
+		ret = ret.Truncate(24 * time.Hour)
+	}
+	return ret
+}
+
+func (f *field) layout() string {
+	if f.timeLayout != "" {
+		return f.timeLayout
+	}
+	return time.RFC3339
+}
+
+// parse converts example tag value to field type
+func (f *field) parse(rType reflect.Type, text string) (reflect.Value, bool) {
+	ret := reflect.New(rType).Elem()
+	switch rType.Kind() {
+	case reflect.String:
+		ret.SetString(text)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		value, err := strconv.ParseInt(text, 10, 64)
+		if err != nil {
+			return ret, false
+		}
+		ret.SetInt(value)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		value, err := strconv.ParseUint(text, 10, 64)
+		if err != nil {
+			return ret, false
+		}
+		ret.SetUint(value)
+	case reflect.Float32, reflect.Float64:
+		value, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return ret, false
+		}
+		ret.SetFloat(value)
+	case reflect.Bool:
+		value, err := strconv.ParseBool(text)
+		if err != nil {
+			return ret, false
+		}
+		ret.SetBool(value)
+	case reflect.Struct:
+		if rType != timeType {
+			return ret, false
+		}
+		for _, layout := range []string{f.layout(), time.RFC3339, "2006-01-02"} {
+			if value, err := time.Parse(layout, text); err == nil {
+				ret.Set(reflect.ValueOf(value))
+				return ret, true
+			}
+		}
+		return ret, false
+	default:
+		return ret, false
+	}
+	return ret, true
+}
+
+// convert converts value to target type, it is used to link relation records with parent keys
+func convert(value interface{}, rType reflect.Type) (reflect.Value, bool) {
+	if value == nil {
+		return reflect.Value{}, false
+	}
+	rValue := reflect.ValueOf(value)
+	for rValue.Kind() == reflect.Ptr {
+		if rValue.IsNil() {
+			return reflect.Value{}, false
+		}
+		rValue = rValue.Elem()
+	}
+	if rType.Kind() == reflect.Ptr {
+		elem, ok := convert(rValue.Interface(), rType.Elem())
+		if !ok {
+			return reflect.Value{}, false
+		}
+		ptr := reflect.New(rType.Elem())
+		ptr.Elem().Set(elem)
+		return ptr, true
+	}
+	if rValue.Type().ConvertibleTo(rType) && (rValue.Kind() == reflect.String) == (rType.Kind() == reflect.String) {
+		return rValue.Convert(rType), true
+	}
+	if rType.Kind() == reflect.String {
+		return reflect.ValueOf(fmt.Sprint(rValue.Interface())).Convert(rType), true
+	}
+	return (&field{}).parse(rType, fmt.Sprint(rValue.Interface()))
+}
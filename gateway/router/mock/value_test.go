@@ -0,0 +1,76 @@
+package mock
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockRecord struct {
+	Id       int
+	Name     string
+	Email    string
+	Status   string `example:"ACTIVE"`
+	Amount   float64
+	Created  time.Time
+	Tags     []string
+	Comments []*mockComment
+}
+
+type mockComment struct {
+	Id     int
+	PostId *int
+	Body   string
+}
+
+func TestFill(t *testing.T) {
+	generate := func(seed int64, index int) *mockRecord {
+		ret := &mockRecord{}
+		fill(reflect.ValueOf(ret), newRand(seed, "post", index), index, 0, func(f *field) bool { return f.name == "Comments" })
+		return ret
+	}
+	record := generate(1, 4)
+	assert.EqualValues(t, generate(1, 4), record)
+	assert.NotEqualValues(t, generate(2, 4), record)
+	assert.Equal(t, 5, record.Id)
+	assert.Equal(t, "ACTIVE", record.Status)
+	assert.Contains(t, record.Email, "@example.com")
+	assert.Contains(t, names, record.Name)
+	assert.False(t, record.Created.IsZero())
+	assert.NotEmpty(t, record.Tags)
+	assert.Nil(t, record.Comments)
+}
+
+func TestConvert(t *testing.T) {
+	value, ok := convert(int64(3), reflect.TypeOf(0))
+	require.True(t, ok)
+	assert.Equal(t, 3, value.Interface())
+
+	value, ok = convert(3, reflect.TypeOf(""))
+	require.True(t, ok)
+	assert.Equal(t, "3", value.Interface())
+
+	value, ok = convert("7", reflect.TypeOf((*int)(nil)))
+	require.True(t, ok)
+	assert.Equal(t, 7, *value.Interface().(*int))
+
+	_, ok = convert("x", reflect.TypeOf(0))
+	assert.False(t, ok)
+}
+
+func TestSortRows(t *testing.T) {
+	rows := []interface{}{&mockRecord{Id: 1, Name: "b"}, &mockRecord{Id: 2, Name: "a"}, &mockRecord{Id: 3, Name: "b"}}
+	sortRows(rows, "NAME, ID desc")
+	var ids []int
+	for _, row := range rows {
+		ids = append(ids, row.(*mockRecord).Id)
+	}
+	assert.Equal(t, []int{2, 3, 1}, ids)
+
+	record := &mockRecord{Id: 1, Name: "b", Email: "x"}
+	project(record, []string{"name"}, func(f *field) bool { return false })
+	assert.Equal(t, &mockRecord{Name: "b"}, record)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	openapi "github.com/viant/datly/gateway/router/openapi/openapi3"
 	"github.com/viant/datly/repository"
+	"github.com/viant/datly/repository/content"
 	"github.com/viant/datly/shared"
 	"github.com/viant/datly/view"
 	"github.com/viant/datly/view/state"
@@ -366,13 +367,15 @@ func (g *generator) requestBody(ctx context.Context, component *ComponentSchema)
 		return nil, err
 	}
 
+	mediaTypes := map[string]*openapi.MediaType{
+		ApplicationJson: {
+			Schema: requestBodySchema,
+		},
+	}
+	addFormatMediaTypes(mediaTypes, requestBodySchema, true)
 	return &openapi.RequestBody{
 		Required: true,
-		Content: map[string]*openapi.MediaType{
-			ApplicationJson: {
-				Schema: requestBodySchema,
-			},
-		},
+		Content:  mediaTypes,
 	}, nil
 }
 
@@ -393,13 +396,15 @@ func (g *generator) responses(ctx context.Context, component *ComponentSchema) (
 	}
 
 	responses := openapi.Responses{}
+	mediaTypes := map[string]*openapi.MediaType{
+		ApplicationJson: {
+			Schema: schema,
+		},
+	}
+	addFormatMediaTypes(mediaTypes, schema, false)
 	openapi.SetResponse(responses, openapi.ResponseOK, &openapi.Response{
 		Description: stringPtr("Success response"),
-		Content: map[string]*openapi.MediaType{
-			ApplicationJson: {
-				Schema: schema,
-			},
-		},
+		Content:     mediaTypes,
 	})
 
 	errorSchema, err := component.GetOrGenerateSchema(ctx, component.ReflectSchema("ErrorResponse", errorType, errorSchemaDescription, component.component.IOConfig()))
@@ -421,3 +426,20 @@ func (g *generator) responses(ctx context.Context, component *ComponentSchema) (
 func stringPtr(value string) *string {
 	return &value
 }
+
+// addFormatMediaTypes adds media types contributed by registered content formats
+func addFormatMediaTypes(mediaTypes map[string]*openapi.MediaType, schema *openapi.Schema, request bool) {
+	for _, format := range content.Formats().Formats() {
+		if format.OpenAPI == nil || (request && !format.OpenAPI.Request) || (!request && !format.OpenAPI.Response) {
+			continue
+		}
+		if (request && format.Unmarshaller == nil) || (!request && format.Marshaller == nil) {
+			continue
+		}
+		mediaType := &openapi.MediaType{Schema: schema}
+		if format.OpenAPI.Binary {
+			mediaType.Schema = &openapi.Schema{Type: stringOutput, Format: "binary"}
+		}
+		mediaTypes[format.ContentType()] = mediaType
+	}
+}
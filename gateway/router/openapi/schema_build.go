@@ -81,6 +81,9 @@ func (c *SchemaContainer) addStructSchema(ctx context.Context, component *Compon
 		if shouldSkipStructField(field) {
 			continue
 		}
+		if !view.FieldAllowed(ctx, field) {
+			continue
+		}
 
 		aTag, err := ParseTag(field, field.Tag, schema.isInput, rootTable)
 		if err != nil {
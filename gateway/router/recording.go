@@ -0,0 +1,70 @@
+package router
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/viant/datly/gateway/router/recording"
+	"github.com/viant/datly/repository"
+	"github.com/viant/datly/service/session"
+	"github.com/viant/datly/view"
+	"github.com/viant/datly/view/state"
+)
+
+// startRecording samples request, returned context collects component input and SQL, returned writer captures response outcome
+func (r *Handler) startRecording(ctx context.Context, writer http.ResponseWriter, request *http.Request) (context.Context, http.ResponseWriter, func()) {
+	if r.Recording == nil {
+		return ctx, writer, func() {}
+	}
+	record := r.Recording.Sample(request, r.Path.URI)
+	if record == nil {
+		return ctx, writer, func() {}
+	}
+	Sanitize(request, r.Path, record.Header, nil)
+	ctx = recording.Begin(ctx, record)
+	responseWriter := r.Recording.ResponseWriter(writer)
+	return ctx, responseWriter, func() {
+		record.Complete(responseWriter)
+		r.Recording.Append(record)
+	}
+}
+
+// recordInput sets populated component input state on recorded request, header, cookie and request parameters are masked
+func (r *Handler) recordInput(ctx context.Context, aComponent *repository.Component, aSession *session.Session) {
+	if record := recording.RecordOf(ctx); record != nil {
+		record.SetInput(aSession, sensitiveParameters(aComponent)...)
+	}
+}
+
+// sensitiveParameters returns names of component and view parameters populated from request headers, cookies or request itself
+func sensitiveParameters(aComponent *repository.Component) []string {
+	var ret []string
+	appendParameters := func(parameters state.Parameters) {
+		for _, parameter := range parameters {
+			if parameter.In == nil {
+				continue
+			}
+			switch parameter.In.Kind {
+			case state.KindHeader, state.KindCookie, state.KindRequest:
+				ret = append(ret, parameter.Name)
+			}
+		}
+	}
+	appendParameters(aComponent.Input.Type.Parameters)
+	var appendView func(aView *view.View)
+	appendView = func(aView *view.View) {
+		if aView == nil {
+			return
+		}
+		if aView.Template != nil {
+			appendParameters(aView.Template.Parameters)
+		}
+		for _, relation := range aView.With {
+			if relation.Of != nil {
+				appendView(&relation.Of.View)
+			}
+		}
+	}
+	appendView(aComponent.View)
+	return ret
+}
@@ -0,0 +1,74 @@
+package recording
+
+import (
+	"math/rand"
+	"strings"
+	"time"
+)
+
+const (
+	defaultBatchSize       = 100
+	defaultFlushInterval   = time.Minute
+	defaultMaxResponseSize = 1024 * 1024
+)
+
+// defaultMask represents sensitive fields masked in recorded body, input and response
+var defaultMask = []string{"password", "secret", "token", "accessToken", "refreshToken", "apiKey", "authorization", "cookie"}
+
+// Config represents request recording settings
+type Config struct {
+	URL             string   //destination folder for JSONL recording files
+	Rate            float64  `json:",omitempty" yaml:",omitempty"` //sampling rate between 0 and 1, all requests are recorded by default
+	URIs            []string `json:",omitempty" yaml:",omitempty"` //recorded route URI prefixes, all routes by default
+	BatchSize       int      `json:",omitempty" yaml:",omitempty"` //records per file, 100 by default
+	FlushInterval   int      `json:",omitempty" yaml:",omitempty"` //max seconds records are buffered, 60 by default
+	MaxBodySize     int      `json:",omitempty" yaml:",omitempty"` //max recorded request body size in bytes, larger bodies are skipped
+	MaxResponseSize int      `json:",omitempty" yaml:",omitempty"` //max recorded response size in bytes, larger responses are compared by hash only, 1MB by default
+	Mask            []string `json:",omitempty" yaml:",omitempty"` //masked body, input and response fields, in addition to default sensitive ones
+}
+
+// Matches returns true if route URI is recorded
+func (c *Config) Matches(URI string) bool {
+	if len(c.URIs) == 0 {
+		return true
+	}
+	for _, candidate := range c.URIs {
+		if strings.HasPrefix(URI, candidate) {
+			return true
+		}
+	}
+	return false
+}
+
+// Sampled returns true if request should be recorded
+func (c *Config) Sampled() bool {
+	if c.Rate <= 0 || c.Rate >= 1 {
+		return true
+	}
+	return rand.Float64() < c.Rate
+}
+
+func (c *Config) batchSize() int {
+	if c.BatchSize > 0 {
+		return c.BatchSize
+	}
+	return defaultBatchSize
+}
+
+func (c *Config) flushInterval() time.Duration {
+	if c.FlushInterval > 0 {
+		return time.Duration(c.FlushInterval) * time.Second
+	}
+	return defaultFlushInterval
+}
+
+func (c *Config) maxResponseSize() int {
+	if c.MaxResponseSize > 0 {
+		return c.MaxResponseSize
+	}
+	return defaultMaxResponseSize
+}
+
+func (c *Config) mask() []string {
+	return append(append([]string{}, defaultMask...), c.Mask...)
+}
@@ -0,0 +1,149 @@
+package recording
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+const maskedValue = "***"
+
+// fieldMask represents masked field names, names are matched case insensitively ignoring '_' and '-'
+type fieldMask map[string]bool
+
+func newFieldMask(names []string) fieldMask {
+	ret := fieldMask{}
+	for _, name := range names {
+		ret[normalizeField(name)] = true
+	}
+	return ret
+}
+
+func (m fieldMask) has(name string) bool {
+	return m[normalizeField(name)]
+}
+
+// maskJSON masks values of object fields matching mask, false is returned for invalid JSON
+func (m fieldMask) maskJSON(data []byte) ([]byte, bool) {
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, false
+	}
+	ret, err := json.Marshal(m.maskValue(value))
+	return ret, err == nil
+}
+
+func (m fieldMask) maskValue(value interface{}) interface{} {
+	switch actual := value.(type) {
+	case map[string]interface{}:
+		for key, item := range actual {
+			if m.has(key) {
+				actual[key] = maskedValue
+				continue
+			}
+			actual[key] = m.maskValue(item)
+		}
+	case []interface{}:
+		for i, item := range actual {
+			actual[i] = m.maskValue(item)
+		}
+	}
+	return value
+}
+
+// secrets appends values of object fields matching mask
+func (m fieldMask) secrets(value interface{}, secrets []string) []string {
+	switch actual := value.(type) {
+	case map[string]interface{}:
+		for key, item := range actual {
+			if m.has(key) {
+				secrets = appendSecrets(secrets, item)
+				continue
+			}
+			secrets = m.secrets(item, secrets)
+		}
+	case []interface{}:
+		for _, item := range actual {
+			secrets = m.secrets(item, secrets)
+		}
+	}
+	return secrets
+}
+
+// appendSecrets appends text of decoded JSON scalar values, empty strings and booleans are not secrets
+func appendSecrets(secrets []string, value interface{}) []string {
+	switch actual := value.(type) {
+	case string:
+		if actual != "" && actual != maskedValue {
+			secrets = append(secrets, actual)
+		}
+	case float64:
+		secrets = append(secrets, fmt.Sprint(actual))
+	case map[string]interface{}:
+		for _, item := range actual {
+			secrets = appendSecrets(secrets, item)
+		}
+	case []interface{}:
+		for _, item := range actual {
+			secrets = appendSecrets(secrets, item)
+		}
+	}
+	return secrets
+}
+
+// maskArgs returns SQL args copy with args equal to or containing secret masked
+func maskArgs(args []interface{}, secrets []string) []interface{} {
+	if len(args) == 0 || len(secrets) == 0 {
+		return args
+	}
+	ret := make([]interface{}, len(args))
+	for i, arg := range args {
+		ret[i] = arg
+		value := reflect.Indirect(reflect.ValueOf(arg))
+		if !value.IsValid() {
+			continue
+		}
+		text, isText := value.Interface().(string)
+		if !isText {
+			text = fmt.Sprint(value.Interface())
+		}
+		for _, secret := range secrets {
+			if text == secret || (isText && strings.Contains(text, secret)) {
+				ret[i] = maskedValue
+				break
+			}
+		}
+	}
+	return ret
+}
+
+// maskBody masks JSON or form request body, false is returned for other content types
+func (m fieldMask) maskBody(contentType string, body []byte) (string, bool) {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch {
+	case mediaType == "application/x-www-form-urlencoded":
+		values, err := url.ParseQuery(string(body))
+		if err != nil {
+			return "", false
+		}
+		for key := range values {
+			if m.has(key) {
+				values[key] = []string{maskedValue}
+			}
+		}
+		return values.Encode(), true
+	case mediaType == "" || mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+		data, ok := m.maskJSON(body)
+		return string(data), ok
+	}
+	return "", false
+}
+
+func normalizeField(name string) string {
+	name = strings.ReplaceAll(name, "_", "")
+	name = strings.ReplaceAll(name, "-", "")
+	return strings.ToLower(name)
+}
@@ -0,0 +1,103 @@
+package recording
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/viant/datly/service/reader"
+)
+
+type (
+	// Record represents recorded request with component input state, read SQL and response outcome
+	Record struct {
+		ID           string
+		Time         time.Time
+		Method       string
+		URI          string          //request URI with query string
+		Header       http.Header     `json:",omitempty"`
+		Body         string          `json:",omitempty"`
+		Input        json.RawMessage `json:",omitempty"`
+		SQL          []*Query        `json:",omitempty"`
+		StatusCode   int
+		ResponseHash string
+		ResponseSize int
+		Response     json.RawMessage `json:",omitempty"` //JSON response, omitted when exceeds max response size
+		Masked       []string        `json:",omitempty"` //masked body, input and response fields
+		recorder     *reader.Recorder
+		secrets      []string //masked input values, SQL args carrying them are masked
+	}
+
+	// Query represents recorded view SQL
+	Query struct {
+		View string
+		SQL  string
+		Args []interface{} `json:",omitempty"`
+	}
+
+	recordKey string
+)
+
+const recordContextKey = recordKey("record")
+
+// Begin returns context collecting record input state and SQL issued by reader
+func Begin(ctx context.Context, record *Record) context.Context {
+	record.recorder = &reader.Recorder{}
+	ctx = reader.WithRecorder(ctx, record.recorder)
+	return context.WithValue(ctx, recordContextKey, record)
+}
+
+// RecordOf returns context record or nil
+func RecordOf(ctx context.Context) *Record {
+	if ctx == nil {
+		return nil
+	}
+	ret, _ := ctx.Value(recordContextKey).(*Record)
+	return ret
+}
+
+// Complete sets record SQL and response outcome
+func (r *Record) Complete(writer *ResponseWriter) {
+	if r.recorder != nil {
+		r.SQL = nil
+		for _, query := range r.recorder.Queries() {
+			r.SQL = append(r.SQL, &Query{View: query.View, SQL: query.SQL, Args: maskArgs(query.Args, r.secrets)})
+		}
+	}
+	r.StatusCode = writer.StatusCode()
+	r.ResponseHash = writer.Hash()
+	r.ResponseSize = writer.Size()
+	r.Response = nil
+	if body, ok := writer.Body(); ok && len(body) > 0 {
+		if data, ok := newFieldMask(r.Masked).maskJSON(body); ok {
+			r.Response = data
+		}
+	}
+}
+
+// SetInput sets component input state, sensitive parameters, i.e. populated from headers or cookies, and masked fields are masked,
+// masked values are also masked in recorded SQL args
+func (r *Record) SetInput(input interface{}, sensitive ...string) {
+	data, err := json.Marshal(input)
+	if err != nil {
+		return
+	}
+	var value interface{}
+	if err = json.Unmarshal(data, &value); err != nil {
+		return
+	}
+	mask := newFieldMask(r.Masked)
+	if values, ok := value.(map[string]interface{}); ok {
+		for _, name := range sensitive {
+			if item, ok := values[name]; ok {
+				r.secrets = appendSecrets(r.secrets, item)
+				values[name] = maskedValue
+			}
+		}
+	}
+	r.secrets = mask.secrets(value, r.secrets)
+	if data, err = json.Marshal(mask.maskValue(value)); err == nil {
+		r.Input = data
+	}
+}
@@ -0,0 +1,29 @@
+package recording
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/viant/datly/service/reader"
+)
+
+func TestRecord_Complete_MasksSQLArgs(t *testing.T) {
+	record := &Record{Masked: defaultMask}
+	ctx := Begin(context.Background(), record)
+	require.NotNil(t, RecordOf(ctx))
+	tenant, apiKey := "tenant-secret-42", "k1"
+	record.SetInput(map[string]interface{}{"Id": 7, "Tenant": tenant, "Filter": map[string]interface{}{"ApiKey": apiKey}}, "Tenant")
+	record.recorder.Append(&reader.RecordedQuery{View: "orders", SQL: "SELECT * FROM ORDERS WHERE TENANT = ? AND ID = ? AND NAME LIKE ? AND KEY = ?",
+		Args: []interface{}{tenant, 7, "%" + tenant + "%", &apiKey}})
+
+	record.Complete(NewResponseWriter(httptest.NewRecorder(), defaultMaxResponseSize))
+	require.Len(t, record.SQL, 1)
+	assert.Equal(t, []interface{}{maskedValue, 7, maskedValue, maskedValue}, record.SQL[0].Args)
+	assert.JSONEq(t, `{"Id":7,"Tenant":"***","Filter":{"ApiKey":"***"}}`, string(record.Input))
+	assert.Equal(t, tenant, record.recorder.Queries()[0].Args[0], "reader args should not be modified")
+	assert.Equal(t, http.StatusOK, record.StatusCode)
+}
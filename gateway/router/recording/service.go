@@ -0,0 +1,188 @@
+package recording
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/viant/afs"
+	"github.com/viant/afs/file"
+	"github.com/viant/afs/url"
+)
+
+const fileExt = ".jsonl"
+
+// Service samples requests and writes records to JSONL files, records are flushed in background
+type Service struct {
+	config  *Config
+	fs      afs.Service
+	mux     sync.Mutex
+	records []*Record
+	seq     int
+	flush   chan bool
+	done    chan bool
+	stopped chan bool
+	close   sync.Once
+}
+
+// Sample returns new record for sampled request or nil, request body is read and restored, masked body is recorded,
+// requests with body other than JSON or form are not recorded
+func (s *Service) Sample(request *http.Request, URI string) *Record {
+	if !s.config.Matches(URI) || !s.config.Sampled() {
+		return nil
+	}
+	ret := &Record{ID: uuid.New().String(), Time: time.Now(), Method: request.Method, URI: request.URL.RequestURI(), Header: request.Header.Clone(), Masked: s.config.mask()}
+	if request.Body == nil || request.Body == http.NoBody {
+		return ret
+	}
+	data, err := io.ReadAll(request.Body)
+	_ = request.Body.Close()
+	request.Body = io.NopCloser(bytes.NewReader(data))
+	if err != nil || (s.config.MaxBodySize > 0 && len(data) > s.config.MaxBodySize) {
+		return nil
+	}
+	if len(data) == 0 {
+		return ret
+	}
+	body, ok := newFieldMask(ret.Masked).maskBody(request.Header.Get("Content-Type"), data)
+	if !ok {
+		return nil
+	}
+	ret.Body = body
+	return ret
+}
+
+// ResponseWriter returns response writer capturing record response outcome
+func (s *Service) ResponseWriter(writer http.ResponseWriter) *ResponseWriter {
+	return NewResponseWriter(writer, s.config.maxResponseSize())
+}
+
+// Append appends record, records are flushed in background when batch is full or flush interval elapsed
+func (s *Service) Append(record *Record) {
+	s.mux.Lock()
+	s.records = append(s.records, record)
+	due := len(s.records) >= s.config.batchSize()
+	s.mux.Unlock()
+	if !due {
+		return
+	}
+	select {
+	case s.flush <- true:
+	default:
+	}
+}
+
+// Close stops background flushing and flushes buffered records
+func (s *Service) Close(ctx context.Context) error {
+	s.close.Do(func() {
+		close(s.done)
+		<-s.stopped
+	})
+	return s.Flush(ctx)
+}
+
+func (s *Service) run() {
+	defer close(s.stopped)
+	ticker := time.NewTicker(s.config.flushInterval())
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+		case <-s.flush:
+		}
+		if err := s.Flush(context.Background()); err != nil {
+			fmt.Printf("[ERROR] %v\n", err)
+		}
+	}
+}
+
+// Flush writes buffered records to a new JSONL file
+func (s *Service) Flush(ctx context.Context) error {
+	s.mux.Lock()
+	records := s.records
+	s.records = nil
+	if len(records) == 0 {
+		s.mux.Unlock()
+		return nil
+	}
+	s.seq++
+	name := fmt.Sprintf("%v_%04d%v", time.Now().UTC().Format("20060102150405"), s.seq, fileExt)
+	s.mux.Unlock()
+	buffer := &bytes.Buffer{}
+	encoder := json.NewEncoder(buffer)
+	for _, record := range records {
+		if err := encoder.Encode(record); err != nil {
+			return err
+		}
+	}
+	URL := url.Join(s.config.URL, name)
+	if err := s.fs.Upload(ctx, URL, file.DefaultFileOsMode, buffer); err != nil {
+		return fmt.Errorf("failed to write recording %v: %w", URL, err)
+	}
+	return nil
+}
+
+// Load loads records from JSONL file or folder of JSONL files
+func Load(ctx context.Context, fs afs.Service, URL string) ([]*Record, error) {
+	var URLs []string
+	if strings.HasSuffix(URL, fileExt) {
+		URLs = append(URLs, URL)
+	} else {
+		objects, err := fs.List(ctx, URL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list recordings %v: %w", URL, err)
+		}
+		for _, object := range objects {
+			if !object.IsDir() && path.Ext(object.Name()) == fileExt {
+				URLs = append(URLs, object.URL())
+			}
+		}
+		sort.Strings(URLs)
+	}
+	var ret []*Record
+	for _, candidate := range URLs {
+		data, err := fs.DownloadWithURL(ctx, candidate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load recording %v: %w", candidate, err)
+		}
+		scanner := bufio.NewScanner(bytes.NewReader(data))
+		scanner.Buffer(make([]byte, 0, 64*1024), len(data)+1)
+		for scanner.Scan() {
+			line := bytes.TrimSpace(scanner.Bytes())
+			if len(line) == 0 {
+				continue
+			}
+			record := &Record{}
+			if err = json.Unmarshal(line, record); err != nil {
+				return nil, fmt.Errorf("invalid recording %v: %w", candidate, err)
+			}
+			ret = append(ret, record)
+		}
+		if err = scanner.Err(); err != nil {
+			return nil, err
+		}
+	}
+	return ret, nil
+}
+
+// New creates recording service and starts background flushing, Close has to be called to flush buffered records
+func New(config *Config, fs afs.Service) *Service {
+	if fs == nil {
+		fs = afs.New()
+	}
+	ret := &Service{config: config, fs: fs, flush: make(chan bool, 1), done: make(chan bool), stopped: make(chan bool)}
+	go ret.run()
+	return ret
+}
@@ -0,0 +1,102 @@
+package recording
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/viant/afs"
+)
+
+func TestService(t *testing.T) {
+	ctx := context.Background()
+	fs := afs.New()
+	baseURL := "mem://localhost/recordings"
+	service := New(&Config{URL: baseURL, URIs: []string{"/v1/api/dev/emp"}, BatchSize: 2}, fs)
+	defer service.Close(ctx)
+
+	request := httptest.NewRequest(http.MethodPost, "/v1/api/dev/emp?debug=true", strings.NewReader(`{"Name":"Bob","Password":"p1"}`))
+	request.Header.Set("X-Trace", "t1")
+	request.Header.Set("Content-Type", "application/json")
+	assert.Nil(t, service.Sample(httptest.NewRequest(http.MethodGet, "/v1/api/dev/dept", nil), "/v1/api/dev/dept"))
+	record := service.Sample(request, "/v1/api/dev/emp")
+	require.NotNil(t, record)
+	assert.Equal(t, "/v1/api/dev/emp?debug=true", record.URI)
+	assert.JSONEq(t, `{"Name":"Bob","Password":"***"}`, record.Body)
+	assert.Equal(t, "t1", record.Header.Get("X-Trace"))
+	body, _ := io.ReadAll(request.Body)
+	assert.Equal(t, `{"Name":"Bob","Password":"p1"}`, string(body))
+
+	writer := service.ResponseWriter(httptest.NewRecorder())
+	writer.WriteHeader(http.StatusCreated)
+	_, _ = writer.Write([]byte(`{"Id":1,"Token":"t1"}`))
+	record.Complete(writer)
+	record.SetInput(map[string]interface{}{"Name": "Bob", "Auth": "Bearer x", "ApiKey": "k1"}, "Auth")
+	assert.Equal(t, http.StatusCreated, record.StatusCode)
+	assert.Equal(t, 21, record.ResponseSize)
+	assert.Len(t, record.ResponseHash, 64)
+	assert.JSONEq(t, `{"Id":1,"Token":"***"}`, string(record.Response))
+	assert.JSONEq(t, `{"Name":"Bob","Auth":"***","ApiKey":"***"}`, string(record.Input))
+
+	service.Append(record)
+	exists, _ := fs.Exists(ctx, baseURL)
+	assert.False(t, exists, "records should be buffered until batch is full")
+
+	service.Append(&Record{ID: "2", Method: http.MethodGet, URI: "/v1/api/dev/emp/1"})
+	var records []*Record
+	require.Eventually(t, func() bool {
+		records, _ = Load(ctx, fs, baseURL)
+		return len(records) == 2
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, record.ID, records[0].ID)
+	assert.Equal(t, record.ResponseHash, records[0].ResponseHash)
+	assert.JSONEq(t, `{"Name":"Bob","Auth":"***","ApiKey":"***"}`, string(records[0].Input))
+	assert.Equal(t, "2", records[1].ID)
+}
+
+func TestService_Sample(t *testing.T) {
+	service := New(&Config{URL: "mem://localhost/sample"}, afs.New())
+	defer service.Close(context.Background())
+
+	request := httptest.NewRequest(http.MethodPost, "/v1/api/dev/auth", strings.NewReader("user=bob&password=p1"))
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	record := service.Sample(request, "/v1/api/dev/auth")
+	require.NotNil(t, record)
+	assert.Equal(t, "password=%2A%2A%2A&user=bob", record.Body)
+
+	request = httptest.NewRequest(http.MethodPost, "/v1/api/dev/upload", strings.NewReader("raw"))
+	request.Header.Set("Content-Type", "application/octet-stream")
+	assert.Nil(t, service.Sample(request, "/v1/api/dev/upload"))
+}
+
+func TestService_Close(t *testing.T) {
+	ctx := context.Background()
+	fs := afs.New()
+	baseURL := "mem://localhost/close"
+	service := New(&Config{URL: baseURL}, fs)
+	service.Append(&Record{ID: "1", Method: http.MethodGet, URI: "/v1/api/dev/emp"})
+	require.NoError(t, service.Close(ctx))
+	records, err := Load(ctx, fs, baseURL)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, "1", records[0].ID)
+}
+
+func TestConfig_Sampled(t *testing.T) {
+	assert.True(t, (&Config{}).Sampled())
+	assert.True(t, (&Config{Rate: 1}).Sampled())
+	sampled := 0
+	config := &Config{Rate: 0.2}
+	for i := 0; i < 1000; i++ {
+		if config.Sampled() {
+			sampled++
+		}
+	}
+	assert.InDelta(t, 200, sampled, 100)
+}
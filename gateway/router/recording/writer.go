@@ -0,0 +1,78 @@
+package recording
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"hash"
+	"net/http"
+)
+
+// ResponseWriter wraps http response writer to capture status code, response size, hash and body up to max body size
+type ResponseWriter struct {
+	http.ResponseWriter
+	hash        hash.Hash
+	size        int
+	statusCode  int
+	body        bytes.Buffer
+	maxBodySize int
+}
+
+// WriteHeader captures status code
+func (w *ResponseWriter) WriteHeader(statusCode int) {
+	if w.statusCode == 0 {
+		w.statusCode = statusCode
+	}
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+// Write hashes and writes response data
+func (w *ResponseWriter) Write(data []byte) (int, error) {
+	if w.statusCode == 0 {
+		w.statusCode = http.StatusOK
+	}
+	w.hash.Write(data)
+	w.size += len(data)
+	if w.size <= w.maxBodySize {
+		w.body.Write(data)
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+// Flush flushes underlying writer if supported
+func (w *ResponseWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// StatusCode returns response status code
+func (w *ResponseWriter) StatusCode() int {
+	if w.statusCode == 0 {
+		return http.StatusOK
+	}
+	return w.statusCode
+}
+
+// Hash returns hex encoded sha256 response hash
+func (w *ResponseWriter) Hash() string {
+	return hex.EncodeToString(w.hash.Sum(nil))
+}
+
+// Size returns response size
+func (w *ResponseWriter) Size() int {
+	return w.size
+}
+
+// Body returns captured response body, false if response exceeded max body size
+func (w *ResponseWriter) Body() ([]byte, bool) {
+	if w.size > w.maxBodySize {
+		return nil, false
+	}
+	return w.body.Bytes(), true
+}
+
+// NewResponseWriter creates response writer, response body up to max body size is captured
+func NewResponseWriter(writer http.ResponseWriter, maxBodySize int) *ResponseWriter {
+	return &ResponseWriter{ResponseWriter: writer, hash: sha256.New(), maxBodySize: maxBodySize}
+}
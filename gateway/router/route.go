@@ -88,6 +88,13 @@ func (r *Route) UnmarshalFunc(request *http.Request) shared.Unmarshal {
 	case content.CSVContentType:
 		return r.CSV.Unmarshal
 	default:
+		if unmarshaller, err := r.Content.FormatUnmarshaller(contentType); err != nil {
+			return func(data []byte, dest interface{}) error {
+				return err
+			}
+		} else if unmarshaller != nil {
+			return unmarshaller.Unmarshal
+		}
 	}
 	jsonPathInterceptor := json.UnmarshalerInterceptors{}
 	for i := range r._unmarshallerInterceptors {
@@ -14,6 +14,9 @@ func Sanitize(request *http.Request, aPath *path.Path, headers http.Header, resp
 	if authorization := headers.Get("Authorization"); authorization != "" {
 		obfuscateAuthorization(request, response, authorization, headers, aPath)
 	}
+	if headers.Get("Cookie") != "" {
+		headers.Set("Cookie", "***")
+	}
 
 	if apiKey := aPath.APIKey; apiKey != nil {
 		for key := range headers {
@@ -1,18 +1,23 @@
 package status
 
 import (
+	"context"
 	"errors"
 	"net/http"
 
 	"github.com/viant/datly/service/executor/expand"
 	derrors "github.com/viant/datly/utils/errors"
 	"github.com/viant/datly/utils/httputils"
+	"github.com/viant/datly/view"
 	"github.com/viant/govalidator"
 	svalidator "github.com/viant/sqlx/io/validator"
 	"github.com/viant/xdatly/handler/response"
 )
 
 func NormalizeErr(err error, statusCode int) (int, string, interface{}) {
+	if code := BudgetStatusCode(err); code != 0 {
+		return code, http.StatusText(code), nil
+	}
 	violations := httputils.Violations{}
 	switch actual := err.(type) {
 	case *response.Error:
@@ -116,3 +121,33 @@ func NormalizeErr(err error, statusCode int) (int, string, interface{}) {
 		return statusCode, err.Error(), nil
 	}
 }
+
+// BudgetStatusCode returns 504 for errors caused by exceeded execution deadline, 503 for exhausted connector
+// bulkhead, otherwise 0
+func BudgetStatusCode(err error) int {
+	switch actual := err.(type) {
+	case nil:
+		return 0
+	case *response.Error:
+		if actual != nil && actual.Err != nil {
+			return BudgetStatusCode(actual.Err)
+		}
+	case *response.Errors:
+		for _, anError := range actual.Errors {
+			if anError == nil {
+				continue
+			}
+			if code := BudgetStatusCode(anError.Err); code != 0 {
+				return code
+			}
+		}
+		return 0
+	}
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout
+	case errors.Is(err, view.ErrConnectorBusy):
+		return http.StatusServiceUnavailable
+	}
+	return 0
+}
@@ -0,0 +1,108 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/viant/datly/gateway/router/proxy"
+	"github.com/viant/datly/gateway/rpc"
+	"github.com/viant/datly/repository"
+	"github.com/viant/datly/repository/locator/output/keys"
+	dpath "github.com/viant/datly/repository/path"
+	"github.com/viant/datly/view/state"
+)
+
+func (r *Router) buildRPCIntegration(aPath *dpath.Path, aRoute *Route, provider *repository.Provider) error {
+	if aPath.Internal {
+		return nil
+	}
+	component, err := provider.Component(context.Background())
+	if err != nil {
+		return fmt.Errorf("failed to get component from provider: %w", err)
+	}
+	meta := aPath.Meta.Build(component.View.Name, component.View.Table, &aPath.Path)
+	name := rpc.Identifier(meta.Name)
+	if prev, ok := r.rpcMethods[name]; ok && (prev.URI != aPath.URI || prev.HTTPMethod != aPath.Method) {
+		name += rpc.Identifier(strings.ToLower(aPath.Method))
+	}
+	inputType := r.buildToolInputType(context.Background(), component)
+	method := &rpc.Method{
+		Name:       name,
+		URI:        aPath.URI,
+		HTTPMethod: aPath.Method,
+		Input:      inputType,
+		Output:     component.OutputType(),
+		CaseFormat: component.IOConfig().CaseFormat,
+		Invoke:     r.rpcInvoker(component, aRoute),
+	}
+	if aPath.Method == http.MethodGet {
+		r.initRPCStream(method, component, inputType)
+	}
+	if r.rpcMethods == nil {
+		r.rpcMethods = map[string]*rpc.Method{}
+	}
+	r.rpcMethods[method.Name] = method
+	return nil
+}
+
+// initRPCStream enables server-streaming variant when a reader component returns a collection
+func (r *Router) initRPCStream(method *rpc.Method, component *repository.Component, inputType reflect.Type) {
+	outputType := method.Output
+	for outputType != nil && outputType.Kind() == reflect.Ptr {
+		outputType = outputType.Elem()
+	}
+	if outputType == nil {
+		return
+	}
+	switch outputType.Kind() {
+	case reflect.Slice:
+		method.Stream = true
+	case reflect.Struct:
+		parameter := component.Output.Type.Parameters.LookupByLocation(state.KindOutput, keys.ViewData)
+		if parameter == nil || component.Output.Cardinality != state.Many {
+			return
+		}
+		if field, ok := outputType.FieldByName(parameter.Name); ok && field.Type.Kind() == reflect.Slice {
+			method.Stream = true
+			method.StreamField = field.Name
+		}
+	}
+	if !method.Stream || component.View == nil || component.View.Selector == nil {
+		return
+	}
+	selector := component.View.Selector
+	if selector.LimitParameter == nil || selector.OffsetParameter == nil {
+		return
+	}
+	limitArg, offsetArg := strings.Title(selector.LimitParameter.Name), strings.Title(selector.OffsetParameter.Name)
+	if _, ok := inputType.FieldByName(limitArg); !ok {
+		return
+	}
+	if _, ok := inputType.FieldByName(offsetArg); !ok {
+		return
+	}
+	method.LimitArg, method.OffsetArg = limitArg, offsetArg
+}
+
+func (r *Router) rpcInvoker(component *repository.Component, aRoute *Route) rpc.Invoker {
+	return func(ctx context.Context, request *rpc.Request) (*rpc.Response, error) {
+		httpRequest, matched, _, rpcErr := r.newComponentRequest(ctx, aRoute, component, request.Arguments, request.Header)
+		if rpcErr != nil {
+			return nil, &rpc.StatusError{StatusCode: http.StatusBadRequest, Message: rpcErr.Message}
+		}
+		writer := proxy.NewWriter()
+		matched.Handle(writer, httpRequest)
+		data, err := decodeToolResponseBody(writer)
+		if err != nil {
+			return nil, err
+		}
+		statusCode := writer.Code
+		if statusCode == 0 {
+			statusCode = http.StatusOK
+		}
+		return &rpc.Response{StatusCode: statusCode, Header: writer.HeaderMap, Body: data}, nil
+	}
+}
@@ -0,0 +1,47 @@
+package rpc
+
+import "strings"
+
+const (
+	defaultPackage        = "datly.v1"
+	defaultService        = "Datly"
+	defaultStreamPageSize = 1000
+)
+
+// Config represents gRPC/Connect front door config
+type Config struct {
+	Port           *int
+	Package        string //proto package, datly.v1 by default
+	Service        string //proto service name, Datly by default
+	Reflection     *bool  //enables gRPC server reflection, true by default
+	Connect        *bool  //enables Connect protocol handler, true by default
+	StreamPageSize int    //number of rows fetched per page by server-streaming methods
+}
+
+// Init initialises config defaults
+func (c *Config) Init() {
+	if strings.TrimSpace(c.Package) == "" {
+		c.Package = defaultPackage
+	}
+	if strings.TrimSpace(c.Service) == "" {
+		c.Service = defaultService
+	}
+	if c.StreamPageSize == 0 {
+		c.StreamPageSize = defaultStreamPageSize
+	}
+}
+
+// ReflectionEnabled returns true if server reflection is enabled
+func (c *Config) ReflectionEnabled() bool {
+	return c.Reflection == nil || *c.Reflection
+}
+
+// ConnectEnabled returns true if Connect protocol handler is enabled
+func (c *Config) ConnectEnabled() bool {
+	return c.Connect == nil || *c.Connect
+}
+
+// ServiceName returns fully qualified proto service name
+func (c *Config) ServiceName() string {
+	return c.Package + "." + c.Service
+}
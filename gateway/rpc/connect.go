@@ -0,0 +1,217 @@
+package rpc
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	connectContentJSON        = "application/json"
+	connectContentProto       = "application/proto"
+	connectStreamContentJSON  = "application/connect+json"
+	connectStreamContentProto = "application/connect+proto"
+	connectEndStreamFlag      = 0x02
+)
+
+type connectError struct {
+	Code    string `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+// serveConnect handles Connect protocol unary and server-streaming requests
+func (s *Server) serveConnect(writer http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodPost {
+		writer.Header().Set("Allow", http.MethodPost)
+		writer.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	aBinding, err := s.registry.lookup(request.URL.Path)
+	if err != nil {
+		writeConnectError(writer, connectStatus(codes.Unimplemented), codes.Unimplemented, err.Error())
+		return
+	}
+	contentType := strings.TrimSpace(strings.Split(request.Header.Get("Content-Type"), ";")[0])
+	isStream := strings.HasPrefix(contentType, "application/connect+")
+	isProto := contentType == connectContentProto || contentType == connectStreamContentProto
+	if isStream != aBinding.streaming {
+		writeConnectError(writer, http.StatusUnsupportedMediaType, codes.InvalidArgument, "unsupported content type: "+contentType)
+		return
+	}
+	data, err := io.ReadAll(request.Body)
+	if err != nil {
+		writeConnectError(writer, http.StatusBadRequest, codes.InvalidArgument, err.Error())
+		return
+	}
+	if isStream {
+		if data, err = unwrapEnvelope(data); err != nil {
+			writeConnectError(writer, http.StatusBadRequest, codes.InvalidArgument, err.Error())
+			return
+		}
+	}
+	input := aBinding.newInput()
+	if isProto {
+		err = proto.Unmarshal(data, input)
+	} else if len(data) > 0 {
+		err = unmarshalOptions.Unmarshal(data, input)
+	}
+	if err != nil {
+		writeConnectError(writer, http.StatusBadRequest, codes.InvalidArgument, err.Error())
+		return
+	}
+	ctx := request.Context()
+	if isStream {
+		writer.Header().Set("Content-Type", contentType)
+		writer.WriteHeader(http.StatusOK)
+		err = aBinding.stream(ctx, input, request.Header, s.config.StreamPageSize, func(message proto.Message) error {
+			payload, err := marshalConnect(message, isProto)
+			if err != nil {
+				return err
+			}
+			if err = writeEnvelope(writer, 0, payload); err != nil {
+				return err
+			}
+			if flusher, ok := writer.(http.Flusher); ok {
+				flusher.Flush()
+			}
+			return nil
+		})
+		endStream := map[string]interface{}{}
+		if err != nil {
+			code, message := connectCode(err)
+			endStream["error"] = &connectError{Code: connectCodeName(code), Message: message}
+		}
+		payload, _ := json.Marshal(endStream)
+		_ = writeEnvelope(writer, connectEndStreamFlag, payload)
+		return
+	}
+	output, err := aBinding.call(ctx, input, request.Header)
+	if err != nil {
+		code, message := connectCode(err)
+		writeConnectError(writer, connectStatus(code), code, message)
+		return
+	}
+	payload, err := marshalConnect(output, isProto)
+	if err != nil {
+		writeConnectError(writer, http.StatusInternalServerError, codes.Internal, err.Error())
+		return
+	}
+	if isProto {
+		writer.Header().Set("Content-Type", connectContentProto)
+	} else {
+		writer.Header().Set("Content-Type", connectContentJSON)
+	}
+	writer.WriteHeader(http.StatusOK)
+	_, _ = writer.Write(payload)
+}
+
+func marshalConnect(message proto.Message, isProto bool) ([]byte, error) {
+	if isProto {
+		return proto.Marshal(message)
+	}
+	return protojson.Marshal(message)
+}
+
+func unwrapEnvelope(data []byte) ([]byte, error) {
+	if len(data) < 5 {
+		return nil, errors.New("invalid connect envelope")
+	}
+	size := binary.BigEndian.Uint32(data[1:5])
+	if int(size) > len(data)-5 {
+		return nil, errors.New("invalid connect envelope size")
+	}
+	return data[5 : 5+size], nil
+}
+
+func writeEnvelope(writer io.Writer, flags byte, payload []byte) error {
+	prefix := make([]byte, 5)
+	prefix[0] = flags
+	binary.BigEndian.PutUint32(prefix[1:], uint32(len(payload)))
+	if _, err := writer.Write(prefix); err != nil {
+		return err
+	}
+	_, err := writer.Write(payload)
+	return err
+}
+
+func writeConnectError(writer http.ResponseWriter, statusCode int, code codes.Code, message string) {
+	payload, _ := json.Marshal(&connectError{Code: connectCodeName(code), Message: message})
+	writer.Header().Set("Content-Type", connectContentJSON)
+	writer.WriteHeader(statusCode)
+	_, _ = writer.Write(payload)
+}
+
+func connectCode(err error) (codes.Code, string) {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return grpcCode(statusErr.StatusCode), statusErr.Message
+	}
+	if st, ok := status.FromError(grpcError(err)); ok {
+		return st.Code(), st.Message()
+	}
+	return codes.Internal, err.Error()
+}
+
+// connectCodeName returns Connect protocol code name, i.e. "invalid_argument"
+func connectCodeName(code codes.Code) string {
+	switch code {
+	case codes.Canceled:
+		return "canceled"
+	case codes.InvalidArgument:
+		return "invalid_argument"
+	case codes.DeadlineExceeded:
+		return "deadline_exceeded"
+	case codes.NotFound:
+		return "not_found"
+	case codes.AlreadyExists:
+		return "already_exists"
+	case codes.PermissionDenied:
+		return "permission_denied"
+	case codes.ResourceExhausted:
+		return "resource_exhausted"
+	case codes.FailedPrecondition:
+		return "failed_precondition"
+	case codes.Unimplemented:
+		return "unimplemented"
+	case codes.Internal:
+		return "internal"
+	case codes.Unavailable:
+		return "unavailable"
+	case codes.Unauthenticated:
+		return "unauthenticated"
+	}
+	return "unknown"
+}
+
+// connectStatus returns HTTP status code mandated by Connect protocol for unary errors
+func connectStatus(code codes.Code) int {
+	switch code {
+	case codes.Canceled:
+		return 499
+	case codes.InvalidArgument, codes.FailedPrecondition:
+		return http.StatusBadRequest
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.NotFound, codes.Unimplemented:
+		return http.StatusNotFound
+	case codes.AlreadyExists:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	}
+	return http.StatusInternalServerError
+}
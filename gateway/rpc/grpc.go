@@ -0,0 +1,110 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// handleStream handles every gRPC call as an unknown service and dispatches it to registered component methods
+func (s *Server) handleStream(_ interface{}, stream grpc.ServerStream) error {
+	fullMethod, ok := grpc.MethodFromServerStream(stream)
+	if !ok {
+		return status.Error(codes.Internal, "failed to resolve method")
+	}
+	aBinding, err := s.registry.lookup(fullMethod)
+	if err != nil {
+		return status.Error(codes.Unimplemented, err.Error())
+	}
+	input := aBinding.newInput()
+	if err = stream.RecvMsg(input); err != nil {
+		return err
+	}
+	ctx := stream.Context()
+	header := metadataHeader(ctx)
+	if aBinding.streaming {
+		err = aBinding.stream(ctx, input, header, s.config.StreamPageSize, func(message proto.Message) error {
+			return stream.SendMsg(message)
+		})
+		return grpcError(err)
+	}
+	output, err := aBinding.call(ctx, input, header)
+	if err != nil {
+		return grpcError(err)
+	}
+	return stream.SendMsg(output)
+}
+
+// metadataHeader converts incoming gRPC metadata into HTTP headers, so that auth and API key checks apply unchanged
+func metadataHeader(ctx context.Context) http.Header {
+	header := http.Header{}
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return header
+	}
+	for key, values := range md {
+		if strings.HasPrefix(key, ":") || strings.HasPrefix(key, "grpc-") {
+			continue
+		}
+		for _, value := range values {
+			header.Add(key, value)
+		}
+	}
+	return header
+}
+
+func grpcError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return status.Error(grpcCode(statusErr.StatusCode), statusErr.Message)
+	}
+	switch {
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+func grpcCode(statusCode int) codes.Code {
+	switch statusCode {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusConflict:
+		return codes.AlreadyExists
+	case http.StatusPreconditionFailed:
+		return codes.FailedPrecondition
+	case http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case http.StatusNotImplemented:
+		return codes.Unimplemented
+	case http.StatusServiceUnavailable:
+		return codes.Unavailable
+	case http.StatusGatewayTimeout:
+		return codes.DeadlineExceeded
+	}
+	if statusCode >= http.StatusInternalServerError {
+		return codes.Internal
+	}
+	return codes.Unknown
+}
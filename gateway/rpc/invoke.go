@@ -0,0 +1,183 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/viant/tagly/format/text"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+type (
+	// StatusError represents component invocation error with HTTP status code
+	StatusError struct {
+		StatusCode int
+		Message    string
+	}
+)
+
+func (e *StatusError) Error() string {
+	return e.Message
+}
+
+var unmarshalOptions = protojson.UnmarshalOptions{DiscardUnknown: true}
+
+func (b *binding) newInput() *dynamicpb.Message {
+	return dynamicpb.NewMessage(b.desc.Input())
+}
+
+// call invokes component and converts its JSON output into method response
+func (b *binding) call(ctx context.Context, input proto.Message, header http.Header) (proto.Message, error) {
+	arguments, err := messageArguments(input)
+	if err != nil {
+		return nil, &StatusError{StatusCode: http.StatusBadRequest, Message: err.Error()}
+	}
+	body, err := b.invoke(ctx, arguments, header)
+	if err != nil {
+		return nil, err
+	}
+	if !isStruct(b.method.Output) {
+		body = append(append([]byte(`{"data":`), body...), '}')
+	}
+	output := dynamicpb.NewMessage(b.desc.Output())
+	if len(body) > 0 {
+		if err = unmarshalOptions.Unmarshal(body, output); err != nil {
+			return nil, fmt.Errorf("failed to convert %v output: %w", b.method.Name, err)
+		}
+	}
+	return output, nil
+}
+
+// stream invokes component and sends each output row, paging through rows when limit/offset arguments are defined
+func (b *binding) stream(ctx context.Context, input proto.Message, header http.Header, pageSize int, send func(message proto.Message) error) error {
+	arguments, err := messageArguments(input)
+	if err != nil {
+		return &StatusError{StatusCode: http.StatusBadRequest, Message: err.Error()}
+	}
+	paging := b.method.LimitArg != "" && b.method.OffsetArg != "" && pageSize > 0
+	limitKey := fieldKey(b.method.Input, b.method.LimitArg, text.CaseFormatUndefined)
+	offsetKey := fieldKey(b.method.Input, b.method.OffsetArg, text.CaseFormatUndefined)
+	offset := 0
+	if paging {
+		offset = asInt(arguments[offsetKey])
+		arguments[limitKey] = pageSize
+	}
+	itemKey := b.streamItemKey()
+	for {
+		if paging {
+			arguments[offsetKey] = offset
+		}
+		body, err := b.invoke(ctx, arguments, header)
+		if err != nil {
+			return err
+		}
+		items, err := streamItems(body, itemKey)
+		if err != nil {
+			return fmt.Errorf("failed to read %v stream rows: %w", b.method.Name, err)
+		}
+		for _, item := range items {
+			message := dynamicpb.NewMessage(b.desc.Output())
+			if err = unmarshalOptions.Unmarshal(item, message); err != nil {
+				return fmt.Errorf("failed to convert %v stream row: %w", b.method.Name, err)
+			}
+			if err = send(message); err != nil {
+				return err
+			}
+		}
+		if !paging || len(items) < pageSize {
+			return nil
+		}
+		if err = ctx.Err(); err != nil {
+			return err
+		}
+		offset += len(items)
+	}
+}
+
+func (b *binding) invoke(ctx context.Context, arguments map[string]interface{}, header http.Header) ([]byte, error) {
+	response, err := b.method.Invoke(ctx, &Request{Arguments: arguments, Header: header})
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode >= http.StatusBadRequest {
+		message := strings.TrimSpace(string(response.Body))
+		if message == "" {
+			message = http.StatusText(response.StatusCode)
+		}
+		return nil, &StatusError{StatusCode: response.StatusCode, Message: message}
+	}
+	return response.Body, nil
+}
+
+func (b *binding) streamItemKey() string {
+	if b.method.StreamField == "" {
+		return ""
+	}
+	return fieldKey(b.method.Output, b.method.StreamField, b.method.CaseFormat)
+}
+
+// fieldKey returns JSON key of a Go struct field
+func fieldKey(rType reflect.Type, fieldName string, caseFormat text.CaseFormat) string {
+	if rType = indirect(rType); rType == nil || rType.Kind() != reflect.Struct {
+		return fieldName
+	}
+	field, ok := rType.FieldByName(fieldName)
+	if !ok {
+		return fieldName
+	}
+	name, _ := fieldJSONName(field, caseFormat)
+	return name
+}
+
+func streamItems(body []byte, key string) ([]json.RawMessage, error) {
+	if len(body) == 0 {
+		return nil, nil
+	}
+	var items []json.RawMessage
+	if key == "" {
+		return items, json.Unmarshal(body, &items)
+	}
+	output := map[string]json.RawMessage{}
+	if err := json.Unmarshal(body, &output); err != nil {
+		return nil, err
+	}
+	data, ok := output[key]
+	if !ok || string(data) == "null" {
+		return nil, nil
+	}
+	return items, json.Unmarshal(data, &items)
+}
+
+func messageArguments(message proto.Message) (map[string]interface{}, error) {
+	data, err := protojson.Marshal(message)
+	if err != nil {
+		return nil, err
+	}
+	result := map[string]interface{}{}
+	return result, json.Unmarshal(data, &result)
+}
+
+func isStruct(rType reflect.Type) bool {
+	rType = indirect(rType)
+	return rType == nil || rType.Kind() == reflect.Struct
+}
+
+func asInt(value interface{}) int {
+	switch actual := value.(type) {
+	case float64:
+		return int(actual)
+	case int:
+		return actual
+	case string:
+		var result int
+		_, _ = fmt.Sscanf(actual, "%d", &result)
+		return result
+	}
+	return 0
+}
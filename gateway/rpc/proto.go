@@ -0,0 +1,109 @@
+package rpc
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// ProtoFile renders registered methods as .proto file content
+func (r *Registry) ProtoFile() ([]byte, error) {
+	file, err := r.FileDescriptorProto()
+	if err != nil {
+		return nil, err
+	}
+	return RenderProto(file), nil
+}
+
+// RenderProto renders file descriptor as .proto file content
+func RenderProto(file *descriptorpb.FileDescriptorProto) []byte {
+	builder := &strings.Builder{}
+	builder.WriteString("// Code generated by datly. DO NOT EDIT.\n\n")
+	builder.WriteString(`syntax = "proto3";` + "\n\n")
+	builder.WriteString("package " + file.GetPackage() + ";\n\n")
+	for _, dependency := range file.Dependency {
+		builder.WriteString(`import "` + dependency + `";` + "\n")
+	}
+	if len(file.Dependency) > 0 {
+		builder.WriteString("\n")
+	}
+	pkgPrefix := "." + file.GetPackage() + "."
+	for _, service := range file.Service {
+		builder.WriteString("service " + service.GetName() + " {\n")
+		for _, method := range service.Method {
+			output := strings.TrimPrefix(method.GetOutputType(), pkgPrefix)
+			if method.GetServerStreaming() {
+				output = "stream " + output
+			}
+			builder.WriteString(fmt.Sprintf("  rpc %v(%v) returns (%v);\n", method.GetName(), strings.TrimPrefix(method.GetInputType(), pkgPrefix), output))
+		}
+		builder.WriteString("}\n")
+	}
+	for _, message := range file.MessageType {
+		builder.WriteString("\n")
+		renderMessage(builder, message, pkgPrefix, "")
+	}
+	return []byte(builder.String())
+}
+
+func renderMessage(builder *strings.Builder, message *descriptorpb.DescriptorProto, pkgPrefix, indent string) {
+	entries := map[string]*descriptorpb.DescriptorProto{}
+	for _, nested := range message.NestedType {
+		if nested.GetOptions().GetMapEntry() {
+			entries[message.GetName()+"."+nested.GetName()] = nested
+		}
+	}
+	builder.WriteString(indent + "message " + message.GetName() + " {\n")
+	for _, field := range message.Field {
+		builder.WriteString(indent + "  ")
+		typeName := strings.TrimPrefix(field.GetTypeName(), pkgPrefix)
+		if entry, ok := entries[typeName]; ok {
+			builder.WriteString(fmt.Sprintf("map<%v, %v>", fieldType(entry.Field[0], pkgPrefix), fieldType(entry.Field[1], pkgPrefix)))
+		} else {
+			if field.GetLabel() == descriptorpb.FieldDescriptorProto_LABEL_REPEATED {
+				builder.WriteString("repeated ")
+			}
+			builder.WriteString(fieldType(field, pkgPrefix))
+		}
+		builder.WriteString(fmt.Sprintf(" %v = %v", field.GetName(), field.GetNumber()))
+		if field.GetJsonName() != "" && field.GetJsonName() != lowerCamel(field.GetName()) {
+			builder.WriteString(fmt.Sprintf(` [json_name = "%v"]`, field.GetJsonName()))
+		}
+		builder.WriteString(";\n")
+	}
+	for _, nested := range message.NestedType {
+		if nested.GetOptions().GetMapEntry() {
+			continue
+		}
+		renderMessage(builder, nested, pkgPrefix, indent+"  ")
+	}
+	builder.WriteString(indent + "}\n")
+}
+
+func fieldType(field *descriptorpb.FieldDescriptorProto, pkgPrefix string) string {
+	if field.GetType() == descriptorpb.FieldDescriptorProto_TYPE_MESSAGE {
+		typeName := strings.TrimPrefix(field.GetTypeName(), pkgPrefix)
+		return strings.TrimPrefix(typeName, ".")
+	}
+	return strings.ToLower(strings.TrimPrefix(field.GetType().String(), "TYPE_"))
+}
+
+// lowerCamel returns default proto JSON name for a field name
+func lowerCamel(name string) string {
+	var result []byte
+	upper := false
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		if c == '_' {
+			upper = true
+			continue
+		}
+		if upper && 'a' <= c && c <= 'z' {
+			c -= 'a' - 'A'
+		}
+		upper = false
+		result = append(result, c)
+	}
+	return string(result)
+}
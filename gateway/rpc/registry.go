@@ -0,0 +1,306 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"sync"
+
+	"github.com/viant/tagly/format/text"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	_ "google.golang.org/protobuf/types/known/structpb"
+)
+
+type (
+	// Request represents component invocation request
+	Request struct {
+		Arguments map[string]interface{}
+		Header    http.Header
+	}
+
+	// Response represents component invocation response
+	Response struct {
+		StatusCode int
+		Header     http.Header
+		Body       []byte
+	}
+
+	// Invoker invokes a component with JSON arguments
+	Invoker func(ctx context.Context, request *Request) (*Response, error)
+
+	// Method represents component exposed as RPC method
+	Method struct {
+		Name       string
+		URI        string
+		HTTPMethod string
+		Input      reflect.Type
+		Output     reflect.Type
+		CaseFormat text.CaseFormat
+		//Stream adds server-streaming <Name>Stream method emitting Output slice elements
+		Stream bool
+		//StreamField output struct field holding streamed rows, empty when output is a slice
+		StreamField string
+		//LimitArg and OffsetArg are Input struct fields used to page through streamed rows
+		LimitArg  string
+		OffsetArg string
+		Invoke    Invoker
+	}
+
+	//Registry represents RPC method registry
+	Registry struct {
+		config  *Config
+		mux     sync.RWMutex
+		methods map[string]*Method
+		file    *descriptor
+	}
+
+	descriptor struct {
+		proto   *descriptorpb.FileDescriptorProto
+		file    protoreflect.FileDescriptor
+		service protoreflect.ServiceDescriptor
+		methods map[string]*binding
+	}
+
+	binding struct {
+		method    *Method
+		desc      protoreflect.MethodDescriptor
+		streaming bool
+	}
+)
+
+// Register registers or replaces a method
+func (r *Registry) Register(method *Method) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	r.methods[method.Name] = method
+	r.file = nil
+}
+
+// Replace replaces all registered methods, methods of removed components are no longer served
+func (r *Registry) Replace(methods map[string]*Method) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	r.methods = make(map[string]*Method, len(methods))
+	for name, method := range methods {
+		r.methods[name] = method
+	}
+	r.file = nil
+}
+
+// Method returns registered method
+func (r *Registry) Method(name string) (*Method, bool) {
+	r.mux.RLock()
+	defer r.mux.RUnlock()
+	method, ok := r.methods[name]
+	return method, ok
+}
+
+// Methods returns registered methods sorted by name
+func (r *Registry) Methods() []*Method {
+	r.mux.RLock()
+	defer r.mux.RUnlock()
+	result := make([]*Method, 0, len(r.methods))
+	for _, method := range r.methods {
+		result = append(result, method)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
+
+// Config returns registry config
+func (r *Registry) Config() *Config {
+	return r.config
+}
+
+// FileDescriptorProto returns proto file for registered methods
+func (r *Registry) FileDescriptorProto() (*descriptorpb.FileDescriptorProto, error) {
+	file, err := r.descriptor()
+	if err != nil {
+		return nil, err
+	}
+	return file.proto, nil
+}
+
+// FileName returns proto file name
+func (r *Registry) FileName() string {
+	return r.config.Package + ".proto"
+}
+
+// FindFileByPath implements protodesc.Resolver
+func (r *Registry) FindFileByPath(path string) (protoreflect.FileDescriptor, error) {
+	if path == r.FileName() {
+		file, err := r.descriptor()
+		if err != nil {
+			return nil, err
+		}
+		return file.file, nil
+	}
+	return protoregistry.GlobalFiles.FindFileByPath(path)
+}
+
+// FindDescriptorByName implements protodesc.Resolver
+func (r *Registry) FindDescriptorByName(name protoreflect.FullName) (protoreflect.Descriptor, error) {
+	file, err := r.descriptor()
+	if err != nil {
+		return nil, err
+	}
+	if name == file.service.FullName() {
+		return file.service, nil
+	}
+	if desc := findDescriptor(file.file, name); desc != nil {
+		return desc, nil
+	}
+	return protoregistry.GlobalFiles.FindDescriptorByName(name)
+}
+
+func (r *Registry) lookup(fullMethod string) (*binding, error) {
+	file, err := r.descriptor()
+	if err != nil {
+		return nil, err
+	}
+	ret, ok := file.methods[fullMethod]
+	if !ok {
+		return nil, fmt.Errorf("unknown method: %v", fullMethod)
+	}
+	return ret, nil
+}
+
+func (r *Registry) descriptor() (*descriptor, error) {
+	r.mux.RLock()
+	file := r.file
+	r.mux.RUnlock()
+	if file != nil {
+		return file, nil
+	}
+	methods := r.Methods()
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	if r.file != nil {
+		return r.file, nil
+	}
+	var err error
+	if r.file, err = r.buildDescriptor(methods); err != nil {
+		return nil, err
+	}
+	return r.file, nil
+}
+
+func (r *Registry) buildDescriptor(methods []*Method) (*descriptor, error) {
+	schema := NewSchema(r.config.Package)
+	service := &descriptorpb.ServiceDescriptorProto{Name: proto.String(r.config.Service)}
+	streaming := map[string]bool{}
+	for _, method := range methods {
+		input, err := schema.Message(method.Name+"Request", method.Input, text.CaseFormatUndefined)
+		if err != nil {
+			return nil, err
+		}
+		output, err := schema.Message(method.Name+"Response", method.Output, method.CaseFormat)
+		if err != nil {
+			return nil, err
+		}
+		service.Method = append(service.Method, &descriptorpb.MethodDescriptorProto{
+			Name:       proto.String(method.Name),
+			InputType:  proto.String(schema.fullName(input)),
+			OutputType: proto.String(schema.fullName(output)),
+		})
+		if !method.Stream {
+			continue
+		}
+		itemType, err := streamItemType(method)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build %v stream: %w", method.Name, err)
+		}
+		item, err := schema.Element(method.Name+"Item", itemType, method.CaseFormat)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build %v stream: %w", method.Name, err)
+		}
+		streamName := method.Name + "Stream"
+		streaming[streamName] = true
+		service.Method = append(service.Method, &descriptorpb.MethodDescriptorProto{
+			Name:            proto.String(streamName),
+			InputType:       proto.String(schema.fullName(input)),
+			OutputType:      proto.String(schema.fullName(item)),
+			ServerStreaming: proto.Bool(true),
+		})
+	}
+	fileProto := &descriptorpb.FileDescriptorProto{
+		Name:        proto.String(r.FileName()),
+		Package:     proto.String(r.config.Package),
+		Syntax:      proto.String("proto3"),
+		Dependency:  schema.Dependencies(),
+		MessageType: schema.Messages(),
+		Service:     []*descriptorpb.ServiceDescriptorProto{service},
+	}
+	file, err := protodesc.NewFile(fileProto, protoregistry.GlobalFiles)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build proto descriptor: %w", err)
+	}
+	ret := &descriptor{proto: fileProto, file: file, service: file.Services().Get(0), methods: map[string]*binding{}}
+	byName := map[string]*Method{}
+	for _, method := range methods {
+		byName[method.Name] = method
+		byName[method.Name+"Stream"] = method
+	}
+	for i := 0; i < ret.service.Methods().Len(); i++ {
+		desc := ret.service.Methods().Get(i)
+		name := string(desc.Name())
+		fullMethod := "/" + string(ret.service.FullName()) + "/" + name
+		ret.methods[fullMethod] = &binding{method: byName[name], desc: desc, streaming: streaming[name]}
+	}
+	return ret, nil
+}
+
+func streamItemType(method *Method) (reflect.Type, error) {
+	outputType := indirect(method.Output)
+	if method.StreamField == "" {
+		return outputType, nil
+	}
+	if outputType == nil || outputType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("invalid output type: %v", method.Output)
+	}
+	for i := 0; i < outputType.NumField(); i++ {
+		field := outputType.Field(i)
+		if field.Name == method.StreamField {
+			return field.Type, nil
+		}
+	}
+	return nil, fmt.Errorf("failed to lookup stream field: %v", method.StreamField)
+}
+
+func findDescriptor(file protoreflect.FileDescriptor, name protoreflect.FullName) protoreflect.Descriptor {
+	messages := file.Messages()
+	for i := 0; i < messages.Len(); i++ {
+		if desc := findMessage(messages.Get(i), name); desc != nil {
+			return desc
+		}
+	}
+	return nil
+}
+
+func findMessage(message protoreflect.MessageDescriptor, name protoreflect.FullName) protoreflect.Descriptor {
+	if message.FullName() == name {
+		return message
+	}
+	nested := message.Messages()
+	for i := 0; i < nested.Len(); i++ {
+		if desc := findMessage(nested.Get(i), name); desc != nil {
+			return desc
+		}
+	}
+	return nil
+}
+
+// NewRegistry creates RPC method registry
+func NewRegistry(config *Config) *Registry {
+	if config == nil {
+		config = &Config{}
+	}
+	config.Init()
+	return &Registry{config: config, methods: map[string]*Method{}}
+}
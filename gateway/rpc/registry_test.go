@@ -0,0 +1,133 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testVendor struct {
+	ID       int
+	Name     string
+	Tags     []string
+	Attrs    map[string]string
+	Products []*testProduct
+}
+
+type testProduct struct {
+	ID    int     `json:"id"`
+	Price float64 `json:"price"`
+	Meta  interface{}
+}
+
+type testInput struct {
+	VendorID int
+	Limit    int `json:",omitempty"`
+	Offset   int `json:",omitempty"`
+}
+
+func TestRegistry_ProtoFile(t *testing.T) {
+	registry := NewRegistry(nil)
+	registry.Register(&Method{
+		Name:   "Vendors",
+		Input:  reflect.TypeOf(testInput{}),
+		Output: reflect.TypeOf([]*testVendor{}),
+		Stream: true,
+	})
+	data, err := registry.ProtoFile()
+	require.NoError(t, err)
+	proto := string(data)
+	assert.Contains(t, proto, "package datly.v1;")
+	assert.Contains(t, proto, `import "google/protobuf/struct.proto";`)
+	assert.Contains(t, proto, "rpc Vendors(VendorsRequest) returns (VendorsResponse);")
+	assert.Contains(t, proto, "rpc VendorsStream(VendorsRequest) returns (stream TestVendor);")
+	assert.Contains(t, proto, "repeated TestVendor data = 1;")
+	assert.Contains(t, proto, "map<string, string> attrs = 4;")
+	assert.Contains(t, proto, "repeated TestProduct products = 5;")
+	assert.Contains(t, proto, "google.protobuf.Value meta = 3;")
+	assert.Contains(t, proto, "int64 vendor_id = 1;")
+}
+
+func TestServer_Connect(t *testing.T) {
+	port := 0
+	registry := NewRegistry(&Config{Port: &port, StreamPageSize: 2})
+	var calls []map[string]interface{}
+	registry.Register(&Method{
+		Name:      "Vendors",
+		Input:     reflect.TypeOf(testInput{}),
+		Output:    reflect.TypeOf([]*testVendor{}),
+		Stream:    true,
+		LimitArg:  "Limit",
+		OffsetArg: "Offset",
+		Invoke: func(ctx context.Context, request *Request) (*Response, error) {
+			calls = append(calls, request.Arguments)
+			if request.Header.Get("Authorization") == "" {
+				return &Response{StatusCode: http.StatusUnauthorized}, nil
+			}
+			if asInt(request.Arguments["offset"]) >= 2 {
+				return &Response{StatusCode: http.StatusOK, Body: []byte(`[{"id":3,"name":"c"}]`)}, nil
+			}
+			return &Response{StatusCode: http.StatusOK, Body: []byte(`[{"id":1,"name":"a","products":[{"id":10,"price":1.5}]},{"id":2,"name":"b"}]`)}, nil
+		},
+	})
+	srv, err := NewServer(registry)
+	require.NoError(t, err)
+
+	request := httptest.NewRequest(http.MethodPost, "/datly.v1.Datly/Vendors", bytes.NewReader([]byte(`{"vendorId":1}`)))
+	request.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+	srv.ServeHTTP(recorder, request)
+	assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), `"unauthenticated"`)
+
+	request = httptest.NewRequest(http.MethodPost, "/datly.v1.Datly/Vendors", bytes.NewReader([]byte(`{"vendorId":1}`)))
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Authorization", "Bearer token")
+	recorder = httptest.NewRecorder()
+	srv.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	output := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &output))
+	assert.Len(t, output["data"], 2)
+	assert.EqualValues(t, "1", calls[1]["vendorId"])
+
+	body := &bytes.Buffer{}
+	require.NoError(t, writeEnvelope(body, 0, []byte(`{}`)))
+	request = httptest.NewRequest(http.MethodPost, "/datly.v1.Datly/VendorsStream", body)
+	request.Header.Set("Content-Type", "application/connect+json")
+	request.Header.Set("Authorization", "Bearer token")
+	recorder = httptest.NewRecorder()
+	srv.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	var messages []string
+	data := recorder.Body.Bytes()
+	for len(data) > 0 {
+		payload, err := unwrapEnvelope(data)
+		require.NoError(t, err)
+		messages = append(messages, string(payload))
+		data = data[5+len(payload):]
+	}
+	assert.Equal(t, []string{`{"id":"1","name":"a","products":[{"id":"10","price":1.5}]}`, `{"id":"2","name":"b"}`, `{"id":"3","name":"c"}`, `{}`}, messages)
+}
+
+func TestRegistry_Replace(t *testing.T) {
+	registry := NewRegistry(nil)
+	registry.Register(&Method{Name: "Vendors", Input: reflect.TypeOf(testInput{}), Output: reflect.TypeOf([]*testVendor{})})
+	registry.Replace(map[string]*Method{
+		"Products": {Name: "Products", Input: reflect.TypeOf(testInput{}), Output: reflect.TypeOf([]*testProduct{})},
+	})
+	_, ok := registry.Method("Vendors")
+	assert.False(t, ok)
+	_, ok = registry.Method("Products")
+	assert.True(t, ok)
+	data, err := registry.ProtoFile()
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "rpc Vendors(")
+}
@@ -0,0 +1,334 @@
+package rpc
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/viant/tagly/format/text"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+const (
+	structProtoFile = "google/protobuf/struct.proto"
+	valueTypeName   = ".google.protobuf.Value"
+)
+
+type (
+	// Schema builds proto messages from Go types
+	Schema struct {
+		pkg        string
+		caseFormat text.CaseFormat
+		messages   []*descriptorpb.DescriptorProto
+		byType     map[reflect.Type]string
+		byName     map[string]reflect.Type
+		usesValue  bool
+	}
+)
+
+// NewSchema creates proto schema builder
+func NewSchema(pkg string) *Schema {
+	return &Schema{
+		pkg:        pkg,
+		caseFormat: text.CaseFormatLowerCamel,
+		byType:     map[reflect.Type]string{},
+		byName:     map[string]reflect.Type{},
+	}
+}
+
+// Message adds message for supplied type, slice types are wrapped with a message with repeated "data" field
+func (s *Schema) Message(name string, rType reflect.Type, caseFormat text.CaseFormat) (string, error) {
+	prev := s.caseFormat
+	if caseFormat.IsDefined() {
+		s.caseFormat = caseFormat
+	}
+	defer func() { s.caseFormat = prev }()
+	rType = indirect(rType)
+	if rType == nil {
+		rType = reflect.TypeOf(struct{}{})
+	}
+	if rType.Kind() != reflect.Struct {
+		rType = reflect.StructOf([]reflect.StructField{{Name: "Data", Type: rType, Tag: `json:"data"`}})
+	}
+	name = s.uniqueName(name, rType)
+	if _, ok := s.byName[name]; ok {
+		return name, nil
+	}
+	return s.message(name, rType)
+}
+
+// Element returns slice element message for supplied type
+func (s *Schema) Element(name string, rType reflect.Type, caseFormat text.CaseFormat) (string, error) {
+	rType = indirect(rType)
+	if rType == nil || rType.Kind() != reflect.Slice {
+		return "", fmt.Errorf("expected slice, but had: %v", rType)
+	}
+	elem := indirect(rType.Elem())
+	if elem.Kind() != reflect.Struct {
+		return "", fmt.Errorf("unsupported stream element: %v", elem)
+	}
+	if messageName, ok := s.byType[elem]; ok {
+		return messageName, nil
+	}
+	return s.Message(name, elem, caseFormat)
+}
+
+// Messages returns built messages
+func (s *Schema) Messages() []*descriptorpb.DescriptorProto {
+	return s.messages
+}
+
+// Dependencies returns proto file dependencies
+func (s *Schema) Dependencies() []string {
+	if s.usesValue {
+		return []string{structProtoFile}
+	}
+	return nil
+}
+
+func (s *Schema) message(name string, rType reflect.Type) (string, error) {
+	s.byType[rType] = name
+	s.byName[name] = rType
+	message := &descriptorpb.DescriptorProto{Name: proto.String(name)}
+	s.messages = append(s.messages, message)
+	unique := map[string]bool{}
+	if err := s.appendFields(message, rType, unique); err != nil {
+		return "", err
+	}
+	return name, nil
+}
+
+func (s *Schema) appendFields(message *descriptorpb.DescriptorProto, rType reflect.Type, unique map[string]bool) error {
+	for i := 0; i < rType.NumField(); i++ {
+		field := rType.Field(i)
+		if isEmbedded(field) {
+			if err := s.appendFields(message, indirect(field.Type), unique); err != nil {
+				return err
+			}
+			continue
+		}
+		jsonName, skip := fieldJSONName(field, s.caseFormat)
+		if skip {
+			continue
+		}
+		if unique[protoName(jsonName)] {
+			continue
+		}
+		unique[protoName(jsonName)] = true
+		fieldProto, err := s.field(message, field, jsonName)
+		if err != nil {
+			return fmt.Errorf("failed to build proto field %v.%v: %w", message.GetName(), field.Name, err)
+		}
+		fieldProto.Number = proto.Int32(int32(len(message.Field) + 1))
+		message.Field = append(message.Field, fieldProto)
+	}
+	return nil
+}
+
+func (s *Schema) field(message *descriptorpb.DescriptorProto, field reflect.StructField, jsonName string) (*descriptorpb.FieldDescriptorProto, error) {
+	result := &descriptorpb.FieldDescriptorProto{
+		Name:     proto.String(protoName(jsonName)),
+		JsonName: proto.String(jsonName),
+		Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+	}
+	fieldType := indirect(field.Type)
+	switch fieldType.Kind() {
+	case reflect.Slice, reflect.Array:
+		if fieldType.Elem().Kind() == reflect.Uint8 {
+			result.Type = descriptorpb.FieldDescriptorProto_TYPE_BYTES.Enum()
+			return result, nil
+		}
+		elem := indirect(fieldType.Elem())
+		if elem.Kind() == reflect.Slice || elem.Kind() == reflect.Map && !isScalarKey(elem.Key()) {
+			s.setValue(result)
+			return result, nil
+		}
+		result.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+		return result, s.assignType(result, elem, message.GetName()+Identifier(field.Name))
+	case reflect.Map:
+		if !isScalarKey(fieldType.Key()) {
+			s.setValue(result)
+			return result, nil
+		}
+		entry, err := s.mapEntry(message, field, fieldType)
+		if err != nil {
+			return nil, err
+		}
+		result.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+		result.Type = descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum()
+		result.TypeName = proto.String(s.fullName(message.GetName()) + "." + entry)
+		return result, nil
+	}
+	return result, s.assignType(result, fieldType, message.GetName()+Identifier(field.Name))
+}
+
+func (s *Schema) mapEntry(message *descriptorpb.DescriptorProto, field reflect.StructField, mapType reflect.Type) (string, error) {
+	name := Identifier(field.Name) + "Entry"
+	key := &descriptorpb.FieldDescriptorProto{Name: proto.String("key"), JsonName: proto.String("key"), Number: proto.Int32(1), Label: descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum()}
+	if err := s.assignType(key, mapType.Key(), ""); err != nil {
+		return "", err
+	}
+	value := &descriptorpb.FieldDescriptorProto{Name: proto.String("value"), JsonName: proto.String("value"), Number: proto.Int32(2), Label: descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum()}
+	if err := s.assignType(value, indirect(mapType.Elem()), message.GetName()+Identifier(field.Name)+"Value"); err != nil {
+		return "", err
+	}
+	message.NestedType = append(message.NestedType, &descriptorpb.DescriptorProto{
+		Name:    proto.String(name),
+		Field:   []*descriptorpb.FieldDescriptorProto{key, value},
+		Options: &descriptorpb.MessageOptions{MapEntry: proto.Bool(true)},
+	})
+	return name, nil
+}
+
+func (s *Schema) assignType(field *descriptorpb.FieldDescriptorProto, rType reflect.Type, anonymousName string) error {
+	if rType == reflect.TypeOf(time.Time{}) {
+		field.Type = descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum()
+		return nil
+	}
+	switch rType.Kind() {
+	case reflect.String:
+		field.Type = descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum()
+	case reflect.Bool:
+		field.Type = descriptorpb.FieldDescriptorProto_TYPE_BOOL.Enum()
+	case reflect.Int, reflect.Int64:
+		field.Type = descriptorpb.FieldDescriptorProto_TYPE_INT64.Enum()
+	case reflect.Int8, reflect.Int16, reflect.Int32:
+		field.Type = descriptorpb.FieldDescriptorProto_TYPE_INT32.Enum()
+	case reflect.Uint, reflect.Uint64, reflect.Uintptr:
+		field.Type = descriptorpb.FieldDescriptorProto_TYPE_UINT64.Enum()
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		field.Type = descriptorpb.FieldDescriptorProto_TYPE_UINT32.Enum()
+	case reflect.Float32:
+		field.Type = descriptorpb.FieldDescriptorProto_TYPE_FLOAT.Enum()
+	case reflect.Float64:
+		field.Type = descriptorpb.FieldDescriptorProto_TYPE_DOUBLE.Enum()
+	case reflect.Struct:
+		messageName, ok := s.byType[rType]
+		if !ok {
+			name := rType.Name()
+			if name == "" {
+				name = anonymousName
+			}
+			var err error
+			if messageName, err = s.message(s.uniqueName(Identifier(name), rType), rType); err != nil {
+				return err
+			}
+		}
+		field.Type = descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum()
+		field.TypeName = proto.String(s.fullName(messageName))
+	default:
+		s.setValue(field)
+	}
+	return nil
+}
+
+func (s *Schema) setValue(field *descriptorpb.FieldDescriptorProto) {
+	s.usesValue = true
+	field.Label = descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum()
+	field.Type = descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum()
+	field.TypeName = proto.String(valueTypeName)
+}
+
+func (s *Schema) uniqueName(name string, rType reflect.Type) string {
+	candidate := name
+	for i := 2; ; i++ {
+		prev, ok := s.byName[candidate]
+		if !ok || prev == rType {
+			return candidate
+		}
+		candidate = fmt.Sprintf("%v%v", name, i)
+	}
+}
+
+func (s *Schema) fullName(messageName string) string {
+	return "." + s.pkg + "." + messageName
+}
+
+// fieldJSONName returns JSON name of a field, unexported and "-" tagged fields are skipped
+func fieldJSONName(field reflect.StructField, caseFormat text.CaseFormat) (string, bool) {
+	if !field.IsExported() {
+		return "", true
+	}
+	name := ""
+	if tag, ok := field.Tag.Lookup("json"); ok {
+		if name = strings.Split(tag, ",")[0]; name == "-" {
+			return "", true
+		}
+	}
+	if name == "" {
+		if !caseFormat.IsDefined() {
+			caseFormat = text.CaseFormatLowerCamel
+		}
+		name = text.CaseFormatUpperCamel.Format(field.Name, caseFormat)
+	}
+	return name, false
+}
+
+func isEmbedded(field reflect.StructField) bool {
+	if !field.Anonymous || indirect(field.Type).Kind() != reflect.Struct {
+		return false
+	}
+	tag, ok := field.Tag.Lookup("json")
+	return !ok || strings.Split(tag, ",")[0] == ""
+}
+
+func isScalarKey(rType reflect.Type) bool {
+	switch rType.Kind() {
+	case reflect.String, reflect.Bool, reflect.Int, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
+
+func indirect(rType reflect.Type) reflect.Type {
+	for rType != nil && rType.Kind() == reflect.Ptr {
+		rType = rType.Elem()
+	}
+	return rType
+}
+
+// Identifier converts text into proto message or method identifier
+func Identifier(name string) string {
+	var result []rune
+	upper := true
+	for _, r := range name {
+		if !(unicode.IsLetter(r) || unicode.IsDigit(r)) || r > unicode.MaxASCII {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		result = append(result, r)
+	}
+	if len(result) == 0 || unicode.IsDigit(result[0]) {
+		result = append([]rune("X"), result...)
+	}
+	return string(result)
+}
+
+// protoName converts JSON field name into proto field name
+func protoName(jsonName string) string {
+	var result []rune
+	for i, r := range jsonName {
+		switch {
+		case r > unicode.MaxASCII || !(unicode.IsLetter(r) || unicode.IsDigit(r)):
+			result = append(result, '_')
+		case unicode.IsUpper(r):
+			if i > 0 && len(result) > 0 && result[len(result)-1] != '_' {
+				result = append(result, '_')
+			}
+			result = append(result, unicode.ToLower(r))
+		default:
+			result = append(result, r)
+		}
+	}
+	if len(result) == 0 || unicode.IsDigit(result[0]) {
+		result = append([]rune("f_"), result...)
+	}
+	return string(result)
+}
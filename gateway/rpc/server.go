@@ -0,0 +1,82 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+	reflectionv1 "google.golang.org/grpc/reflection/grpc_reflection_v1"
+)
+
+// Server represents gRPC and Connect front door for components
+type Server struct {
+	http.Server
+	config   *Config
+	registry *Registry
+	grpc     *grpc.Server
+}
+
+// GetServiceInfo returns dynamically registered services, used by server reflection
+func (s *Server) GetServiceInfo() map[string]grpc.ServiceInfo {
+	var methods []grpc.MethodInfo
+	for _, method := range s.registry.Methods() {
+		methods = append(methods, grpc.MethodInfo{Name: method.Name})
+		if method.Stream {
+			methods = append(methods, grpc.MethodInfo{Name: method.Name + "Stream", IsServerStream: true})
+		}
+	}
+	return map[string]grpc.ServiceInfo{
+		s.config.ServiceName(): {Methods: methods, Metadata: s.registry.FileName()},
+	}
+}
+
+// ServeHTTP dispatches gRPC (HTTP/2) traffic to grpc server, and everything else to Connect handler
+func (s *Server) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	if request.ProtoMajor == 2 && strings.HasPrefix(request.Header.Get("Content-Type"), "application/grpc") {
+		s.grpc.ServeHTTP(writer, request)
+		return
+	}
+	if !s.config.ConnectEnabled() {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+	s.serveConnect(writer, request)
+}
+
+// Shutdown stops server
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.grpc.GracefulStop()
+	return s.Server.Shutdown(ctx)
+}
+
+// NewServer creates gRPC/Connect server
+func NewServer(registry *Registry) (*Server, error) {
+	if registry == nil {
+		return nil, fmt.Errorf("rpc registry was empty")
+	}
+	config := registry.Config()
+	if config.Port == nil {
+		return nil, fmt.Errorf("rpc port was empty")
+	}
+	if _, err := registry.FileDescriptorProto(); err != nil {
+		return nil, err
+	}
+	ret := &Server{config: config, registry: registry}
+	ret.grpc = grpc.NewServer(grpc.UnknownServiceHandler(ret.handleStream))
+	if config.ReflectionEnabled() {
+		reflectionv1.RegisterServerReflectionServer(ret.grpc, reflection.NewServerV1(reflection.ServerOptions{
+			Services:           ret,
+			DescriptorResolver: registry,
+		}))
+	}
+	ret.Server = http.Server{
+		Addr:    fmt.Sprintf(":%d", *config.Port),
+		Handler: h2c.NewHandler(ret, &http2.Server{}),
+	}
+	return ret, nil
+}
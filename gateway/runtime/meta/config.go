@@ -20,6 +20,12 @@ const (
 
 	//StateURI state uri
 	StateURI = "/v1/api/meta/state"
+
+	//JobURI durable job queue URIPrefix, job routes are opt-in: registered only when Config.JobURI is set
+	JobURI = "/v1/api/jobs"
+
+	//PlanURI slow query plan URIPrefix, plan routes are opt-in: registered only when Config.PlanURI is set
+	PlanURI = "/v1/api/meta/plan"
 )
 
 // Config represents meta config
@@ -35,6 +41,8 @@ type Config struct {
 	CacheWarmURI  string
 	StructURI     string
 	StateURI      string
+	JobURI        string
+	PlanURI       string
 }
 
 // Init initialises config
@@ -70,5 +78,4 @@ func (m *Config) Init() {
 	if m.StateURI == "" {
 		m.StateURI = StateURI
 	}
-
 }
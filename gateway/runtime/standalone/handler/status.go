@@ -4,16 +4,18 @@ import (
 	"encoding/json"
 	"fmt"
 	meta "github.com/viant/datly/gateway/runtime/meta"
+	"github.com/viant/datly/view"
 	"net/http"
 	"time"
 )
 
 type (
 	info struct {
-		Version   string
-		Status    string
-		UpTime    string
-		StartTime time.Time
+		Version    string
+		Status     string
+		UpTime     string
+		StartTime  time.Time
+		Connectors []*view.ConnectionStatus `json:",omitempty"`
 	}
 
 	StatusHandler struct {
@@ -27,8 +29,10 @@ func (h *StatusHandler) ServeHTTP(writer http.ResponseWriter, request *http.Requ
 		writer.WriteHeader(http.StatusForbidden)
 		return
 	}
-	h.info.UpTime = fmt.Sprintf("%s", time.Now().Sub(h.info.StartTime))
-	JSON, err := json.Marshal(&h.info)
+	info := h.info
+	info.UpTime = fmt.Sprintf("%s", time.Now().Sub(h.info.StartTime))
+	info.Connectors = view.ConnectionStatuses()
+	JSON, err := json.Marshal(&info)
 	if err != nil {
 		http.Error(writer, err.Error(), http.StatusInternalServerError)
 		return
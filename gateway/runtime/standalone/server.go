@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"github.com/viant/datly/gateway"
+	"github.com/viant/datly/gateway/rpc"
 	"github.com/viant/datly/gateway/runtime/standalone/handler"
 	"github.com/viant/datly/mcp"
 	"github.com/viant/datly/view/extension"
@@ -20,6 +21,7 @@ type Server struct {
 	Service      *gateway.Service
 	useSingleton *bool //true by default
 	MCP          *mcp.Server
+	RPC          *rpc.Server
 }
 
 // shutdownOnInterrupt server on interupts
@@ -34,6 +36,11 @@ func (r *Server) shutdownOnInterrupt() {
 			// Error from closing listeners, or context timeout:
 			log.Printf("HTTP server Shutdown: %v", err)
 		}
+		if r.RPC != nil {
+			if err := r.RPC.Shutdown(context.Background()); err != nil {
+				log.Printf("RPC server Shutdown: %v", err)
+			}
+		}
 		close(closed)
 	}()
 }
@@ -57,11 +64,20 @@ func New(ctx context.Context, opts ...Option) (*Server, error) {
 		},
 	}
 	if config.MCP != nil && config.MCP.Port != nil {
-		server.MCP, err = mcp.NewServer(service.MCP(), config.MCP)
+		server.MCP, err = mcp.NewServer(service.MCP(), config.MCP, mcp.WithCompletions(service.MCPCompletions()), mcp.WithSubscriptions(service.MCPSubscriptions()), mcp.WithToolSchemas(service.MCPToolSchemas()))
 		if err != nil {
 			return nil, err
 		}
 	}
+	if config.RPC != nil && config.RPC.Port != nil {
+		if server.RPC, err = rpc.NewServer(service.RPC()); err != nil {
+			return nil, err
+		}
+	}
+	if err = service.StartSchedules(context.Background()); err != nil {
+		_ = service.Close()
+		return nil, err
+	}
 	server.shutdownOnInterrupt()
 	return server, nil
 }
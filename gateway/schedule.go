@@ -0,0 +1,63 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/viant/datly/repository/path"
+	"github.com/viant/datly/service/scheduler"
+	"github.com/viant/datly/view"
+)
+
+// StartSchedules starts cron triggered execution of paths with Schedule setting, schedules are
+// re-synchronised with repository changes and stop on Close
+func (r *Service) StartSchedules(ctx context.Context) error {
+	aScheduler := scheduler.New(r.repository.Registry().Dispatcher(), scheduler.WithMetrics(r.metrics))
+	ctx, cancel := context.WithCancel(ctx)
+	r.cancelFn = cancel
+	aScheduler.Start(ctx)
+	r.scheduler = aScheduler
+	return r.syncSchedules(ctx)
+}
+
+// syncSchedules registers repository scheduled paths with the scheduler, schedules of removed paths are stopped
+func (r *Service) syncSchedules(ctx context.Context) error {
+	if r.scheduler == nil {
+		return nil
+	}
+	paths := r.repository.ScheduledPaths()
+	for _, aPath := range paths {
+		connector, err := r.scheduleConnector(ctx, aPath)
+		if err != nil {
+			return err
+		}
+		if err = r.scheduler.Add(aPath, connector); err != nil {
+			return err
+		}
+	}
+	r.scheduler.Retain(paths)
+	if len(paths) > 0 {
+		fmt.Printf("[INFO] scheduled %v path(s)\n", len(paths))
+	}
+	return nil
+}
+
+// scheduleConnector returns schedule connector, component view connector by default
+func (r *Service) scheduleConnector(ctx context.Context, aPath *path.Path) (*view.Connector, error) {
+	provider, err := r.repository.Registry().LookupProvider(ctx, &aPath.Path)
+	if err != nil {
+		return nil, err
+	}
+	component, err := provider.Component(ctx)
+	if err != nil {
+		return nil, err
+	}
+	name := aPath.Schedule.Connector
+	if name == "" {
+		name = aPath.Connector
+	}
+	if name == "" {
+		return component.View.Connector, nil
+	}
+	return component.View.GetResource().Connector(name)
+}
@@ -8,8 +8,14 @@ import (
 	"github.com/viant/afs/matcher"
 	"github.com/viant/afs/option"
 	furl "github.com/viant/afs/url"
+	"github.com/viant/datly/gateway/router/recording"
+	"github.com/viant/datly/gateway/rpc"
+	mcpext "github.com/viant/datly/mcp/extension"
 	"github.com/viant/datly/repository"
+	"github.com/viant/datly/repository/drift"
 	"github.com/viant/datly/repository/locator/component/dispatcher"
+	"github.com/viant/datly/service/jobs"
+	"github.com/viant/datly/service/scheduler"
 	"github.com/viant/datly/view"
 	"github.com/viant/gmetric"
 	serverproto "github.com/viant/mcp-protocol/server"
@@ -31,9 +37,26 @@ type (
 		mux           sync.RWMutex
 		statusHandler http.Handler
 		mcpRegistry   *serverproto.Registry
+		completions   *mcpext.Completions
+		subscriptions *mcpext.Subscriptions
+		toolSchemas   *mcpext.ToolSchemas
+		rpcRegistry   *rpc.Registry
+		recording     *recording.Service
+		drift         *drift.Monitor
+		scheduler     *scheduler.Service
+		jobPool       *jobs.Pool
+		jobCancel     context.CancelFunc
 	}
 )
 
+// RPC returns gRPC/Connect method registry, nil when RPC front door is not configured
+func (r *Service) RPC() *rpc.Registry {
+	if r == nil {
+		return nil
+	}
+	return r.rpcRegistry
+}
+
 func (r *Service) MCP() *serverproto.Registry {
 	if r == nil {
 		return nil
@@ -44,6 +67,30 @@ func (r *Service) MCP() *serverproto.Registry {
 	return r.mcpRegistry
 }
 
+// MCPCompletions returns MCP argument completion handlers
+func (r *Service) MCPCompletions() *mcpext.Completions {
+	if r == nil {
+		return nil
+	}
+	return r.completions
+}
+
+// MCPSubscriptions returns MCP resource subscriptions tracker
+func (r *Service) MCPSubscriptions() *mcpext.Subscriptions {
+	if r == nil {
+		return nil
+	}
+	return r.subscriptions
+}
+
+// MCPToolSchemas returns caller dependent MCP tool input schemas
+func (r *Service) MCPToolSchemas() *mcpext.ToolSchemas {
+	if r == nil {
+		return nil
+	}
+	return r.toolSchemas
+}
+
 func (r *Service) JWTSigner() *signer.Service {
 	return r.repository.JWTSigner()
 }
@@ -78,6 +125,16 @@ func (r *Service) Close() error {
 	if r.cancelFn != nil {
 		r.cancelFn()
 	}
+	if r.drift != nil {
+		r.drift.Close()
+	}
+	if r.jobCancel != nil {
+		r.jobCancel()
+		r.jobPool.Wait()
+	}
+	if r.recording != nil {
+		return r.recording.Close(context.Background())
+	}
 
 	return nil
 }
@@ -128,10 +185,30 @@ func New(ctx context.Context, opts ...Option) (*Service, error) {
 	}
 
 	var mcpRegistry *serverproto.Registry
+	var completions *mcpext.Completions
+	var subscriptions *mcpext.Subscriptions
+	var toolSchemas *mcpext.ToolSchemas
 	if aConfig.MCP != nil {
 		mcpRegistry = serverproto.NewRegistry()
+		completions = mcpext.NewCompletions()
+		subscriptions = mcpext.NewSubscriptions(aConfig.MCP.SubscriptionInterval())
+		toolSchemas = mcpext.NewToolSchemas()
+	}
+	var rpcRegistry *rpc.Registry
+	if aConfig.RPC != nil {
+		rpcRegistry = rpc.NewRegistry(aConfig.RPC)
+	}
+	var recorder *recording.Service
+	if aConfig.Recording != nil {
+		recorder = recording.New(aConfig.Recording, fs)
 	}
-	mainRouter, err := NewRouter(ctx, componentRepository, aConfig, options.metrics, options.statusHandler, mcpRegistry)
+	var driftMonitor *drift.Monitor
+	if aConfig.Drift != nil {
+		if driftMonitor, err = startDriftMonitor(ctx, componentRepository, aConfig.Drift); err != nil {
+			return nil, err
+		}
+	}
+	mainRouter, err := NewRouter(ctx, componentRepository, aConfig, options.metrics, options.statusHandler, mcpRegistry, WithMCPCompletions(completions), WithMCPSubscriptions(subscriptions), WithMCPToolSchemas(toolSchemas), WithRPCRegistry(rpcRegistry), WithRecording(recorder), WithDrift(driftMonitor))
 	if err != nil {
 		return nil, err
 	}
@@ -144,9 +221,16 @@ func New(ctx context.Context, opts ...Option) (*Service, error) {
 		statusHandler: options.statusHandler,
 		mainRouter:    mainRouter,
 		mcpRegistry:   mcpRegistry,
+		completions:   completions,
+		subscriptions: subscriptions,
+		toolSchemas:   toolSchemas,
+		rpcRegistry:   rpcRegistry,
+		recording:     recorder,
+		drift:         driftMonitor,
 	}
 
 	go srv.watchAsyncJob(context.Background())
+	srv.startJobPool(context.Background())
 	//fmt.Printf("[INFO]: started gatweay after: %s\n", time.Since(start))
 	return srv, err
 }
@@ -223,7 +307,7 @@ func (r *Service) syncChanges(ctx context.Context, metrics *gmetric.Service, sta
 	}
 	start := time.Now()
 	fmt.Printf("[INFO] detected resources changes, rebuilding routers\n")
-	mainRouter, err := NewRouter(ctx, r.repository, r.Config, metrics, statusHandler, r.mcpRegistry)
+	mainRouter, err := NewRouter(ctx, r.repository, r.Config, metrics, statusHandler, r.mcpRegistry, WithMCPCompletions(r.completions), WithMCPSubscriptions(r.subscriptions), WithMCPToolSchemas(r.toolSchemas), WithRPCRegistry(r.rpcRegistry), WithRecording(r.recording), WithDrift(r.drift))
 	if err != nil {
 		return err
 	}
@@ -242,6 +326,9 @@ func (r *Service) syncChanges(ctx context.Context, metrics *gmetric.Service, sta
 	r.mainRouter = mainRouter
 	r.mux.Unlock()
 	fmt.Printf("[INFO]: routers rebuild completed after: %s\n", time.Since(start))
+	if err = r.syncSchedules(ctx); err != nil {
+		fmt.Printf("[WARN] failed to sync schedules: %v\n", err)
+	}
 	return nil
 }
 
@@ -23,6 +23,7 @@ const (
 	warmupRunErrorKey         = "run.error"
 	warmupCasesCompletedKey   = "cases.completed"
 	warmupCasesFailedKey      = "cases.failed"
+	warmupCasesSkippedKey     = "cases.skipped"
 	warmupGroupsWrittenKey    = "groupsWritten"
 	warmupMetricFallbackPkg   = "datly"
 	warmupMetricRecentBuckets = 2
@@ -40,12 +41,15 @@ type PreCached struct {
 	Elapsed       string
 	TimeTaken     time.Duration
 	GroupsWritten int    `json:"groupsWritten,omitempty"`
+	Skipped       bool   `json:"skipped,omitempty"`
+	Watermark     string `json:"watermark,omitempty"`
 	Error         string `json:"error,omitempty"`
 }
 
 type Summary struct {
 	CompletedCases int `json:"completedCases"`
 	FailedCases    int `json:"failedCases"`
+	SkippedCases   int `json:"skippedCases,omitempty"`
 	GroupsWritten  int `json:"groupsWritten,omitempty"`
 }
 
@@ -53,6 +57,7 @@ type viewSummary struct {
 	View           string
 	CompletedCases int
 	FailedCases    int
+	SkippedCases   int
 	GroupsWritten  int
 	Elapsed        time.Duration
 }
@@ -150,6 +155,8 @@ func appendPreCached(response *Response, URI string, result *warmup.Result) {
 			Elapsed:       entry.Elapsed,
 			TimeTaken:     entry.TimeTaken,
 			GroupsWritten: entry.GroupsWritten,
+			Skipped:       entry.Skipped,
+			Watermark:     entry.Watermark,
 			Error:         entry.Error,
 		})
 	}
@@ -169,6 +176,9 @@ func summarize(entries []*PreCached) *Summary {
 			continue
 		}
 		summary.CompletedCases++
+		if entry.Skipped {
+			summary.SkippedCases++
+		}
 		summary.GroupsWritten += entry.GroupsWritten
 	}
 	return summary
@@ -194,6 +204,9 @@ func summarizeByView(entries []*warmup.EntryResult) []*viewSummary {
 			continue
 		}
 		current.CompletedCases++
+		if entry.Skipped {
+			current.SkippedCases++
+		}
 		current.GroupsWritten += entry.GroupsWritten
 	}
 	result := make([]*viewSummary, 0, len(index))
@@ -212,11 +225,12 @@ func logViewSummaries(uri string, views []*view.View, result *warmup.Result) {
 	}
 	viewsIndex := indexViewsByName(views)
 	for _, summary := range summarizeByView(result.Entries) {
-		fmt.Printf("[INFO] cache warmup view summary uri=%s view=%s completed_cases=%d failed_cases=%d groups_written=%d elapsed=%s\n",
+		fmt.Printf("[INFO] cache warmup view summary uri=%s view=%s completed_cases=%d failed_cases=%d skipped_cases=%d groups_written=%d elapsed=%s\n",
 			uri,
 			summary.View,
 			summary.CompletedCases,
 			summary.FailedCases,
+			summary.SkippedCases,
 			summary.GroupsWritten,
 			summary.Elapsed)
 		recordWarmupViewMetrics(viewsIndex[summary.View], summary)
@@ -267,6 +281,9 @@ func recordWarmupViewMetrics(aView *view.View, summary *viewSummary) {
 	if summary.FailedCases > 0 {
 		operation.IncrementValueBy(warmupCasesFailedKey, int64(summary.FailedCases))
 	}
+	if summary.SkippedCases > 0 {
+		operation.IncrementValueBy(warmupCasesSkippedKey, int64(summary.SkippedCases))
+	}
 	if summary.GroupsWritten > 0 {
 		operation.IncrementValueBy(warmupGroupsWrittenKey, int64(summary.GroupsWritten))
 	}
@@ -289,6 +306,7 @@ func warmupMetricOperation(aView *view.View) *gmetricx.OperationRef {
 			warmupRunErrorKey,
 			warmupCasesCompletedKey,
 			warmupCasesFailedKey,
+			warmupCasesSkippedKey,
 			warmupGroupsWrittenKey,
 		))
 	})
@@ -0,0 +1,247 @@
+package queryplan
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/viant/scy"
+	"google.golang.org/api/bigquery/v2"
+	"google.golang.org/api/option"
+)
+
+// Explain captures dialect specific query plan: EXPLAIN for MySQL and Postgres, dry run bytes for BigQuery
+func Explain(ctx context.Context, db *sql.DB, driver, dsn, SQL string, args []interface{}, relation bool) (*Plan, error) {
+	plan := &Plan{Driver: driver, SQL: SQL, Args: args, Relation: relation, CapturedAt: time.Now()}
+	var err error
+	switch strings.ToLower(driver) {
+	case "mysql":
+		if plan.Steps, err = explainRows(ctx, db, "EXPLAIN "+SQL, args); err == nil {
+			AnalyzeMySQL(plan)
+		}
+	case "postgres", "pgx":
+		if plan.Raw, err = explainJSON(ctx, db, "EXPLAIN (FORMAT JSON) "+SQL, args); err == nil {
+			err = AnalyzePostgres(plan)
+		}
+	case "bigquery":
+		plan.BytesProcessed, err = dryRunBigQuery(ctx, dsn, SQL, args)
+	default:
+		err = fmt.Errorf("query plan is not supported for driver %v", driver)
+	}
+	if err != nil {
+		plan.Error = err.Error()
+	}
+	return plan, err
+}
+
+// AnalyzeMySQL flags full scans and missing index usage in MySQL EXPLAIN rows
+func AnalyzeMySQL(plan *Plan) {
+	for _, step := range plan.Steps {
+		table := step.text("table")
+		if table == "" || strings.HasPrefix(table, "<") { //derived or union result
+			continue
+		}
+		if strings.EqualFold(step.text("type"), "ALL") {
+			plan.FullScan = true
+			plan.Warnings = append(plan.Warnings, fmt.Sprintf("full table scan on %v", table))
+		}
+		if step.text("key") == "" {
+			if plan.Relation || step.text("possible_keys") != "" {
+				plan.MissingIndex = true
+				plan.Warnings = append(plan.Warnings, fmt.Sprintf("no index used on %v", table))
+			}
+		}
+	}
+}
+
+// AnalyzePostgres flags sequential scans in Postgres JSON plan, on relation batch query sequential scan means missing index
+func AnalyzePostgres(plan *Plan) error {
+	var root []struct {
+		Plan map[string]interface{}
+	}
+	if err := json.Unmarshal(plan.Raw, &root); err != nil {
+		return fmt.Errorf("invalid postgres plan: %w", err)
+	}
+	for _, item := range root {
+		walkPostgres(plan, item.Plan)
+	}
+	return nil
+}
+
+func walkPostgres(plan *Plan, node map[string]interface{}) {
+	if node == nil {
+		return
+	}
+	if nodeType, _ := node["Node Type"].(string); nodeType == "Seq Scan" {
+		relation, _ := node["Relation Name"].(string)
+		plan.FullScan = true
+		plan.Warnings = append(plan.Warnings, fmt.Sprintf("sequential scan on %v", relation))
+		if plan.Relation {
+			plan.MissingIndex = true
+			plan.Warnings = append(plan.Warnings, fmt.Sprintf("no index used on %v", relation))
+		}
+	}
+	children, _ := node["Plans"].([]interface{})
+	for _, child := range children {
+		childNode, _ := child.(map[string]interface{})
+		walkPostgres(plan, childNode)
+	}
+}
+
+func (s Step) text(name string) string {
+	for key, value := range s {
+		if !strings.EqualFold(key, name) || value == nil {
+			continue
+		}
+		text := fmt.Sprintf("%v", value)
+		if strings.EqualFold(text, "NULL") {
+			return ""
+		}
+		return text
+	}
+	return ""
+}
+
+func explainRows(ctx context.Context, db *sql.DB, SQL string, args []interface{}) ([]Step, error) {
+	rows, err := db.QueryContext(ctx, SQL, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	var ret []Step
+	for rows.Next() {
+		values := make([]interface{}, len(columns))
+		pointers := make([]interface{}, len(columns))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+		if err = rows.Scan(pointers...); err != nil {
+			return nil, err
+		}
+		step := Step{}
+		for i, column := range columns {
+			if data, ok := values[i].([]byte); ok {
+				values[i] = string(data)
+			}
+			step[column] = values[i]
+		}
+		ret = append(ret, step)
+	}
+	return ret, rows.Err()
+}
+
+func explainJSON(ctx context.Context, db *sql.DB, SQL string, args []interface{}) (json.RawMessage, error) {
+	var data []byte
+	if err := db.QueryRowContext(ctx, SQL, args...).Scan(&data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// dryRunBigQuery estimates processed bytes with dry run job, project, dataset and credentials are taken from
+// bigquery://project/dataset DSN resolved by connector
+func dryRunBigQuery(ctx context.Context, dsn, SQL string, args []interface{}) (int64, error) {
+	project, dataset, err := bigQueryLocation(dsn)
+	if err != nil {
+		return 0, err
+	}
+	options, err := bigQueryOptions(ctx, dsn)
+	if err != nil {
+		return 0, err
+	}
+	service, err := bigquery.NewService(ctx, options...)
+	if err != nil {
+		return 0, err
+	}
+	useLegacySQL := false
+	request := &bigquery.QueryRequest{Query: SQL, DryRun: true, UseLegacySql: &useLegacySQL}
+	if dataset != "" {
+		request.DefaultDataset = &bigquery.DatasetReference{ProjectId: project, DatasetId: dataset}
+	}
+	if len(args) > 0 {
+		request.ParameterMode = "POSITIONAL"
+		for _, arg := range args {
+			request.QueryParameters = append(request.QueryParameters, bigQueryParameter(arg))
+		}
+	}
+	response, err := service.Jobs.Query(project, request).Context(ctx).Do()
+	if err != nil {
+		return 0, err
+	}
+	return response.TotalBytesProcessed, nil
+}
+
+func bigQueryLocation(dsn string) (string, string, error) {
+	parsed, err := url.Parse(dsn)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid bigquery dsn: %w", err)
+	}
+	if parsed.Host == "" {
+		return "", "", fmt.Errorf("bigquery dsn project was empty")
+	}
+	segments := strings.Split(strings.Trim(parsed.Path, "/"), "/")
+	return parsed.Host, segments[len(segments)-1], nil
+}
+
+// bigQueryOptions returns client options with DSN credentials: credJSON (raw or base64 encoded) or credURL secret
+// decrypted with optional credKey, DSN without credentials falls back to default credentials as the driver does
+func bigQueryOptions(ctx context.Context, dsn string) ([]option.ClientOption, error) {
+	parsed, err := url.Parse(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("invalid bigquery dsn: %w", err)
+	}
+	query := parsed.Query()
+	var credentials []byte
+	if credJSON := query.Get("credJSON"); credJSON != "" {
+		credentials = []byte(credJSON)
+		if !strings.HasPrefix(strings.TrimSpace(credJSON), "{") {
+			if credentials, err = base64.StdEncoding.DecodeString(credJSON); err != nil {
+				return nil, fmt.Errorf("invalid bigquery dsn credJSON: %w", err)
+			}
+		}
+	} else if credURL := query.Get("credURL"); credURL != "" {
+		secret, err := scy.New().Load(ctx, &scy.Resource{URL: credURL, Key: query.Get("credKey")})
+		if err != nil {
+			return nil, fmt.Errorf("failed to load bigquery credentials: %w", err)
+		}
+		credentials = []byte(secret.String())
+	}
+	if len(credentials) == 0 {
+		return nil, nil
+	}
+	return []option.ClientOption{option.WithAuthCredentialsJSON(option.ServiceAccount, credentials)}, nil
+}
+
+func bigQueryParameter(arg interface{}) *bigquery.QueryParameter {
+	parameterType := "STRING"
+	value := fmt.Sprintf("%v", arg)
+	switch actual := arg.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		parameterType = "INT64"
+	case float32, float64:
+		parameterType = "FLOAT64"
+	case bool:
+		parameterType = "BOOL"
+	case time.Time:
+		parameterType = "TIMESTAMP"
+		value = actual.UTC().Format(time.RFC3339Nano)
+	case *time.Time:
+		if actual != nil {
+			parameterType = "TIMESTAMP"
+			value = actual.UTC().Format(time.RFC3339Nano)
+		}
+	}
+	return &bigquery.QueryParameter{
+		ParameterType:  &bigquery.QueryParameterType{Type: parameterType},
+		ParameterValue: &bigquery.QueryParameterValue{Value: value},
+	}
+}
@@ -0,0 +1,113 @@
+package queryplan
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+const defaultStoreSize = 200
+
+type (
+	// Plan represents captured query plan of a slow read
+	Plan struct {
+		TraceID        string
+		View           string
+		Relation       bool `json:",omitempty"` // relation batch query
+		Driver         string
+		SQL            string
+		Args           []interface{}   `json:",omitempty"`
+		ElapsedMs      int64           `json:",omitempty"`
+		CapturedAt     time.Time       `json:",omitempty"`
+		Steps          []Step          `json:",omitempty"` // tabular plan (MySQL)
+		Raw            json.RawMessage `json:",omitempty"` // JSON plan (Postgres)
+		BytesProcessed int64           `json:",omitempty"` // dry run estimate (BigQuery)
+		FullScan       bool            `json:",omitempty"`
+		MissingIndex   bool            `json:",omitempty"`
+		Warnings       []string        `json:",omitempty"`
+		Error          string          `json:",omitempty"`
+	}
+
+	// Step represents plan row
+	Step map[string]interface{}
+
+	// Store keeps most recent plans
+	Store struct {
+		mux   sync.RWMutex
+		size  int
+		plans []*Plan
+		next  int
+	}
+)
+
+var aStore = NewStore(defaultStoreSize)
+
+// Default returns process wide plan store
+func Default() *Store {
+	return aStore
+}
+
+// Flagged returns true if plan reports full scan or missing index usage
+func (p *Plan) Flagged() bool {
+	return p.FullScan || p.MissingIndex
+}
+
+// Redacted returns plan copy without query arguments, arguments may carry caller data
+func (p *Plan) Redacted() *Plan {
+	ret := *p
+	ret.Args = nil
+	return &ret
+}
+
+// Add adds plan, the oldest plan is evicted when store is full
+func (s *Store) Add(plan *Plan) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	if len(s.plans) < s.size {
+		s.plans = append(s.plans, plan)
+		return
+	}
+	s.plans[s.next] = plan
+	s.next = (s.next + 1) % s.size
+}
+
+// Recent returns plans starting from the most recent one, limit 0 returns all
+func (s *Store) Recent(limit int, flaggedOnly bool) []*Plan {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+	var ret = make([]*Plan, 0)
+	for i := 0; i < len(s.plans); i++ {
+		index := (s.next - 1 - i + 2*len(s.plans)) % len(s.plans)
+		if len(s.plans) < s.size {
+			index = len(s.plans) - 1 - i
+		}
+		plan := s.plans[index]
+		if flaggedOnly && !plan.Flagged() {
+			continue
+		}
+		ret = append(ret, plan)
+		if limit > 0 && len(ret) == limit {
+			break
+		}
+	}
+	return ret
+}
+
+// ByTrace returns plans captured for request trace
+func (s *Store) ByTrace(traceID string) []*Plan {
+	var ret = make([]*Plan, 0)
+	for _, plan := range s.Recent(0, false) {
+		if plan.TraceID == traceID {
+			ret = append(ret, plan)
+		}
+	}
+	return ret
+}
+
+// NewStore creates plan store
+func NewStore(size int) *Store {
+	if size <= 0 {
+		size = defaultStoreSize
+	}
+	return &Store{size: size}
+}
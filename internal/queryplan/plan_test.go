@@ -0,0 +1,68 @@
+package queryplan
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalyzeMySQL(t *testing.T) {
+	plan := &Plan{Relation: true, Steps: []Step{
+		{"table": "t", "type": "ALL", "possible_keys": nil, "key": nil},
+		{"table": "<derived2>", "type": "ALL", "key": nil},
+		{"table": "v", "type": "ref", "possible_keys": "IDX_V", "key": "IDX_V"},
+	}}
+	AnalyzeMySQL(plan)
+	assert.True(t, plan.FullScan)
+	assert.True(t, plan.MissingIndex)
+	assert.Equal(t, []string{"full table scan on t", "no index used on t"}, plan.Warnings)
+}
+
+func TestAnalyzePostgres(t *testing.T) {
+	plan := &Plan{Relation: true, Raw: []byte(`[{"Plan":{"Node Type":"Hash Join","Plans":[{"Node Type":"Seq Scan","Relation Name":"orders"},{"Node Type":"Index Scan","Relation Name":"items"}]}}]`)}
+	require.Nil(t, AnalyzePostgres(plan))
+	assert.True(t, plan.FullScan)
+	assert.True(t, plan.MissingIndex)
+	assert.Equal(t, []string{"sequential scan on orders", "no index used on orders"}, plan.Warnings)
+}
+
+func TestStore(t *testing.T) {
+	store := NewStore(2)
+	store.Add(&Plan{TraceID: "1"})
+	store.Add(&Plan{TraceID: "2", FullScan: true})
+	store.Add(&Plan{TraceID: "3"})
+	recent := store.Recent(0, false)
+	require.Len(t, recent, 2)
+	assert.Equal(t, "3", recent[0].TraceID)
+	assert.Equal(t, "2", recent[1].TraceID)
+	assert.Len(t, store.Recent(0, true), 1)
+	assert.Len(t, store.ByTrace("1"), 0)
+	assert.Len(t, store.ByTrace("3"), 1)
+}
+
+func TestBigQueryLocation(t *testing.T) {
+	project, dataset, err := bigQueryLocation("bigquery://my-project/my_dataset?credURL=x")
+	require.Nil(t, err)
+	assert.Equal(t, "my-project", project)
+	assert.Equal(t, "my_dataset", dataset)
+}
+
+func TestBigQueryOptions(t *testing.T) {
+	options, err := bigQueryOptions(context.Background(), "bigquery://my-project/my_dataset")
+	require.Nil(t, err)
+	assert.Len(t, options, 0)
+	options, err = bigQueryOptions(context.Background(), "bigquery://my-project/my_dataset?credJSON=eyJ0eXBlIjoic2VydmljZV9hY2NvdW50In0=")
+	require.Nil(t, err)
+	assert.Len(t, options, 1)
+	_, err = bigQueryOptions(context.Background(), "bigquery://my-project/my_dataset?credJSON=%25%25")
+	assert.NotNil(t, err)
+}
+
+func TestPlan_Redacted(t *testing.T) {
+	plan := &Plan{SQL: "SELECT * FROM t WHERE email = ?", Args: []interface{}{"a@b.c"}}
+	assert.Nil(t, plan.Redacted().Args)
+	assert.Equal(t, plan.SQL, plan.Redacted().SQL)
+	assert.Len(t, plan.Args, 1)
+}
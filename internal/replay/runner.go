@@ -0,0 +1,239 @@
+package replay
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+
+	"github.com/viant/datly/gateway/router"
+	"github.com/viant/datly/gateway/router/recording"
+	"github.com/viant/datly/internal/testutil/sqlnormalizer"
+	"github.com/viant/datly/repository"
+	"github.com/viant/godiff"
+)
+
+type (
+	// Runner re-executes recorded requests against candidate repository or running datly instance
+	Runner struct {
+		repository *repository.Service
+		target     string
+		header     http.Header
+		client     *http.Client
+		mutating   bool
+	}
+
+	// Option represents runner option
+	Option func(r *Runner)
+
+	// Result represents replay result
+	Result struct {
+		Record  *recording.Record
+		Actual  *recording.Record
+		Changes *godiff.ChangeLog
+		Skipped bool //mutating request was not replayed
+		Error   error
+	}
+
+	// outcome represents compared part of the record, input and SQL are only available for in process replay,
+	// output is compared when both recorded and replayed responses were captured, response hash otherwise
+	outcome struct {
+		StatusCode   int
+		ResponseHash string                 `json:",omitempty"`
+		Output       map[string]interface{} `json:",omitempty"` //non object response is held by Value key
+		Input        map[string]interface{}
+		SQL          []*recording.Query
+	}
+)
+
+var differRegistry = godiff.NewRegistry()
+
+// WithRepository sets candidate repository, recordings are replayed in process
+func WithRepository(service *repository.Service) Option {
+	return func(r *Runner) {
+		r.repository = service
+	}
+}
+
+// WithTarget sets candidate datly base URL, i.e. http://localhost:8080
+func WithTarget(URL string) Option {
+	return func(r *Runner) {
+		r.target = strings.TrimRight(URL, "/")
+	}
+}
+
+// WithHeader sets request header replacing recorded one, i.e. Authorization removed by recording sanitizer
+func WithHeader(name, value string) Option {
+	return func(r *Runner) {
+		r.header.Set(name, value)
+	}
+}
+
+// WithClient sets http client used with target
+func WithClient(client *http.Client) Option {
+	return func(r *Runner) {
+		r.client = client
+	}
+}
+
+// WithMutating enables replaying requests other than GET and HEAD, they may modify candidate data
+func WithMutating(mutating bool) Option {
+	return func(r *Runner) {
+		r.mutating = mutating
+	}
+}
+
+// Passed returns true if replay run without error and changes
+func (r *Result) Passed() bool {
+	return r.Error == nil && (r.Changes == nil || r.Changes.Size() == 0)
+}
+
+// Run replays record and compares replayed outcome with the recorded one, mutating requests are skipped unless enabled
+func (r *Runner) Run(ctx context.Context, record *recording.Record) *Result {
+	result := &Result{Record: record}
+	if !r.mutating && record.Method != http.MethodGet && record.Method != http.MethodHead {
+		result.Skipped = true
+		return result
+	}
+	actual, err := r.replay(ctx, record)
+	if err != nil {
+		result.Error = fmt.Errorf("%v %v: %w", record.Method, record.URI, err)
+		return result
+	}
+	result.Actual = actual
+	result.Changes, result.Error = r.diff(record, actual)
+	return result
+}
+
+func (r *Runner) replay(ctx context.Context, record *recording.Record) (*recording.Record, error) {
+	request, err := r.request(ctx, record)
+	if err != nil {
+		return nil, err
+	}
+	actual := &recording.Record{ID: record.ID, Method: record.Method, URI: record.URI, Masked: record.Masked}
+	writer := recording.NewResponseWriter(httptest.NewRecorder(), math.MaxInt32)
+	if r.target != "" {
+		response, err := r.client.Do(request)
+		if err != nil {
+			return nil, err
+		}
+		defer response.Body.Close()
+		writer.WriteHeader(response.StatusCode)
+		if _, err = io.Copy(writer, response.Body); err != nil {
+			return nil, err
+		}
+		actual.Complete(writer)
+		return normalize(actual)
+	}
+	if r.repository == nil {
+		return nil, fmt.Errorf("candidate repository and target were empty")
+	}
+	ctx = recording.Begin(ctx, actual)
+	handler, _, err := router.NewLocal(ctx, r.repository, request.Method, request.URL.Path)
+	if err != nil {
+		return nil, err
+	}
+	handler.HandleRequest(ctx, writer, request.WithContext(ctx))
+	actual.Complete(writer)
+	return normalize(actual)
+}
+
+func (r *Runner) request(ctx context.Context, record *recording.Record) (*http.Request, error) {
+	baseURL := r.target
+	if baseURL == "" {
+		baseURL = "http://localhost"
+	}
+	var body io.Reader
+	if record.Body != "" {
+		body = strings.NewReader(record.Body)
+	}
+	request, err := http.NewRequestWithContext(ctx, record.Method, baseURL+record.URI, body)
+	if err != nil {
+		return nil, err
+	}
+	for name, values := range record.Header {
+		for _, value := range values {
+			if strings.Trim(value, "*") == "" { //sanitized value
+				continue
+			}
+			request.Header.Add(name, value)
+		}
+	}
+	for name, values := range r.header {
+		request.Header[name] = values
+	}
+	return request, nil
+}
+
+// diff compares recorded and replayed outcome, input and SQL are skipped for remote target
+func (r *Runner) diff(record, actual *recording.Record) (*godiff.ChangeLog, error) {
+	withOutput := len(record.Response) > 0 && len(actual.Response) > 0
+	expect, err := r.outcome(record, withOutput)
+	if err != nil {
+		return nil, err
+	}
+	replayed, err := r.outcome(actual, withOutput)
+	if err != nil {
+		return nil, err
+	}
+	differ, err := differRegistry.Get(reflect.TypeOf(expect), reflect.TypeOf(replayed), &godiff.Tag{})
+	if err != nil {
+		return nil, err
+	}
+	return differ.Diff(expect, replayed), nil
+}
+
+func (r *Runner) outcome(record *recording.Record, withOutput bool) (*outcome, error) {
+	ret := &outcome{StatusCode: record.StatusCode}
+	if withOutput {
+		var output interface{}
+		if err := json.Unmarshal(record.Response, &output); err != nil {
+			return nil, fmt.Errorf("invalid recorded response: %w", err)
+		}
+		var ok bool
+		if ret.Output, ok = output.(map[string]interface{}); !ok {
+			ret.Output = map[string]interface{}{"Value": output}
+		}
+	} else {
+		ret.ResponseHash = record.ResponseHash
+	}
+	if r.target != "" {
+		return ret, nil
+	}
+	if len(record.Input) > 0 {
+		if err := json.Unmarshal(record.Input, &ret.Input); err != nil {
+			return nil, fmt.Errorf("invalid recorded input: %w", err)
+		}
+	}
+	for _, query := range record.SQL {
+		ret.SQL = append(ret.SQL, &recording.Query{View: query.View, SQL: sqlnormalizer.Normalize(query.SQL), Args: query.Args})
+	}
+	return ret, nil
+}
+
+// normalize round trips record through JSON, so that replayed values have the same types as loaded recordings
+func normalize(record *recording.Record) (*recording.Record, error) {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return nil, err
+	}
+	ret := &recording.Record{}
+	return ret, json.Unmarshal(data, ret)
+}
+
+// New creates replay runner
+func New(opts ...Option) *Runner {
+	ret := &Runner{header: http.Header{}}
+	for _, opt := range opts {
+		opt(ret)
+	}
+	if ret.client == nil {
+		ret.client = http.DefaultClient
+	}
+	return ret
+}
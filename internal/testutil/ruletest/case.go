@@ -0,0 +1,223 @@
+package ruletest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/viant/afs"
+	"github.com/viant/afs/storage"
+	"github.com/viant/scy/auth/jwt"
+	"gopkg.in/yaml.v3"
+)
+
+// CaseFolderSuffix marks folder with rule test cases, i.e. emp.test holds cases of emp rule
+const CaseFolderSuffix = ".test"
+
+type (
+	// Case represents rule contract test case, it is defined as <case>.yaml next to its snapshots
+	Case struct {
+		Name    string     `json:",omitempty"`
+		URL     string     `json:"-"`
+		Rule    string     `json:"-"`
+		Request *Request   `json:",omitempty"`
+		Seed    []*Fixture `json:",omitempty"`
+		Expect  *Expect    `json:",omitempty"`
+	}
+
+	// Request represents test case http request
+	Request struct {
+		Method  string            `json:",omitempty"`
+		URI     string            `json:",omitempty"`
+		Query   map[string]string `json:",omitempty"`
+		Headers map[string]string `json:",omitempty"`
+		Body    interface{}       `json:",omitempty"`
+		Claims  *jwt.Claims       `json:",omitempty"`
+	}
+
+	// Fixture represents table seed data, table is emptied before insert unless Append is set
+	Fixture struct {
+		Connector string                   `json:",omitempty"`
+		Table     string                   `json:",omitempty"`
+		Append    bool                     `json:",omitempty"`
+		Rows      []map[string]interface{} `json:",omitempty"`
+	}
+
+	// Expect represents test case expectation
+	Expect struct {
+		StatusCode int      `json:",omitempty"`
+		SQL        bool     `json:",omitempty"` //compares executed SQL with <case>.sql snapshot
+		Ignore     []string `json:",omitempty"` //dotted output paths excluded from snapshot comparison
+	}
+)
+
+// OutputSnapshotURL returns expected output snapshot location
+func (c *Case) OutputSnapshotURL() string {
+	return c.snapshotURL(".json")
+}
+
+// SQLSnapshotURL returns expected SQL snapshot location
+func (c *Case) SQLSnapshotURL() string {
+	return c.snapshotURL(".sql")
+}
+
+func (c *Case) snapshotURL(ext string) string {
+	return strings.TrimSuffix(c.URL, path.Ext(c.URL)) + ext
+}
+
+// HTTPRequest creates case http request
+func (r *Request) HTTPRequest(ctx context.Context) (*http.Request, error) {
+	method := r.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+	URL, err := url.Parse(r.URI)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request URI %v: %w", r.URI, err)
+	}
+	if len(r.Query) > 0 {
+		query := URL.Query()
+		for key, value := range r.Query {
+			query.Set(key, value)
+		}
+		URL.RawQuery = query.Encode()
+	}
+	var body io.Reader
+	switch actual := r.Body.(type) {
+	case nil:
+	case string:
+		body = strings.NewReader(actual)
+	default:
+		data, err := json.Marshal(actual)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal request body: %w", err)
+		}
+		body = bytes.NewReader(data)
+	}
+	request, err := http.NewRequestWithContext(ctx, strings.ToUpper(method), URL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+	for key, value := range r.Headers {
+		request.Header.Set(key, value)
+	}
+	return request, nil
+}
+
+// Load loads test case
+func Load(ctx context.Context, fs afs.Service, URL string) (*Case, error) {
+	data, err := fs.DownloadWithURL(ctx, URL)
+	if err != nil {
+		return nil, err
+	}
+	var transient interface{}
+	if err = yaml.Unmarshal(data, &transient); err != nil {
+		return nil, fmt.Errorf("invalid test case %v: %w", URL, err)
+	}
+	if data, err = json.Marshal(normalizeYAML(transient)); err != nil {
+		return nil, fmt.Errorf("invalid test case %v: %w", URL, err)
+	}
+	ret := &Case{}
+	if err = json.Unmarshal(data, ret); err != nil {
+		return nil, fmt.Errorf("invalid test case %v: %w", URL, err)
+	}
+	ret.URL = URL
+	if ret.Name == "" {
+		ret.Name = strings.TrimSuffix(path.Base(URL), path.Ext(URL))
+	}
+	if ret.Request == nil || ret.Request.URI == "" {
+		return nil, fmt.Errorf("invalid test case %v: request URI was empty", URL)
+	}
+	if ret.Expect == nil {
+		ret.Expect = &Expect{}
+	}
+	return ret, nil
+}
+
+// Discover returns test cases found in <rule>.test folders under URL, sorted by rule and name
+func Discover(ctx context.Context, fs afs.Service, URL string) ([]*Case, error) {
+	var result []*Case
+	if err := discover(ctx, fs, strings.TrimRight(URL, "/"), "", &result); err != nil {
+		return nil, err
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Rule == result[j].Rule {
+			return result[i].Name < result[j].Name
+		}
+		return result[i].Rule < result[j].Rule
+	})
+	return result, nil
+}
+
+func discover(ctx context.Context, fs afs.Service, URL, rule string, result *[]*Case) error {
+	objects, err := fs.List(ctx, URL)
+	if err != nil {
+		return fmt.Errorf("failed to list %v: %w", URL, err)
+	}
+	for _, object := range objects {
+		if object.URL() == URL || strings.TrimRight(object.URL(), "/") == URL {
+			continue
+		}
+		if object.IsDir() {
+			childRule := ""
+			if strings.HasSuffix(object.Name(), CaseFolderSuffix) {
+				childRule = strings.TrimSuffix(strings.TrimRight(object.URL(), "/"), CaseFolderSuffix)
+			}
+			if err = discover(ctx, fs, object.URL(), childRule, result); err != nil {
+				return err
+			}
+			continue
+		}
+		if rule == "" || !isCaseFile(object) {
+			continue
+		}
+		aCase, err := Load(ctx, fs, object.URL())
+		if err != nil {
+			return err
+		}
+		aCase.Rule = rule
+		*result = append(*result, aCase)
+	}
+	return nil
+}
+
+func isCaseFile(object storage.Object) bool {
+	switch strings.ToLower(path.Ext(object.Name())) {
+	case ".yaml", ".yml":
+		return true
+	}
+	return false
+}
+
+// normalizeYAML converts yaml maps with non string keys into JSON compatible maps
+func normalizeYAML(value interface{}) interface{} {
+	switch actual := value.(type) {
+	case map[string]interface{}:
+		for k, v := range actual {
+			actual[k] = normalizeYAML(v)
+		}
+		return actual
+	case map[interface{}]interface{}:
+		ret := make(map[string]interface{}, len(actual))
+		for k, v := range actual {
+			ret[fmt.Sprintf("%v", k)] = normalizeYAML(v)
+		}
+		return ret
+	case []interface{}:
+		for i, v := range actual {
+			actual[i] = normalizeYAML(v)
+		}
+		return actual
+	}
+	return value
+}
@@ -0,0 +1,90 @@
+package ruletest
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/viant/afs"
+)
+
+func TestDiscover(t *testing.T) {
+	ctx := context.Background()
+	fs := afs.New()
+	baseURL := "mem://localhost/routes"
+	upload := func(URL, content string) {
+		require.NoError(t, fs.Upload(ctx, baseURL+URL, 0644, strings.NewReader(content)))
+	}
+	upload("/dev/emp.yaml", "URI: /v1/api/dev/emp")
+	upload("/dev/emp.test/by_id.yaml", `
+Request:
+  URI: /v1/api/dev/emp/1
+  Query:
+    fields: id,name
+  Headers:
+    X-Trace: t1
+Seed:
+  - Table: EMP
+    Rows:
+      - ID: 1
+        NAME: Bob
+Expect:
+  StatusCode: 200
+  SQL: true
+  Ignore: [Status.TraceId]
+`)
+	upload("/dev/emp.test/by_id.json", `{"ID":1}`)
+	upload("/dev/emp.test/all.yaml", `
+Request:
+  Method: POST
+  URI: /v1/api/dev/emp
+  Body:
+    Name: Bob
+`)
+	upload("/dev/dept.yaml", "URI: /v1/api/dev/dept")
+
+	cases, err := Discover(ctx, fs, baseURL)
+	require.NoError(t, err)
+	require.Len(t, cases, 2)
+	assert.Equal(t, "all", cases[0].Name)
+	assert.Equal(t, "by_id", cases[1].Name)
+	assert.True(t, strings.HasSuffix(cases[1].Rule, "/dev/emp"))
+	assert.Equal(t, baseURL+"/dev/emp.test/by_id.json", cases[1].OutputSnapshotURL())
+	assert.Equal(t, baseURL+"/dev/emp.test/by_id.sql", cases[1].SQLSnapshotURL())
+	assert.Equal(t, 200, cases[1].Expect.StatusCode)
+	assert.True(t, cases[1].Expect.SQL)
+	require.Len(t, cases[1].Seed, 1)
+	assert.Equal(t, "EMP", cases[1].Seed[0].Table)
+	assert.EqualValues(t, 1, cases[1].Seed[0].Rows[0]["ID"])
+
+	request, err := cases[1].Request.HTTPRequest(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "GET", request.Method)
+	assert.Equal(t, "id,name", request.URL.Query().Get("fields"))
+	assert.Equal(t, "t1", request.Header.Get("X-Trace"))
+
+	request, err = cases[0].Request.HTTPRequest(ctx)
+	require.NoError(t, err)
+	body, _ := io.ReadAll(request.Body)
+	assert.Equal(t, "POST", request.Method)
+	assert.JSONEq(t, `{"Name":"Bob"}`, string(body))
+}
+
+func TestSnapshot(t *testing.T) {
+	actual := OutputSnapshot([]byte(`{"Data":[{"ID":1,"Updated":"now"}],"Status":{"Status":"ok","TraceId":"x"}}`), []string{"Data.Updated", "Status.TraceId"})
+	assert.Equal(t, "{\n  \"Data\": [\n    {\n      \"ID\": 1\n    }\n  ],\n  \"Status\": {\n    \"Status\": \"ok\"\n  }\n}\n", string(actual))
+	assert.True(t, EqualOutput([]byte(`{"Status":{"Status":"ok"},"Data":[{"ID":1}]}`), actual, nil))
+	assert.Equal(t, "plain", string(OutputSnapshot([]byte("plain"), nil)))
+
+	SQL := SQLSnapshot([]*Query{{View: "emp", SQL: "SELECT  ID\nFROM EMP WHERE ID = ?", Args: []interface{}{1}}})
+	assert.Equal(t, "-- view: emp\nSELECT ID FROM EMP WHERE ID = ?;\n-- args: [1]\n", string(SQL))
+	assert.True(t, EqualSQL([]byte("-- view: emp\nSELECT ID\n  FROM EMP WHERE ID = ?;\n-- args: [1]\n"), SQL))
+	assert.False(t, EqualSQL([]byte("-- view: emp\nSELECT NAME FROM EMP;\n"), SQL))
+	assert.Contains(t, Diff([]byte("a\nb"), []byte("a\nc")), "line 2")
+
+	SQL = SQLSnapshot([]*Query{{View: "emp", SQL: "SELECT 2"}, {View: "dept", SQL: "SELECT 1"}, {View: "emp", SQL: "SELECT 1"}})
+	assert.Equal(t, "-- view: dept\nSELECT 1;\n-- view: emp\nSELECT 1;\n-- view: emp\nSELECT 2;\n", string(SQL))
+}
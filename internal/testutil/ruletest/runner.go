@@ -0,0 +1,244 @@
+package ruletest
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/viant/afs"
+	"github.com/viant/afs/file"
+	"github.com/viant/datly/gateway/router"
+	"github.com/viant/datly/repository"
+	"github.com/viant/datly/service/reader"
+	"github.com/viant/datly/view"
+)
+
+type (
+	// Runner runs rule test cases in process against repository components
+	Runner struct {
+		repository *repository.Service
+		fs         afs.Service
+		update     bool
+		seedable   map[string]bool
+		seedAll    bool
+	}
+
+	// Option represents runner option
+	Option func(r *Runner)
+
+	// Result represents test case result
+	Result struct {
+		Case     *Case
+		Updated  []string
+		Failures []string
+		Error    error
+	}
+)
+
+// WithUpdate enables snapshot update mode, actual output and SQL replace expected snapshots
+func WithUpdate(update bool) Option {
+	return func(r *Runner) {
+		r.update = update
+	}
+}
+
+// WithFs sets snapshot file system
+func WithFs(fs afs.Service) Option {
+	return func(r *Runner) {
+		r.fs = fs
+	}
+}
+
+// WithSeedConnectors allows fixtures to seed connectors, i.e. overridden with local database stand-in
+func WithSeedConnectors(names ...string) Option {
+	return func(r *Runner) {
+		for _, name := range names {
+			r.seedable[name] = true
+		}
+	}
+}
+
+// WithSeedAll allows fixtures to seed any connector, including configured ones
+func WithSeedAll(seedAll bool) Option {
+	return func(r *Runner) {
+		r.seedAll = seedAll
+	}
+}
+
+// Passed returns true if case run without error and failures
+func (r *Result) Passed() bool {
+	return r.Error == nil && len(r.Failures) == 0
+}
+
+// Run runs test case: seeds fixtures, handles request with router handler and compares snapshots
+func (r *Runner) Run(ctx context.Context, aCase *Case) *Result {
+	result := &Result{Case: aCase}
+	if result.Error = r.run(ctx, aCase, result); result.Error != nil {
+		result.Error = fmt.Errorf("%v: %w", aCase.Name, result.Error)
+	}
+	return result
+}
+
+func (r *Runner) run(ctx context.Context, aCase *Case, result *Result) error {
+	recorder := &reader.Recorder{}
+	ctx = reader.WithRecorder(ctx, recorder)
+	request, err := aCase.Request.HTTPRequest(ctx)
+	if err != nil {
+		return err
+	}
+	if err = r.signRequest(request, aCase.Request); err != nil {
+		return err
+	}
+	handler, component, err := router.NewLocal(ctx, r.repository, request.Method, request.URL.Path)
+	if err != nil {
+		return err
+	}
+	if err = r.seed(ctx, component, aCase.Seed); err != nil {
+		return err
+	}
+	writer := httptest.NewRecorder()
+	handler.HandleRequest(ctx, writer, request)
+
+	if expect := aCase.Expect.StatusCode; expect != 0 && expect != writer.Code {
+		result.Failures = append(result.Failures, fmt.Sprintf("expected status code %v, but had %v: %s", expect, writer.Code, writer.Body.String()))
+	}
+	output := OutputSnapshot(writer.Body.Bytes(), aCase.Expect.Ignore)
+	if err = r.compare(ctx, aCase.OutputSnapshotURL(), output, result, func(expect []byte) bool {
+		return EqualOutput(expect, output, aCase.Expect.Ignore)
+	}); err != nil {
+		return err
+	}
+	if !aCase.Expect.SQL {
+		return nil
+	}
+	var queries []*Query
+	for _, query := range recorder.Queries() {
+		queries = append(queries, &Query{View: query.View, SQL: query.SQL, Args: query.Args})
+	}
+	SQL := SQLSnapshot(queries)
+	return r.compare(ctx, aCase.SQLSnapshotURL(), SQL, result, func(expect []byte) bool {
+		return EqualSQL(expect, SQL)
+	})
+}
+
+func (r *Runner) compare(ctx context.Context, URL string, actual []byte, result *Result, equal func(expect []byte) bool) error {
+	exists, _ := r.fs.Exists(ctx, URL)
+	if r.update {
+		if exists {
+			if expect, err := r.fs.DownloadWithURL(ctx, URL); err == nil && equal(expect) {
+				return nil
+			}
+		}
+		if err := r.fs.Upload(ctx, URL, file.DefaultFileOsMode, strings.NewReader(string(actual))); err != nil {
+			return fmt.Errorf("failed to update snapshot %v: %w", URL, err)
+		}
+		result.Updated = append(result.Updated, URL)
+		return nil
+	}
+	if !exists {
+		result.Failures = append(result.Failures, fmt.Sprintf("snapshot %v does not exist, run with update flag to create it", URL))
+		return nil
+	}
+	expect, err := r.fs.DownloadWithURL(ctx, URL)
+	if err != nil {
+		return fmt.Errorf("failed to load snapshot %v: %w", URL, err)
+	}
+	if !equal(expect) {
+		result.Failures = append(result.Failures, fmt.Sprintf("snapshot %v mismatch, %v", URL, Diff(expect, actual)))
+	}
+	return nil
+}
+
+func (r *Runner) signRequest(request *http.Request, caseRequest *Request) error {
+	if caseRequest.Claims == nil {
+		return nil
+	}
+	aSigner := r.repository.JWTSigner()
+	if aSigner == nil {
+		return fmt.Errorf("request claims were defined, but JWT signer was not configured")
+	}
+	token, err := aSigner.Create(time.Hour, caseRequest.Claims)
+	if err != nil {
+		return fmt.Errorf("failed to sign request claims: %w", err)
+	}
+	request.Header.Set("Authorization", "Bearer "+token)
+	return nil
+}
+
+func (r *Runner) seed(ctx context.Context, component *repository.Component, fixtures []*Fixture) error {
+	for _, fixture := range fixtures {
+		connector := component.View.Connector
+		if fixture.Connector != "" {
+			var err error
+			if connector, err = component.View.GetResource().Connector(fixture.Connector); err != nil {
+				return err
+			}
+		}
+		if connector == nil {
+			return fmt.Errorf("failed to seed %v: connector was empty", fixture.Table)
+		}
+		if !r.seedAll && !r.seedable[connector.Name] {
+			return fmt.Errorf("failed to seed %v: connector %v was not overridden, use connector override or enable seeding configured connectors", fixture.Table, connector.Name)
+		}
+		db, err := connector.DB()
+		if err != nil {
+			return fmt.Errorf("failed to seed %v: %w", fixture.Table, err)
+		}
+		if err = seedTable(ctx, db, connector, fixture); err != nil {
+			return fmt.Errorf("failed to seed %v: %w", fixture.Table, err)
+		}
+	}
+	return nil
+}
+
+func seedTable(ctx context.Context, db *sql.DB, connector *view.Connector, fixture *Fixture) error {
+	if !fixture.Append {
+		if _, err := db.ExecContext(ctx, "DELETE FROM "+fixture.Table); err != nil {
+			return err
+		}
+	}
+	for _, row := range fixture.Rows {
+		columns := make([]string, 0, len(row))
+		for column := range row {
+			columns = append(columns, column)
+		}
+		sort.Strings(columns)
+		placeholders := make([]string, len(columns))
+		args := make([]interface{}, len(columns))
+		for i, column := range columns {
+			placeholders[i] = placeholder(connector.Driver, i)
+			args[i] = row[column]
+		}
+		SQL := "INSERT INTO " + fixture.Table + "(" + strings.Join(columns, ",") + ") VALUES(" + strings.Join(placeholders, ",") + ")"
+		if _, err := db.ExecContext(ctx, SQL, args...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func placeholder(driver string, index int) string {
+	switch driver {
+	case "postgres", "pgx":
+		return "$" + strconv.Itoa(index+1)
+	}
+	return "?"
+}
+
+// New creates test runner
+func New(service *repository.Service, opts ...Option) *Runner {
+	ret := &Runner{repository: service, seedable: map[string]bool{}}
+	for _, opt := range opts {
+		opt(ret)
+	}
+	if ret.fs == nil {
+		ret.fs = afs.New()
+	}
+	return ret
+}
@@ -0,0 +1,124 @@
+package ruletest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/viant/datly/internal/testutil/sqlnormalizer"
+)
+
+// Query represents executed SQL captured for snapshot
+type Query struct {
+	View string
+	SQL  string
+	Args []interface{}
+}
+
+// OutputSnapshot returns canonical output snapshot, JSON is re-indented with ignored paths removed, other content is kept verbatim
+func OutputSnapshot(data []byte, ignore []string) []byte {
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return data
+	}
+	for _, aPath := range ignore {
+		removePath(value, strings.Split(aPath, "."))
+	}
+	ret, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return data
+	}
+	return append(ret, '\n')
+}
+
+// SQLSnapshot returns normalized SQL snapshot, one statement per executed view query, statements are sorted by view and SQL
+// as relation views are read concurrently
+func SQLSnapshot(queries []*Query) []byte {
+	statements := make([]string, len(queries))
+	for i, query := range queries {
+		statements[i] = sqlnormalizer.Normalize(query.SQL)
+	}
+	indexes := make([]int, len(queries))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	sort.SliceStable(indexes, func(i, j int) bool {
+		left, right := queries[indexes[i]], queries[indexes[j]]
+		if left.View != right.View {
+			return left.View < right.View
+		}
+		if statements[indexes[i]] != statements[indexes[j]] {
+			return statements[indexes[i]] < statements[indexes[j]]
+		}
+		return fmt.Sprint(left.Args) < fmt.Sprint(right.Args)
+	})
+	var buffer bytes.Buffer
+	for _, index := range indexes {
+		query := queries[index]
+		buffer.WriteString("-- view: " + query.View + "\n")
+		buffer.WriteString(statements[index] + ";\n")
+		if len(query.Args) > 0 {
+			buffer.WriteString(fmt.Sprintf("-- args: %v\n", query.Args))
+		}
+	}
+	return buffer.Bytes()
+}
+
+// EqualOutput reports whether output snapshots are equal
+func EqualOutput(expect, actual []byte, ignore []string) bool {
+	return bytes.Equal(OutputSnapshot(expect, ignore), OutputSnapshot(actual, ignore))
+}
+
+// EqualSQL reports whether SQL snapshots are equal, statements are compared after normalization
+func EqualSQL(expect, actual []byte) bool {
+	expectStatements := strings.Split(strings.TrimSpace(string(expect)), ";\n")
+	actualStatements := strings.Split(strings.TrimSpace(string(actual)), ";\n")
+	if len(expectStatements) != len(actualStatements) {
+		return false
+	}
+	for i := range expectStatements {
+		if !sqlnormalizer.Equal(expectStatements[i], actualStatements[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Diff returns first differing line of snapshots
+func Diff(expect, actual []byte) string {
+	expectLines := strings.Split(string(expect), "\n")
+	actualLines := strings.Split(string(actual), "\n")
+	for i := 0; i < len(expectLines) || i < len(actualLines); i++ {
+		var expectLine, actualLine string
+		if i < len(expectLines) {
+			expectLine = expectLines[i]
+		}
+		if i < len(actualLines) {
+			actualLine = actualLines[i]
+		}
+		if expectLine != actualLine {
+			return fmt.Sprintf("line %v:\n\texpected: %s\n\tactual:   %s", i+1, expectLine, actualLine)
+		}
+	}
+	return ""
+}
+
+func removePath(value interface{}, keys []string) {
+	if len(keys) == 0 {
+		return
+	}
+	switch actual := value.(type) {
+	case map[string]interface{}:
+		if len(keys) == 1 {
+			delete(actual, keys[0])
+			return
+		}
+		removePath(actual[keys[0]], keys[1:])
+	case []interface{}:
+		for _, item := range actual {
+			removePath(item, keys)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package sqlnormalizer
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Normalize returns SQL with collapsed whitespace, quoted literals are preserved, so that formatting changes do not break SQL snapshots
+func Normalize(SQL string) string {
+	var builder strings.Builder
+	var quote, last rune
+	pendingSpace := false
+	for _, r := range strings.TrimSpace(SQL) {
+		if quote != 0 {
+			builder.WriteRune(r)
+			if r == quote {
+				quote = 0
+			}
+			last = r
+			continue
+		}
+		if unicode.IsSpace(r) {
+			pendingSpace = true
+			continue
+		}
+		switch r {
+		case ',', ')':
+			pendingSpace = false
+		case '\'', '"', '`':
+			quote = r
+		}
+		if pendingSpace && last != '(' {
+			builder.WriteByte(' ')
+		}
+		pendingSpace = false
+		builder.WriteRune(r)
+		last = r
+	}
+	return strings.TrimSpace(strings.TrimSuffix(builder.String(), ";"))
+}
+
+// Equal reports whether SQL statements are equal after normalization
+func Equal(expect, actual string) bool {
+	return Normalize(expect) == Normalize(actual)
+}
@@ -0,0 +1,23 @@
+package sqlnormalizer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalize(t *testing.T) {
+	var testCases = []struct {
+		description string
+		SQL         string
+		expect      string
+	}{
+		{description: "collapse whitespace", SQL: "SELECT  id,\n\tname\nFROM emp ;", expect: "SELECT id, name FROM emp"},
+		{description: "tighten parentheses and commas", SQL: "SELECT * FROM emp WHERE id IN ( 1 , 2 )", expect: "SELECT * FROM emp WHERE id IN (1, 2)"},
+		{description: "preserve literals", SQL: "SELECT * FROM emp WHERE name = 'a  b' ", expect: "SELECT * FROM emp WHERE name = 'a  b'"},
+	}
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.expect, Normalize(testCase.SQL), testCase.description)
+	}
+	assert.True(t, Equal("SELECT 1\n", " SELECT   1;"))
+}
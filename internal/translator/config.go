@@ -108,6 +108,12 @@ func (c *Config) updateURIs() error {
 	if !strings.HasPrefix(cfg.Meta.StateURI, c.repository.APIPrefix) {
 		cfg.Meta.StateURI = strings.Replace(cfg.Meta.StateURI, cfg.APIPrefix, c.repository.APIPrefix, 1)
 	}
+	if !strings.HasPrefix(cfg.Meta.JobURI, c.repository.APIPrefix) {
+		cfg.Meta.JobURI = strings.Replace(cfg.Meta.JobURI, cfg.APIPrefix, c.repository.APIPrefix, 1)
+	}
+	if !strings.HasPrefix(cfg.Meta.PlanURI, c.repository.APIPrefix) {
+		cfg.Meta.PlanURI = strings.Replace(cfg.Meta.PlanURI, cfg.APIPrefix, c.repository.APIPrefix, 1)
+	}
 	return nil
 }
 
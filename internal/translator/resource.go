@@ -642,7 +642,10 @@ func parseSettingsDirectives(dSQL string) (*routeSettingsDirective, bool, error)
 		case content.JSONFormat, content.XMLFormat, content.CSVFormat, content.JSONDataFormatTabular:
 			ret.Format = raw
 		default:
-			return nil, false, fmt.Errorf("invalid $format directive: unsupported format %q", raw)
+			if _, ok := content.Formats().Lookup(raw); !ok {
+				return nil, false, fmt.Errorf("invalid $format directive: unsupported format %q", raw)
+			}
+			ret.Format = raw
 		}
 	}
 
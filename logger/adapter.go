@@ -98,6 +98,17 @@ func (l *Adapter) LogDatabaseErr(ctx context.Context, view string, SQL string, e
 		args)
 }
 
+// LogBudgetExceeded logs SQL cancelled by exceeded route or view execution deadline
+func (l *Adapter) LogBudgetExceeded(ctx context.Context, view string, SQL string, elapsed time.Duration, args ...interface{}) {
+	SQL = shared.ExpandSQL(SQL, args)
+	fmt.Printf("[WARN] datly sql budget exceeded reqTraceId=%s view=%s elapsed=%s sql=%q params=%v\n",
+		reqTraceID(ctx),
+		view,
+		elapsed,
+		strings.ReplaceAll(SQL, "\n", "\\n"),
+		args)
+}
+
 func reqTraceID(ctx context.Context) string {
 	if traceID := requesttrace.Current(ctx); traceID != "" {
 		return traceID
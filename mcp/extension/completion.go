@@ -0,0 +1,88 @@
+package extension
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/viant/jsonrpc"
+	"github.com/viant/mcp-protocol/schema"
+	"github.com/viant/mcp-protocol/syncmap"
+)
+
+const (
+	// RefPrompt identifies prompt completion reference
+	RefPrompt = "ref/prompt"
+	// RefResource identifies resource template completion reference
+	RefResource = "ref/resource"
+)
+
+type (
+	// CompletionHandlerFunc returns argument value suggestions
+	CompletionHandlerFunc func(ctx context.Context, params *schema.CompleteRequestParams) (*schema.CompleteResult, *jsonrpc.Error)
+
+	// Completions holds argument completion handlers keyed by prompt name or resource template URI
+	Completions struct {
+		handlers *syncmap.Map[string, CompletionHandlerFunc]
+	}
+
+	// Option represents handler option
+	Option func(h *Handler)
+)
+
+// Register registers completion handler for supplied reference type and name (prompt name or resource template URI)
+func (c *Completions) Register(refType, name string, handler CompletionHandlerFunc) {
+	c.handlers.Put(completionKey(refType, name), handler)
+}
+
+// Lookup returns completion handler for supplied reference
+func (c *Completions) Lookup(ref *schema.CompleteRequestParamsRef) (CompletionHandlerFunc, bool) {
+	if c == nil || ref == nil {
+		return nil, false
+	}
+	name := ref.Name
+	if ref.Type == RefResource {
+		name = ref.Uri
+	}
+	return c.handlers.Get(completionKey(ref.Type, name))
+}
+
+// Size returns number of registered completion handlers
+func (c *Completions) Size() int {
+	if c == nil {
+		return 0
+	}
+	return c.handlers.Size()
+}
+
+func completionKey(refType, name string) string {
+	return refType + ":" + name
+}
+
+// NewCompletions creates completion handler registry
+func NewCompletions() *Completions {
+	return &Completions{handlers: syncmap.NewMap[string, CompletionHandlerFunc]()}
+}
+
+// WithCompletions sets argument completion handlers
+func WithCompletions(completions *Completions) Option {
+	return func(h *Handler) {
+		h.completions = completions
+	}
+}
+
+// Complete handles completion/complete method
+func (i *Handler) Complete(ctx context.Context, jRequest *jsonrpc.TypedRequest[*schema.CompleteRequest]) (*schema.CompleteResult, *jsonrpc.Error) {
+	request := jRequest.Request
+	handler, ok := i.completions.Lookup(&request.Params.Ref)
+	if !ok {
+		return &schema.CompleteResult{Completion: schema.CompleteResultCompletion{Values: []string{}}}, nil
+	}
+	result, err := handler(i.withMCPContext(ctx), &request.Params)
+	if err != nil {
+		return nil, err
+	}
+	if result == nil {
+		return nil, jsonrpc.NewInternalError(fmt.Sprintf("completion for %v returned no result", request.Params.Argument.Name), nil)
+	}
+	return result, nil
+}
@@ -15,6 +15,9 @@ import (
 type (
 	Handler struct {
 		*server.DefaultHandler
+		completions   *Completions
+		subscriptions *Subscriptions
+		toolSchemas   *ToolSchemas
 	}
 )
 
@@ -80,6 +83,7 @@ func (i *Handler) withMCPContext(ctx context.Context) context.Context {
 
 // Implements checks if the method is implemented
 func (i *Handler) Implements(method string) bool {
+	i.subscriptions.markSeen(i) //every session request is checked here
 	switch method {
 	case schema.MethodResourcesList,
 		schema.MethodResourcesTemplatesList,
@@ -89,10 +93,33 @@ func (i *Handler) Implements(method string) bool {
 		schema.MethodToolsList,
 		schema.MethodToolsCall:
 		return true
+	case schema.MethodPromptsList, schema.MethodPromptsGet:
+		return i.DefaultHandler != nil && i.DefaultHandler.Prompts.Size() > 0
+	case schema.MethodComplete:
+		return i.completions.Size() > 0
 	}
 	return false
 }
 
+// Initialize populates server capabilities
+func (i *Handler) Initialize(ctx context.Context, init *schema.InitializeRequestParams, result *schema.InitializeResult) {
+	i.DefaultHandler.Initialize(ctx, init, result)
+	i.populateCapabilities(&result.Capabilities)
+}
+
+// Discover populates server capabilities
+func (i *Handler) Discover(ctx context.Context, result *schema.DiscoverResult) {
+	i.DefaultHandler.Discover(ctx, result)
+	i.populateCapabilities(&result.Capabilities)
+}
+
+func (i *Handler) populateCapabilities(capabilities *schema.ServerCapabilities) {
+	if i.subscriptions != nil && capabilities.Resources != nil {
+		subscribe := true
+		capabilities.Resources.Subscribe = &subscribe
+	}
+}
+
 func (i *Handler) ReadResource(ctx context.Context, request *jsonrpc.TypedRequest[*schema.ReadResourceRequest]) (*schema.ReadResourceResult, *jsonrpc.Error) {
 	return i.DefaultHandler.ReadResource(i.withMCPContext(ctx), request)
 }
@@ -101,13 +128,24 @@ func (i *Handler) CallTool(ctx context.Context, request *jsonrpc.TypedRequest[*s
 	return i.DefaultHandler.CallTool(i.withMCPContext(ctx), request)
 }
 
+func (i *Handler) GetPrompt(ctx context.Context, request *jsonrpc.TypedRequest[*schema.GetPromptRequest]) (*schema.GetPromptResult, *jsonrpc.Error) {
+	return i.DefaultHandler.GetPrompt(i.withMCPContext(ctx), request)
+}
+
 // New creates a new implementer
-func New(registry *server.Registry) server.NewHandler {
+func New(registry *server.Registry, options ...Option) server.NewHandler {
 	return func(_ context.Context, notifier transport.Notifier, logger logger.Logger, client client.Operations) (server.Handler, error) {
 		base := server.NewDefaultHandler(notifier, logger, client)
 		base.Registry = registry
-		return &Handler{
+		ret := &Handler{
 			DefaultHandler: base,
-		}, nil
+		}
+		for _, option := range options {
+			option(ret)
+		}
+		if ret.completions.Size() > 0 {
+			base.ServerCapabilities = &schema.ServerCapabilities{Completions: map[string]interface{}{}}
+		}
+		return ret, nil
 	}
 }
@@ -0,0 +1,329 @@
+package extension
+
+import (
+	"context"
+	"encoding/json"
+	"hash/fnv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/viant/jsonrpc"
+	"github.com/viant/mcp-protocol/authorization"
+	"github.com/viant/mcp-protocol/schema"
+)
+
+const (
+	// DefaultSubscriptionInterval defines default resource polling interval
+	DefaultSubscriptionInterval = 30 * time.Second
+	// DefaultSessionTTL defines inactivity after which session subscriptions are removed, it matches MCP transport session idle TTL
+	DefaultSessionTTL = 5 * time.Minute
+)
+
+type (
+	// Subscriptions tracks per session resource subscriptions; subscribed resources are re-read on interval,
+	// or when an underlying table is modified, and subscribers are notified when resource content hash changes
+	Subscriptions struct {
+		interval time.Duration
+		mux      sync.Mutex
+		items    map[*Handler]map[string]*subscription
+		seen     map[*Handler]time.Time // last session activity, transport does not report session close
+		ttl      time.Duration
+		tables   map[string][]string // resource URI or URI template prefix to source tables
+		running  bool
+		touched  chan struct{}
+	}
+
+	subscription struct {
+		uri     string
+		ctx     context.Context
+		hash    uint64
+		touched bool
+	}
+)
+
+// BindTables binds resource URI (or URI template) to its underlying tables, used to refresh subscriptions on writes
+func (s *Subscriptions) BindTables(uri string, tables ...string) {
+	if s == nil || len(tables) == 0 {
+		return
+	}
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	uri = templatePrefix(uri)
+	for _, table := range tables {
+		table = strings.ToLower(table)
+		if table == "" || containsString(s.tables[uri], table) {
+			continue
+		}
+		s.tables[uri] = append(s.tables[uri], table)
+	}
+}
+
+// Touch marks subscriptions reading supplied table for immediate refresh
+func (s *Subscriptions) Touch(tables ...string) {
+	if s == nil || len(tables) == 0 {
+		return
+	}
+	s.mux.Lock()
+	matched := false
+	for _, items := range s.items {
+		for _, item := range items {
+			bound := s.boundTables(item.uri)
+			for _, table := range tables {
+				if containsString(bound, strings.ToLower(table)) {
+					item.touched = true
+					matched = true
+				}
+			}
+		}
+	}
+	s.mux.Unlock()
+	if !matched {
+		return
+	}
+	select {
+	case s.touched <- struct{}{}:
+	default:
+	}
+}
+
+// Size returns number of active subscriptions
+func (s *Subscriptions) Size() int {
+	if s == nil {
+		return 0
+	}
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	result := 0
+	for _, items := range s.items {
+		result += len(items)
+	}
+	return result
+}
+
+func (s *Subscriptions) boundTables(uri string) []string {
+	if tables, ok := s.tables[uri]; ok {
+		return tables
+	}
+	var result []string
+	for prefix, tables := range s.tables {
+		if strings.HasPrefix(uri, prefix) {
+			result = append(result, tables...)
+		}
+	}
+	return result
+}
+
+func (s *Subscriptions) subscribe(ctx context.Context, handler *Handler, uri string) {
+	// subscription outlives subscribe request, only caller token is retained for subsequent reads
+	readCtx := context.Background()
+	if token := ctx.Value(authorization.TokenKey); token != nil {
+		readCtx = context.WithValue(readCtx, authorization.TokenKey, token)
+	}
+	item := &subscription{uri: uri, ctx: readCtx}
+	if hash, ok := handler.resourceHash(readCtx, uri); ok {
+		item.hash = hash
+	}
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	items, ok := s.items[handler]
+	if !ok {
+		items = map[string]*subscription{}
+		s.items[handler] = items
+	}
+	items[uri] = item
+	s.seen[handler] = time.Now()
+	if !s.running {
+		s.running = true
+		go s.watch()
+	}
+}
+
+func (s *Subscriptions) unsubscribe(handler *Handler, uri string) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	items, ok := s.items[handler]
+	if !ok {
+		return
+	}
+	delete(items, uri)
+	if len(items) == 0 {
+		delete(s.items, handler)
+		delete(s.seen, handler)
+	}
+}
+
+// markSeen records session activity of a subscribed handler
+func (s *Subscriptions) markSeen(handler *Handler) {
+	if s == nil {
+		return
+	}
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	if _, ok := s.items[handler]; ok {
+		s.seen[handler] = time.Now()
+	}
+}
+
+// removeSession removes all subscriptions of a closed session
+func (s *Subscriptions) removeSession(handler *Handler) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	delete(s.items, handler)
+	delete(s.seen, handler)
+}
+
+// expire removes subscriptions of sessions inactive beyond TTL, their transport session is already closed
+func (s *Subscriptions) expire(now time.Time) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	for handler, seen := range s.seen {
+		if now.Sub(seen) > s.ttl {
+			delete(s.items, handler)
+			delete(s.seen, handler)
+		}
+	}
+}
+
+// watch refreshes subscriptions until the last one is removed
+func (s *Subscriptions) watch() {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			s.refresh(true)
+		case <-s.touched:
+			s.refresh(false)
+		}
+		s.mux.Lock()
+		if len(s.items) == 0 {
+			s.running = false
+			s.mux.Unlock()
+			return
+		}
+		s.mux.Unlock()
+	}
+}
+
+func (s *Subscriptions) refresh(all bool) {
+	type pending struct {
+		handler *Handler
+		item    *subscription
+	}
+	var candidates []pending
+	s.expire(time.Now())
+	s.mux.Lock()
+	for handler, items := range s.items {
+		for _, item := range items {
+			if all || item.touched {
+				item.touched = false
+				candidates = append(candidates, pending{handler: handler, item: item})
+			}
+		}
+	}
+	s.mux.Unlock()
+	for _, candidate := range candidates {
+		hash, ok := candidate.handler.resourceHash(candidate.item.ctx, candidate.item.uri)
+		if !ok || hash == candidate.item.hash {
+			continue
+		}
+		candidate.item.hash = hash
+		if err := candidate.handler.notifyResourceUpdated(candidate.item.ctx, candidate.item.uri); err != nil {
+			s.removeSession(candidate.handler) //session is gone
+			continue
+		}
+		s.markSeen(candidate.handler) //notification keeps transport session alive
+	}
+}
+
+// Subscribe tracks resource subscription for the session
+func (i *Handler) Subscribe(ctx context.Context, jRequest *jsonrpc.TypedRequest[*schema.SubscribeRequest]) (*schema.SubscribeResult, *jsonrpc.Error) {
+	result, err := i.DefaultHandler.Subscribe(ctx, jRequest)
+	if err != nil || i.subscriptions == nil {
+		return result, err
+	}
+	i.subscriptions.subscribe(ctx, i, jRequest.Request.Params.Uri)
+	return result, nil
+}
+
+// Unsubscribe removes session resource subscription
+func (i *Handler) Unsubscribe(ctx context.Context, jRequest *jsonrpc.TypedRequest[*schema.UnsubscribeRequest]) (*schema.UnsubscribeResult, *jsonrpc.Error) {
+	result, err := i.DefaultHandler.Unsubscribe(ctx, jRequest)
+	if err != nil || i.subscriptions == nil {
+		return result, err
+	}
+	i.subscriptions.unsubscribe(i, jRequest.Request.Params.Uri)
+	return result, nil
+}
+
+func (i *Handler) resourceHash(ctx context.Context, uri string) (uint64, bool) {
+	request := &jsonrpc.TypedRequest[*schema.ReadResourceRequest]{Request: &schema.ReadResourceRequest{
+		Method: schema.MethodResourcesRead,
+		Params: schema.ReadResourceRequestParams{Uri: uri},
+	}}
+	result, err := i.ReadResource(ctx, request)
+	if err != nil || result == nil {
+		return 0, false
+	}
+	hash := fnv.New64a()
+	for _, content := range result.Contents {
+		_, _ = hash.Write([]byte(content.Text))
+		_, _ = hash.Write([]byte(content.Blob))
+	}
+	return hash.Sum64(), true
+}
+
+func (i *Handler) notifyResourceUpdated(ctx context.Context, uri string) error {
+	if i.DefaultHandler == nil || i.DefaultHandler.Notifier == nil {
+		return nil
+	}
+	params, err := json.Marshal(&schema.ResourceUpdatedNotificationParams{Uri: uri})
+	if err != nil {
+		return err
+	}
+	return i.DefaultHandler.Notifier.Notify(ctx, &jsonrpc.Notification{
+		Jsonrpc: jsonrpc.Version,
+		Method:  schema.MethodNotificationResourceUpdated,
+		Params:  params,
+	})
+}
+
+// templatePrefix returns URI template part preceding the first expression, or URI itself
+func templatePrefix(uri string) string {
+	if index := strings.Index(uri, "{"); index != -1 {
+		return uri[:index]
+	}
+	return uri
+}
+
+func containsString(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
+
+// NewSubscriptions creates resource subscriptions tracker
+func NewSubscriptions(interval time.Duration) *Subscriptions {
+	if interval <= 0 {
+		interval = DefaultSubscriptionInterval
+	}
+	return &Subscriptions{
+		interval: interval,
+		items:    map[*Handler]map[string]*subscription{},
+		seen:     map[*Handler]time.Time{},
+		ttl:      DefaultSessionTTL,
+		tables:   map[string][]string{},
+		touched:  make(chan struct{}, 1),
+	}
+}
+
+// WithSubscriptions sets resource subscriptions tracker
+func WithSubscriptions(subscriptions *Subscriptions) Option {
+	return func(h *Handler) {
+		h.subscriptions = subscriptions
+	}
+}
@@ -0,0 +1,109 @@
+package extension
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/viant/jsonrpc"
+	"github.com/viant/mcp-protocol/schema"
+	serverproto "github.com/viant/mcp-protocol/server"
+)
+
+type fakeNotifier struct {
+	mux           sync.Mutex
+	notifications []*jsonrpc.Notification
+}
+
+func (f *fakeNotifier) Notify(ctx context.Context, notification *jsonrpc.Notification) error {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+	f.notifications = append(f.notifications, notification)
+	return nil
+}
+
+func (f *fakeNotifier) count() int {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+	return len(f.notifications)
+}
+
+func TestHandler_Subscribe_NotifiesOnTableChange(t *testing.T) {
+	const uri = "datly://localhost/v1/api/vendors"
+	var mux sync.Mutex
+	content := "v1"
+	registry := serverproto.NewRegistry()
+	registry.RegisterResource(schema.Resource{Uri: uri, Name: "vendors"}, func(ctx context.Context, request *schema.ReadResourceRequest) (*schema.ReadResourceResult, *jsonrpc.Error) {
+		mux.Lock()
+		defer mux.Unlock()
+		return &schema.ReadResourceResult{Contents: []schema.ReadResourceResultContentsElem{{Uri: uri, Text: content}}}, nil
+	})
+	subscriptions := NewSubscriptions(time.Hour)
+	subscriptions.BindTables(uri, "VENDOR")
+	notifier := &fakeNotifier{}
+	actual, err := New(registry, WithSubscriptions(subscriptions))(context.Background(), notifier, nil, &fakeClientOps{})
+	require.NoError(t, err)
+	handler := actual.(*Handler)
+
+	_, rpcErr := handler.Subscribe(context.Background(), &jsonrpc.TypedRequest[*schema.SubscribeRequest]{
+		Request: &schema.SubscribeRequest{Params: schema.SubscribeRequestParams{Uri: uri}},
+	})
+	require.Nil(t, rpcErr)
+	assert.Equal(t, 1, subscriptions.Size())
+
+	subscriptions.Touch("vendor")
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, 0, notifier.count(), "unchanged content should not be notified")
+
+	mux.Lock()
+	content = "v2"
+	mux.Unlock()
+	subscriptions.Touch("product")
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, 0, notifier.count(), "unrelated table should not refresh resource")
+
+	subscriptions.Touch("vendor")
+	require.Eventually(t, func() bool { return notifier.count() == 1 }, time.Second, 10*time.Millisecond)
+	assert.Equal(t, schema.MethodNotificationResourceUpdated, notifier.notifications[0].Method)
+	assert.Contains(t, string(notifier.notifications[0].Params), uri)
+
+	_, rpcErr = handler.Unsubscribe(context.Background(), &jsonrpc.TypedRequest[*schema.UnsubscribeRequest]{
+		Request: &schema.UnsubscribeRequest{Params: schema.UnsubscribeRequestParams{Uri: uri}},
+	})
+	require.Nil(t, rpcErr)
+	assert.Equal(t, 0, subscriptions.Size())
+}
+
+func TestSubscriptions_ExpiresClosedSessions(t *testing.T) {
+	const uri = "datly://localhost/v1/api/vendors"
+	registry := serverproto.NewRegistry()
+	registry.RegisterResource(schema.Resource{Uri: uri, Name: "vendors"}, func(ctx context.Context, request *schema.ReadResourceRequest) (*schema.ReadResourceResult, *jsonrpc.Error) {
+		return &schema.ReadResourceResult{Contents: []schema.ReadResourceResultContentsElem{{Uri: uri, Text: "v1"}}}, nil
+	})
+	subscriptions := NewSubscriptions(time.Hour)
+	subscriptions.ttl = time.Minute
+	newHandler := New(registry, WithSubscriptions(subscriptions))
+	var handlers []*Handler
+	for i := 0; i < 2; i++ {
+		actual, err := newHandler(context.Background(), &fakeNotifier{}, nil, &fakeClientOps{})
+		require.NoError(t, err)
+		handler := actual.(*Handler)
+		_, rpcErr := handler.Subscribe(context.Background(), &jsonrpc.TypedRequest[*schema.SubscribeRequest]{
+			Request: &schema.SubscribeRequest{Params: schema.SubscribeRequestParams{Uri: uri}},
+		})
+		require.Nil(t, rpcErr)
+		handlers = append(handlers, handler)
+	}
+	assert.Equal(t, 2, subscriptions.Size())
+
+	subscriptions.seen[handlers[0]] = time.Now().Add(-2 * time.Minute)
+	subscriptions.seen[handlers[1]] = time.Now().Add(-2 * time.Minute)
+	handlers[1].Implements(schema.MethodResourcesRead)
+	subscriptions.expire(time.Now())
+	assert.Equal(t, 1, subscriptions.Size())
+	_, ok := subscriptions.items[handlers[1]]
+	assert.True(t, ok, "active session should keep subscriptions")
+}
@@ -0,0 +1,74 @@
+package extension
+
+import (
+	"context"
+
+	"github.com/viant/jsonrpc"
+	"github.com/viant/mcp-protocol/schema"
+	"github.com/viant/mcp-protocol/syncmap"
+)
+
+type (
+	// ToolSchemaFunc returns tool input schema for the caller context
+	ToolSchemaFunc func(ctx context.Context) (*schema.ToolInputSchema, error)
+
+	// ToolSchemas holds caller dependent tool input schemas keyed by tool name, i.e. schemas of tools
+	// with column authorization restricted fields
+	ToolSchemas struct {
+		schemas *syncmap.Map[string, ToolSchemaFunc]
+	}
+)
+
+// Register registers tool input schema function
+func (t *ToolSchemas) Register(name string, schemaFn ToolSchemaFunc) {
+	t.schemas.Put(name, schemaFn)
+}
+
+// Lookup returns tool input schema function
+func (t *ToolSchemas) Lookup(name string) (ToolSchemaFunc, bool) {
+	if t == nil {
+		return nil, false
+	}
+	return t.schemas.Get(name)
+}
+
+// Size returns number of registered tool schema functions
+func (t *ToolSchemas) Size() int {
+	if t == nil {
+		return 0
+	}
+	return t.schemas.Size()
+}
+
+// NewToolSchemas creates tool schema registry
+func NewToolSchemas() *ToolSchemas {
+	return &ToolSchemas{schemas: syncmap.NewMap[string, ToolSchemaFunc]()}
+}
+
+// WithToolSchemas sets caller dependent tool input schemas
+func WithToolSchemas(toolSchemas *ToolSchemas) Option {
+	return func(h *Handler) {
+		h.toolSchemas = toolSchemas
+	}
+}
+
+// ListTools handles tools/list method, registered schema functions replace shared tool input schemas
+func (i *Handler) ListTools(ctx context.Context, request *jsonrpc.TypedRequest[*schema.ListToolsRequest]) (*schema.ListToolsResult, *jsonrpc.Error) {
+	result, rpcErr := i.DefaultHandler.ListTools(ctx, request)
+	if rpcErr != nil || i.toolSchemas.Size() == 0 {
+		return result, rpcErr
+	}
+	for k := range result.Tools {
+		tool := &result.Tools[k]
+		schemaFn, ok := i.toolSchemas.Lookup(tool.Name)
+		if !ok {
+			continue
+		}
+		inputSchema, err := schemaFn(ctx)
+		if err != nil {
+			return nil, jsonrpc.NewInternalError(err.Error(), nil)
+		}
+		tool.InputSchema = *inputSchema
+	}
+	return result, nil
+}
@@ -33,14 +33,41 @@ import (
 )
 
 type Server struct {
-	server   *server.Server // The underlying MCP server instance
-	config   *gateway.ModelContextProtocol
-	registry *serverproto.Registry
+	server        *server.Server // The underlying MCP server instance
+	config        *gateway.ModelContextProtocol
+	registry      *serverproto.Registry
+	completions   *extension.Completions
+	subscriptions *extension.Subscriptions
+	toolSchemas   *extension.ToolSchemas
+}
+
+// Option represents MCP server option
+type Option func(s *Server)
+
+// WithCompletions sets argument completion handlers
+func WithCompletions(completions *extension.Completions) Option {
+	return func(s *Server) {
+		s.completions = completions
+	}
+}
+
+// WithSubscriptions sets resource subscriptions tracker
+func WithSubscriptions(subscriptions *extension.Subscriptions) Option {
+	return func(s *Server) {
+		s.subscriptions = subscriptions
+	}
+}
+
+// WithToolSchemas sets caller dependent tool input schemas
+func WithToolSchemas(toolSchemas *extension.ToolSchemas) Option {
+	return func(s *Server) {
+		s.toolSchemas = toolSchemas
+	}
 }
 
 func (s *Server) init() error {
 
-	var newImplementer = extension.New(s.registry)
+	var newImplementer = extension.New(s.registry, extension.WithCompletions(s.completions), extension.WithSubscriptions(s.subscriptions), extension.WithToolSchemas(s.toolSchemas))
 	var options = []server.Option{
 		server.WithNewHandler(newImplementer),
 		server.WithImplementation(schema.Implementation{Name: "Datly", Version: "0.1"}),
@@ -173,7 +200,7 @@ func loadAuthConfig(ctx context.Context, mcpOption *gateway.ModelContextProtocol
 	return nil, nil
 }
 
-func NewServer(registry *serverproto.Registry, config *gateway.ModelContextProtocol) (*Server, error) {
+func NewServer(registry *serverproto.Registry, config *gateway.ModelContextProtocol, options ...Option) (*Server, error) {
 	if registry == nil {
 		return nil, nil
 	}
@@ -181,6 +208,9 @@ func NewServer(registry *serverproto.Registry, config *gateway.ModelContextProto
 		config:   config,
 		registry: registry,
 	}
+	for _, option := range options {
+		option(s)
+	}
 
 	if err := s.init(); err != nil {
 		return nil, err
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"github.com/viant/afs/url"
+	"github.com/viant/datly/repository/contract"
 	"github.com/viant/datly/service/jobs"
 	"github.com/viant/datly/view"
 	"github.com/viant/datly/view/state"
@@ -35,11 +36,17 @@ type (
 		WithCache            bool
 		ExpiryTimeInSec      int
 		ErrorExpiryTimeInSec int
+		Retry                *jobs.RetryPolicy `json:",omitempty" yaml:",omitempty"`
+		Webhook              *jobs.Webhook     `json:",omitempty" yaml:",omitempty"`
 		async.Notification
-		mux sync.Mutex
+		mux   sync.Mutex
+		queue *jobs.Queue
 	}
 )
 
+// NotificationMethodQueue dispatches jobs with DB backed queue, executed by local worker pool
+const NotificationMethodQueue = async.NotificationMethod("Queue")
+
 func (c *Config) TTL() time.Duration {
 	ttl := time.Second * time.Duration(c.ExpiryTimeInSec)
 	if ttl != 0 {
@@ -88,7 +95,8 @@ func (n *Config) DestinationURL(job *async.Job) string {
 	return destination
 }
 
-func (c *Config) Init(ctx context.Context, resource *view.Resource, mainView *view.View) error {
+// Init initialises async config, component path identifies queued jobs observed by webhook
+func (c *Config) Init(ctx context.Context, resource *view.Resource, mainView *view.View, aPath *contract.Path) error {
 	if c == nil {
 		return nil
 	}
@@ -109,9 +117,31 @@ func (c *Config) Init(ctx context.Context, resource *view.Resource, mainView *vi
 	if err := c.service.Init(ctx); err != nil {
 		return err
 	}
+	if c.Webhook != nil {
+		if err := c.Webhook.Init(ctx, c.service); err != nil {
+			return err
+		}
+	}
+	if c.Method == NotificationMethodQueue {
+		c.queue = jobs.QueueFor(c.service)
+		if err := c.queue.Init(ctx); err != nil {
+			return err
+		}
+		if c.Webhook != nil && aPath != nil {
+			c.queue.Observe(aPath.Method, aPath.URI, c.Webhook.Notify)
+		}
+	}
 	return nil
 }
 
+// Enqueue adds job to the durable queue
+func (c *Config) Enqueue(ctx context.Context, job *async.Job) error {
+	if c.queue == nil {
+		return fmt.Errorf("async job queue was not initialised, notification method: %v", c.Method)
+	}
+	return c.queue.Enqueue(ctx, job, c.Retry)
+}
+
 func (c *Config) JobByID(ctx context.Context, jobID string) (*async.Job, error) {
 	return c.service.JobById(ctx, jobID)
 }
@@ -132,7 +162,22 @@ func (c *Config) CreateJob(ctx context.Context, job *async.Job, notification *as
 }
 
 func (c *Config) UpdateJob(ctx context.Context, job *async.Job) error {
-	return c.service.UpdateJob(ctx, job)
+	if err := c.service.UpdateJob(ctx, job); err != nil {
+		return err
+	}
+	if c.notifies(job) {
+		c.Webhook.Notify(ctx, job)
+	}
+	return nil
+}
+
+// notifies returns true if job completion webhook should be sent, queued job errors are retried,
+// and the queue notifies once attempts are exhausted
+func (c *Config) notifies(job *async.Job) bool {
+	if c.Webhook == nil || !jobs.IsTerminal(job.Status) {
+		return false
+	}
+	return !(c.queue != nil && job.Status == string(async.StatusError))
 }
 
 /*
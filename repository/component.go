@@ -13,12 +13,14 @@ import (
 	"github.com/viant/afs"
 	"github.com/viant/datly/gateway/router/marshal"
 	"github.com/viant/datly/gateway/router/marshal/config"
+	"github.com/viant/datly/gateway/router/marshal/hypermedia"
 	"github.com/viant/datly/gateway/router/marshal/json"
 	"github.com/viant/datly/internal/setter"
 	"github.com/viant/datly/repository/async"
 	content "github.com/viant/datly/repository/content"
 	"github.com/viant/datly/repository/contract"
 	"github.com/viant/datly/repository/handler"
+	"github.com/viant/datly/repository/locale"
 	"github.com/viant/datly/repository/shape/typectx"
 	"github.com/viant/datly/repository/version"
 	"github.com/viant/datly/service"
@@ -53,13 +55,17 @@ type (
 		TypeContext     *typectx.Context `json:",omitempty" yaml:",omitempty"`
 		indexedView     view.NamedViews
 		SourceURL       string
+		MessagesURL     string `json:",omitempty" yaml:",omitempty"` //component message catalogs, override gateway ones
 
-		dispatcher contract.Dispatcher
-		types      *xreflect.Types
-		ioConfig   *config.IOConfig
-		doc        docs.Service
-		embedFs    *embed.FS
-		with       []string
+		dispatcher    contract.Dispatcher
+		types         *xreflect.Types
+		ioConfig      *config.IOConfig
+		doc           docs.Service
+		embedFs       *embed.FS
+		with          []string
+		hypermedia    *hypermedia.Resource
+		hypermediaErr error
+		messages      *locale.Catalog
 	}
 
 	ComponentOption func(c *Component) error
@@ -125,7 +131,16 @@ func (c *Component) Init(ctx context.Context, resource *view.Resource) (err erro
 	if err := c.Content.InitMarshaller(c.IOConfig(), c.Output.Exclude, c.BodyType(), c.OutputType(), lookupType); err != nil {
 		return err
 	}
-	if err = c.Async.Init(ctx, resource, c.View); err != nil {
+	if err = c.Async.Init(ctx, resource, c.View, &c.Path); err != nil {
+		return err
+	}
+	if c.View != nil && c.Service == service.TypeReader {
+		if c.hypermedia, c.hypermediaErr = newHypermediaResource(c.View); c.hypermediaErr != nil && hypermedia.IsFormat(c.Output.DataFormat) {
+			return c.hypermediaErr
+		}
+		c.Content.SetFormatResources(c.formatResource)
+	}
+	if err = c.initMessages(ctx); err != nil {
 		return err
 	}
 	c.doc, _ = resource.Doc()
@@ -433,6 +448,14 @@ func (c *Component) UnmarshalFor(opts ...UnmarshalOption) shared.Unmarshal {
 		return c.Content.Marshaller.XML.Unmarshal
 	case content.CSVContentType:
 		return c.Content.CSV.Unmarshal
+	default:
+		if unmarshaller, err := c.Content.FormatUnmarshaller(contentType); err != nil {
+			return func(data []byte, dest interface{}) error {
+				return err
+			}
+		} else if unmarshaller != nil {
+			return unmarshaller.Unmarshal
+		}
 	}
 	// Fallback to data format preference when no content type or not matched
 	if c.Output.DataFormat == content.XMLFormat {
@@ -525,6 +548,14 @@ func (c *Component) MarshalFunc(opts ...MarshalOption) shared.Marshal {
 
 	// Resolve filters (explicit only)
 	filters := options.filters
+	if marshaller, ok, err := c.Content.ComponentMarshaller(format); ok {
+		return func(src interface{}) ([]byte, error) {
+			if err != nil {
+				return nil, err
+			}
+			return marshaller.Marshal(src, field, options.request)
+		}
+	}
 
 	return func(src interface{}) ([]byte, error) {
 		return c.Content.Marshal(format, field, src, filters)
@@ -31,6 +31,9 @@ type (
 		TabularJSON              *TabularJSONConfig `json:",omitempty"`
 		Transforms               marshal.Transforms `json:"Transforms,omitempty" yaml:"Transforms,omitempty" `
 		unmarshallerInterceptors marshal.Transforms
+		inputType                reflect.Type
+		outputType               reflect.Type
+		formats                  *formatCodecs
 	}
 	XLSConfig struct {
 		DefaultStyle string
@@ -243,6 +246,8 @@ func (x *XLSConfig) Options() []xlsy.Option {
 
 func (c *Content) InitMarshaller(config *config.IOConfig, exclude []string, inputType, outputType reflect.Type, lookupType xreflect.LookupType) error {
 	c.unmarshallerInterceptors = c.Transforms.FilterByKind(marshal.TransformKindUnmarshal)
+	c.inputType, c.outputType = inputType, outputType
+	c.formats = newFormatCodecs()
 	if err := c.Marshaller.Init(lookupType); err != nil {
 		return err
 	}
@@ -491,7 +496,17 @@ func (c *Content) Marshal(format string, field string, response interface{}, opt
 		}
 		return c.Marshaller.JSON.RuntimeMarshallerEngine().Marshal(response, options...)
 	default:
-		return nil, fmt.Errorf("unsupproted readerData format: %s", format)
+		if marshaller, ok, err := c.ComponentMarshaller(format); ok {
+			if err != nil {
+				return nil, err
+			}
+			return marshaller.Marshal(response, field, nil)
+		}
+		marshaller, err := c.FormatMarshaller(format)
+		if err != nil {
+			return nil, err
+		}
+		return marshaller.Marshal(response)
 	}
 	//TODO extract responseData
 }
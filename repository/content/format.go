@@ -0,0 +1,298 @@
+package content
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+type (
+	// MarshallerFactory creates format marshaller for component output type
+	MarshallerFactory func(outputType reflect.Type) (Marshaller, error)
+
+	// UnmarshallerFactory creates format unmarshaller for component input (request body) type
+	UnmarshallerFactory func(inputType reflect.Type) (Unmarshaller, error)
+
+	// ComponentMarshallerFactory creates format marshaller using component format resource, i.e. hypermedia view resource
+	ComponentMarshallerFactory func(component *FormatComponent) (RequestMarshaller, error)
+
+	// FormatResourceFunc returns component specific resource for format name
+	FormatResourceFunc func(format string) (interface{}, error)
+
+	// FormatComponent represents component settings available to component marshaller factory
+	FormatComponent struct {
+		OutputType reflect.Type
+		Resource   interface{}                           //component format resource
+		JSON       func(src interface{}) ([]byte, error) //component JSON marshaller
+	}
+
+	// RequestMarshaller marshals output with request, field is output data field name, empty if output is data itself
+	RequestMarshaller interface {
+		Marshal(src interface{}, field string, request *http.Request) ([]byte, error)
+	}
+
+	// Format represents pluggable content format
+	Format struct {
+		Name         string   //$format query value, i.e. yaml
+		ContentTypes []string //first content type is used for responses
+		Marshaller   MarshallerFactory
+		Unmarshaller UnmarshallerFactory
+		//ComponentMarshaller takes precedence over Marshaller for component outputs
+		ComponentMarshaller ComponentMarshallerFactory
+		OpenAPI             *FormatOpenAPI
+	}
+
+	// FormatOpenAPI controls format media type contribution to OpenAPI document
+	FormatOpenAPI struct {
+		Request  bool //adds media type to request body
+		Response bool //adds media type to responses
+		Binary   bool //describes payload as binary string instead of component schema
+	}
+
+	// FormatRegistry represents content format registry
+	FormatRegistry struct {
+		mux           sync.RWMutex
+		byName        map[string]*Format
+		byContentType map[string]*Format
+	}
+
+	formatCodec struct {
+		marshaller          Marshaller
+		unmarshaller        Unmarshaller
+		componentMarshaller RequestMarshaller
+	}
+
+	formatCodecs struct {
+		mux       sync.Mutex
+		codecs    map[string]*formatCodec
+		resources FormatResourceFunc
+	}
+)
+
+var formats = NewFormatRegistry()
+
+// Formats returns process wide format registry
+func Formats() *FormatRegistry {
+	return formats
+}
+
+// RegisterFormat registers format with process wide registry
+func RegisterFormat(format *Format) error {
+	return formats.Register(format)
+}
+
+// ContentType returns response content type
+func (f *Format) ContentType() string {
+	if len(f.ContentTypes) == 0 {
+		return ""
+	}
+	return f.ContentTypes[0]
+}
+
+// Register registers format, builtin formats can not be replaced
+func (r *FormatRegistry) Register(format *Format) error {
+	if format == nil || format.Name == "" {
+		return fmt.Errorf("format name was empty")
+	}
+	name := strings.ToLower(format.Name)
+	if IsBuiltinFormat(name) {
+		return fmt.Errorf("format %v is builtin", format.Name)
+	}
+	if len(format.ContentTypes) == 0 {
+		return fmt.Errorf("format %v content type was empty", format.Name)
+	}
+	if format.Marshaller == nil && format.Unmarshaller == nil && format.ComponentMarshaller == nil {
+		return fmt.Errorf("format %v marshaller and unmarshaller were empty", format.Name)
+	}
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	r.byName[name] = format
+	for _, contentType := range format.ContentTypes {
+		r.byContentType[normalizeContentType(contentType)] = format
+	}
+	return nil
+}
+
+// Lookup returns format for $format name
+func (r *FormatRegistry) Lookup(name string) (*Format, bool) {
+	r.mux.RLock()
+	defer r.mux.RUnlock()
+	ret, ok := r.byName[strings.ToLower(name)]
+	return ret, ok
+}
+
+// LookupContentType returns format for content type, content type parameters are ignored
+func (r *FormatRegistry) LookupContentType(contentType string) (*Format, bool) {
+	r.mux.RLock()
+	defer r.mux.RUnlock()
+	ret, ok := r.byContentType[normalizeContentType(contentType)]
+	return ret, ok
+}
+
+// Names returns sorted registered format names
+func (r *FormatRegistry) Names() []string {
+	r.mux.RLock()
+	defer r.mux.RUnlock()
+	var ret = make([]string, 0, len(r.byName))
+	for name := range r.byName {
+		ret = append(ret, name)
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+// Formats returns registered formats sorted by name
+func (r *FormatRegistry) Formats() []*Format {
+	var ret []*Format
+	for _, name := range r.Names() {
+		if format, ok := r.Lookup(name); ok {
+			ret = append(ret, format)
+		}
+	}
+	return ret
+}
+
+// NewFormatRegistry creates format registry
+func NewFormatRegistry() *FormatRegistry {
+	return &FormatRegistry{byName: map[string]*Format{}, byContentType: map[string]*Format{}}
+}
+
+// IsBuiltinFormat returns true for formats handled natively by content
+func IsBuiltinFormat(name string) bool {
+	switch strings.ToLower(name) {
+	case JSONFormat, XMLFormat, XLSFormat, CSVFormat, JSONDataFormatTabular:
+		return true
+	}
+	return false
+}
+
+// IsFormat returns true for builtin or registered format
+func IsFormat(name string) bool {
+	if IsBuiltinFormat(name) {
+		return true
+	}
+	_, ok := formats.Lookup(name)
+	return ok
+}
+
+func normalizeContentType(contentType string) string {
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil {
+		return mediaType
+	}
+	if index := strings.Index(contentType, ";"); index != -1 {
+		contentType = contentType[:index]
+	}
+	return strings.ToLower(strings.TrimSpace(contentType))
+}
+
+// FormatMarshaller returns registered format marshaller built for component output type
+func (c *Content) FormatMarshaller(name string) (Marshaller, error) {
+	format, ok := formats.Lookup(name)
+	if !ok {
+		return nil, fmt.Errorf("unsupproted readerData format: %s", name)
+	}
+	if format.Marshaller == nil {
+		return nil, fmt.Errorf("format %s does not support marshalling", name)
+	}
+	if c.formats == nil {
+		return nil, fmt.Errorf("content marshaller was not initialized")
+	}
+	c.formats.mux.Lock()
+	defer c.formats.mux.Unlock()
+	codec := c.formats.codec(format.Name)
+	if codec.marshaller == nil {
+		marshaller, err := format.Marshaller(c.outputType)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create %s marshaller: %w", format.Name, err)
+		}
+		codec.marshaller = marshaller
+	}
+	return codec.marshaller, nil
+}
+
+// SetFormatResources sets component format resources provider used by component marshaller factories
+func (c *Content) SetFormatResources(resources FormatResourceFunc) {
+	if c.formats == nil {
+		c.formats = newFormatCodecs()
+	}
+	c.formats.mux.Lock()
+	defer c.formats.mux.Unlock()
+	c.formats.resources = resources
+}
+
+// ComponentMarshaller returns registered format component marshaller, false if format does not define one
+func (c *Content) ComponentMarshaller(name string) (RequestMarshaller, bool, error) {
+	format, ok := formats.Lookup(name)
+	if !ok || format.ComponentMarshaller == nil {
+		return nil, false, nil
+	}
+	if c.formats == nil {
+		return nil, true, fmt.Errorf("content marshaller was not initialized")
+	}
+	c.formats.mux.Lock()
+	defer c.formats.mux.Unlock()
+	codec := c.formats.codec(format.Name)
+	if codec.componentMarshaller != nil {
+		return codec.componentMarshaller, true, nil
+	}
+	component := &FormatComponent{OutputType: c.outputType, JSON: c.jsonMarshal}
+	if c.formats.resources != nil {
+		resource, err := c.formats.resources(strings.ToLower(format.Name))
+		if err != nil {
+			return nil, true, err
+		}
+		component.Resource = resource
+	}
+	marshaller, err := format.ComponentMarshaller(component)
+	if err != nil {
+		return nil, true, fmt.Errorf("failed to create %s marshaller: %w", format.Name, err)
+	}
+	codec.componentMarshaller = marshaller
+	return marshaller, true, nil
+}
+
+func (c *Content) jsonMarshal(src interface{}) ([]byte, error) {
+	return c.Marshaller.JSON.RuntimeMarshallerEngine().Marshal(src)
+}
+
+// FormatUnmarshaller returns registered format unmarshaller for request content type, nil if content type is not registered
+func (c *Content) FormatUnmarshaller(contentType string) (Unmarshaller, error) {
+	format, ok := formats.LookupContentType(contentType)
+	if !ok {
+		return nil, nil
+	}
+	if format.Unmarshaller == nil {
+		return nil, fmt.Errorf("format %s does not support unmarshalling", format.Name)
+	}
+	if c.formats == nil {
+		return nil, fmt.Errorf("content marshaller was not initialized")
+	}
+	c.formats.mux.Lock()
+	defer c.formats.mux.Unlock()
+	codec := c.formats.codec(format.Name)
+	if codec.unmarshaller == nil {
+		unmarshaller, err := format.Unmarshaller(c.inputType)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create %s unmarshaller: %w", format.Name, err)
+		}
+		codec.unmarshaller = unmarshaller
+	}
+	return codec.unmarshaller, nil
+}
+
+func (c *formatCodecs) codec(name string) *formatCodec {
+	ret, ok := c.codecs[name]
+	if !ok {
+		ret = &formatCodec{}
+		c.codecs[name] = ret
+	}
+	return ret
+}
+
+func newFormatCodecs() *formatCodecs {
+	return &formatCodecs{codecs: map[string]*formatCodec{}}
+}
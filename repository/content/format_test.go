@@ -0,0 +1,83 @@
+package content
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testFormatCodec struct {
+	rType reflect.Type
+}
+
+func (c *testFormatCodec) Marshal(src interface{}) ([]byte, error) {
+	data, err := json.Marshal(src)
+	return []byte(fmt.Sprintf("%v:%s", c.rType.Name(), data)), err
+}
+
+func (c *testFormatCodec) Unmarshal(data []byte, dest interface{}) error {
+	return json.Unmarshal(data, dest)
+}
+
+func TestFormatRegistry_Register(t *testing.T) {
+	registry := NewFormatRegistry()
+	factory := func(rType reflect.Type) (Marshaller, error) { return &testFormatCodec{rType: rType}, nil }
+	assert.NotNil(t, registry.Register(&Format{Name: JSONFormat, ContentTypes: []string{"application/x-json"}, Marshaller: factory}))
+	assert.NotNil(t, registry.Register(&Format{Name: "yaml", Marshaller: factory}))
+	assert.NotNil(t, registry.Register(&Format{Name: "yaml", ContentTypes: []string{"application/yaml"}}))
+	require.Nil(t, registry.Register(&Format{Name: "YAML", ContentTypes: []string{"application/yaml", "text/yaml"}, Marshaller: factory}))
+
+	format, ok := registry.Lookup("yaml")
+	require.True(t, ok)
+	assert.Equal(t, "application/yaml", format.ContentType())
+	_, ok = registry.LookupContentType("text/yaml; charset=utf-8")
+	assert.True(t, ok)
+	_, ok = registry.LookupContentType("application/json")
+	assert.False(t, ok)
+	assert.Equal(t, []string{"yaml"}, registry.Names())
+}
+
+type testFormatRecord struct {
+	ID   int
+	Name string
+}
+
+func TestContent_FormatMarshaller(t *testing.T) {
+	require.Nil(t, RegisterFormat(&Format{
+		Name:         "testfmt",
+		ContentTypes: []string{"application/x-testfmt"},
+		Marshaller: func(rType reflect.Type) (Marshaller, error) {
+			return &testFormatCodec{rType: rType}, nil
+		},
+		Unmarshaller: func(rType reflect.Type) (Unmarshaller, error) {
+			return &testFormatCodec{rType: rType}, nil
+		},
+	}))
+	assert.True(t, IsFormat("testfmt"))
+	assert.True(t, IsFormat(CSVFormat))
+	assert.False(t, IsFormat("cbor"))
+
+	aContent := &Content{formats: newFormatCodecs(), outputType: reflect.TypeOf(testFormatRecord{})}
+	marshaller, err := aContent.FormatMarshaller("testfmt")
+	require.Nil(t, err)
+	data, err := marshaller.Marshal(&testFormatRecord{ID: 1, Name: "abc"})
+	require.Nil(t, err)
+	assert.Equal(t, `testFormatRecord:{"ID":1,"Name":"abc"}`, string(data))
+
+	_, err = aContent.FormatMarshaller("cbor")
+	assert.NotNil(t, err)
+
+	unmarshaller, err := aContent.FormatUnmarshaller("application/x-testfmt")
+	require.Nil(t, err)
+	record := &testFormatRecord{}
+	require.Nil(t, unmarshaller.Unmarshal([]byte(`{"ID":2}`), record))
+	assert.Equal(t, 2, record.ID)
+
+	unmarshaller, err = aContent.FormatUnmarshaller("application/json")
+	assert.Nil(t, err)
+	assert.Nil(t, unmarshaller)
+}
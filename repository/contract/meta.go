@@ -13,13 +13,90 @@ type Meta struct {
 }
 
 type ModelContextProtocol struct {
-	MCPTool             bool `json:",omitempty" yaml:"MCPTool"`
-	MCPResource         bool `json:",omitempty" yaml:"MCPResource"`
-	MCPTemplateResource bool `json:",omitempty" yaml:"MCPTemplateResource"`
+	MCPTool             bool         `json:",omitempty" yaml:"MCPTool"`
+	MCPResource         bool         `json:",omitempty" yaml:"MCPResource"`
+	MCPTemplateResource bool         `json:",omitempty" yaml:"MCPTemplateResource"`
+	MCPPrompts          []*MCPPrompt `json:",omitempty" yaml:"MCPPrompts"`
+}
+
+// MCPPrompt represents MCP prompt template bound to a path component
+type MCPPrompt struct {
+	Name        string               `json:",omitempty" yaml:"Name"`
+	Description string               `json:",omitempty" yaml:"Description"`
+	Template    string               `json:",omitempty" yaml:"Template"` // prompt text with {argument} placeholders
+	Arguments   []*MCPPromptArgument `json:",omitempty" yaml:"Arguments"`
+	EmbedResult bool                 `json:",omitempty" yaml:"EmbedResult"` // when set, component output is embedded in prompt messages
+}
+
+// MCPPromptArgument represents MCP prompt argument
+type MCPPromptArgument struct {
+	Name        string         `json:",omitempty" yaml:"Name"`
+	Description string         `json:",omitempty" yaml:"Description"`
+	Required    bool           `json:",omitempty" yaml:"Required"`
+	Completion  *MCPCompletion `json:",omitempty" yaml:"Completion"`
+}
+
+// MCPCompletion defines view column supplying argument value suggestions
+type MCPCompletion struct {
+	URI       string `json:",omitempty" yaml:"URI"`       // optional reader route, defaults to prompt path
+	Column    string `json:",omitempty" yaml:"Column"`    // output column, defaults to argument name
+	Parameter string `json:",omitempty" yaml:"Parameter"` // route parameter receiving typed prefix, i.e. with LIKE predicate
+	Limit     int    `json:",omitempty" yaml:"Limit"`     // max suggestions, defaults to 100
 }
 
 func (m *ModelContextProtocol) HasMCPIntegration() bool {
-	return m != nil && (m.MCPTool || m.MCPResource || m.MCPTemplateResource)
+	return m != nil && (m.MCPTool || m.MCPResource || m.MCPTemplateResource || len(m.MCPPrompts) > 0)
+}
+
+// PromptArguments returns declared arguments, or arguments inferred from template placeholders
+func (p *MCPPrompt) PromptArguments() []*MCPPromptArgument {
+	if len(p.Arguments) > 0 {
+		return p.Arguments
+	}
+	var result []*MCPPromptArgument
+	var unique = map[string]bool{}
+	for _, placeholder := range templatePlaceholders(p.Template) {
+		if unique[placeholder.name] {
+			continue
+		}
+		unique[placeholder.name] = true
+		result = append(result, &MCPPromptArgument{Name: placeholder.name, Required: true})
+	}
+	return result
+}
+
+// Render replaces {argument} placeholders with argument values
+func (p *MCPPrompt) Render(arguments map[string]string) string {
+	result := p.Template
+	for _, placeholder := range templatePlaceholders(p.Template) {
+		result = strings.ReplaceAll(result, placeholder.token, arguments[placeholder.name])
+	}
+	return result
+}
+
+// placeholder represents raw template token, i.e. "{ id }", and its argument name
+type placeholder struct {
+	token string
+	name  string
+}
+
+func templatePlaceholders(template string) []*placeholder {
+	var result []*placeholder
+	for {
+		start := strings.Index(template, "{")
+		if start == -1 {
+			return result
+		}
+		end := strings.Index(template[start:], "}")
+		if end == -1 {
+			return result
+		}
+		token := template[start : start+end+1]
+		if name := strings.TrimSpace(token[1 : len(token)-1]); name != "" {
+			result = append(result, &placeholder{token: token, name: name})
+		}
+		template = template[start+end+1:]
+	}
 }
 
 func (m *Meta) Build(name string, from string, aPath *Path) *Meta {
@@ -51,6 +51,9 @@ func (o *Output) Init(ctx context.Context, aView *view.View, inputType *state.Ty
 	if err = o.ensureCaseFormat(); err != nil {
 		return err
 	}
+	if o.DataFormat != "" && !content.IsFormat(o.DataFormat) {
+		return fmt.Errorf("unsupported output data format: %v", o.DataFormat)
+	}
 	o.initExclude()
 	o.addExcludePrefixesIfNeeded()
 	o.initDebugStyleIfNeeded()
@@ -93,6 +96,9 @@ func (o *Output) ContentType(format string) string {
 	case content.XMLFormat:
 		return content.XMLContentType
 	default:
+		if format, ok := content.Formats().Lookup(format); ok {
+			return format.ContentType()
+		}
 		return content.JSONContentType
 	}
 }
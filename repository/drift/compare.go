@@ -0,0 +1,119 @@
+package drift
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// Added represents column present in database, but not declared by view
+	Added = ChangeKind("added")
+	// Removed represents column declared by view, but missing in database
+	Removed = ChangeKind("removed")
+	// TypeChanged represents column with incompatible declared and database type
+	TypeChanged = ChangeKind("typeChanged")
+)
+
+type (
+	// ChangeKind represents drift change kind
+	ChangeKind string
+
+	// Change represents view column drift
+	Change struct {
+		View     string
+		Column   string
+		Kind     ChangeKind
+		Declared string `json:",omitempty"`
+		Actual   string `json:",omitempty"`
+	}
+
+	// Column represents compared column
+	Column struct {
+		Name      string
+		DataType  string
+		Type      reflect.Type
+		Computed  bool //expression column, it is not compared
+		Converted bool //codec column, type is not compared
+	}
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// Compare compares declared view columns with columns discovered in database
+func Compare(viewName string, declared, actual []*Column) []*Change {
+	actualByName := index(actual)
+	declaredByName := index(declared)
+	var ret []*Change
+	for _, column := range declared {
+		if column.Computed {
+			continue
+		}
+		match, ok := actualByName[strings.ToLower(column.Name)]
+		if !ok {
+			ret = append(ret, &Change{View: viewName, Column: column.Name, Kind: Removed, Declared: column.describe()})
+			continue
+		}
+		if column.Converted || column.Type == nil || match.Type == nil {
+			continue
+		}
+		if category(column.Type) != category(match.Type) {
+			ret = append(ret, &Change{View: viewName, Column: column.Name, Kind: TypeChanged, Declared: column.describe(), Actual: match.describe()})
+		}
+	}
+	for _, column := range actual {
+		if _, ok := declaredByName[strings.ToLower(column.Name)]; !ok {
+			ret = append(ret, &Change{View: viewName, Column: column.Name, Kind: Added, Actual: column.describe()})
+		}
+	}
+	sort.SliceStable(ret, func(i, j int) bool {
+		return ret[i].Column < ret[j].Column
+	})
+	return ret
+}
+
+// Breaking returns true for removed and type changed columns, added columns do not break declared view
+func (c *Change) Breaking() bool {
+	return c.Kind == Removed || c.Kind == TypeChanged
+}
+
+func index(columns []*Column) map[string]*Column {
+	ret := make(map[string]*Column, len(columns))
+	for _, column := range columns {
+		ret[strings.ToLower(column.Name)] = column
+	}
+	return ret
+}
+
+func (c *Column) describe() string {
+	switch {
+	case c.Type == nil:
+		return c.DataType
+	case c.DataType == "":
+		return c.Type.String()
+	}
+	return c.DataType + " (" + c.Type.String() + ")"
+}
+
+// category returns Go type category, compatible types, i.e. int32 and int64, share category
+func category(rType reflect.Type) string {
+	for rType.Kind() == reflect.Ptr {
+		rType = rType.Elem()
+	}
+	if rType == timeType {
+		return "time"
+	}
+	switch rType.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "float"
+	case reflect.Slice:
+		if rType.Elem().Kind() == reflect.Uint8 {
+			return "bytes"
+		}
+	}
+	return rType.Kind().String()
+}
@@ -0,0 +1,36 @@
+package drift
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompare(t *testing.T) {
+	declared := []*Column{
+		{Name: "ID", DataType: "INT", Type: reflect.TypeOf(0)},
+		{Name: "NAME", DataType: "VARCHAR", Type: reflect.TypeOf("")},
+		{Name: "PRICE", DataType: "DECIMAL", Type: reflect.TypeOf(0.0)},
+		{Name: "CREATED", DataType: "TIMESTAMP", Type: reflect.TypeOf(&time.Time{})},
+		{Name: "TOTAL", Computed: true},
+		{Name: "SETTINGS", DataType: "TEXT", Type: reflect.TypeOf(map[string]interface{}{}), Converted: true},
+		{Name: "LEGACY", DataType: "VARCHAR", Type: reflect.TypeOf("")},
+	}
+	actual := []*Column{
+		{Name: "id", DataType: "BIGINT", Type: reflect.TypeOf(int64(0))},
+		{Name: "NAME", DataType: "VARCHAR", Type: reflect.TypeOf(new(string))},
+		{Name: "PRICE", DataType: "VARCHAR", Type: reflect.TypeOf("")},
+		{Name: "CREATED", DataType: "TIMESTAMP", Type: reflect.TypeOf(time.Time{})},
+		{Name: "SETTINGS", DataType: "TEXT", Type: reflect.TypeOf("")},
+		{Name: "STATUS", DataType: "INT", Type: reflect.TypeOf(0)},
+	}
+	changes := Compare("product", declared, actual)
+	assert.Equal(t, []*Change{
+		{View: "product", Column: "LEGACY", Kind: Removed, Declared: "VARCHAR (string)"},
+		{View: "product", Column: "PRICE", Kind: TypeChanged, Declared: "DECIMAL (float64)", Actual: "VARCHAR (string)"},
+		{View: "product", Column: "STATUS", Kind: Added, Actual: "INT (int)"},
+	}, changes)
+	assert.Empty(t, Compare("product", actual, actual))
+}
@@ -0,0 +1,137 @@
+package drift
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/viant/datly/repository"
+	"github.com/viant/datly/view"
+)
+
+// Report represents route schema drift
+type Report struct {
+	Method  string
+	URI     string
+	Changes []*Change `json:",omitempty"`
+	Error   string    `json:",omitempty"`
+}
+
+// HasDrift returns true if route columns drifted
+func (r *Report) HasDrift() bool {
+	return len(r.Changes) > 0
+}
+
+// Breaking returns true if any route column was removed or changed type
+func (r *Report) Breaking() bool {
+	for _, change := range r.Changes {
+		if change.Breaking() {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns report summary
+func (r *Report) String() string {
+	builder := strings.Builder{}
+	builder.WriteString(r.Method + " " + r.URI)
+	if r.Error != "" {
+		builder.WriteString(": " + r.Error)
+	}
+	for _, change := range r.Changes {
+		builder.WriteString(fmt.Sprintf("\n\t%v.%v %v", change.View, change.Column, change.Kind))
+		if change.Declared != "" {
+			builder.WriteString(" declared: " + change.Declared)
+		}
+		if change.Actual != "" {
+			builder.WriteString(" actual: " + change.Actual)
+		}
+	}
+	return builder.String()
+}
+
+// DetectComponent detects drift of component view and its relations
+func DetectComponent(ctx context.Context, component *repository.Component) *Report {
+	ret := &Report{Method: component.Method, URI: component.URI}
+	changes, err := Detect(ctx, component.View)
+	ret.Changes = changes
+	if err != nil {
+		ret.Error = err.Error()
+	}
+	return ret
+}
+
+// Detect re-discovers columns of the view and its relations and compares them with declared columns
+func Detect(ctx context.Context, aView *view.View) ([]*Change, error) {
+	var ret []*Change
+	visited := map[*view.View]bool{}
+	var detect func(aView *view.View) error
+	detect = func(aView *view.View) error {
+		if aView == nil || visited[aView] {
+			return nil
+		}
+		visited[aView] = true
+		if isDiscoverable(aView) {
+			actual, err := aView.DiscoverColumns(ctx)
+			if err != nil {
+				return fmt.Errorf("view %v: %w", aView.Name, err)
+			}
+			ret = append(ret, Compare(aView.Name, columnsOf(aView.Columns), exclude(columnsOf(actual), aView.Exclude))...)
+		}
+		for _, relation := range aView.With {
+			if relation.Of == nil {
+				continue
+			}
+			if err := detect(&relation.Of.View); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return ret, detect(aView)
+}
+
+// isDiscoverable returns true for SQL query views
+func isDiscoverable(aView *view.View) bool {
+	if aView.HTTP != nil || aView.Connector == nil {
+		return false
+	}
+	return aView.Mode == view.ModeQuery || aView.Mode == view.ModeUnspecified
+}
+
+// exclude removes columns excluded by view
+func exclude(columns []*Column, excluded []string) []*Column {
+	if len(excluded) == 0 {
+		return columns
+	}
+	names := make(map[string]bool, len(excluded))
+	for _, name := range excluded {
+		names[strings.ToLower(name)] = true
+	}
+	ret := make([]*Column, 0, len(columns))
+	for _, column := range columns {
+		if !names[strings.ToLower(column.Name)] {
+			ret = append(ret, column)
+		}
+	}
+	return ret
+}
+
+func columnsOf(columns view.Columns) []*Column {
+	ret := make([]*Column, 0, len(columns))
+	for _, column := range columns {
+		item := &Column{
+			Name:      column.Name,
+			DataType:  column.DataType,
+			Type:      column.ColumnType(),
+			Computed:  column.Expression != "" || column.Aggregate,
+			Converted: column.Codec != nil,
+		}
+		if field := column.Field(); field != nil {
+			item.Type = field.Type
+		}
+		ret = append(ret, item)
+	}
+	return ret
+}
@@ -0,0 +1,24 @@
+package drift
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReport_Breaking(t *testing.T) {
+	added := &Report{Changes: []*Change{{View: "product", Column: "STATUS", Kind: Added}}}
+	assert.True(t, added.HasDrift())
+	assert.False(t, added.Breaking())
+	removed := &Report{Changes: []*Change{{View: "product", Column: "STATUS", Kind: Added}, {View: "product", Column: "LEGACY", Kind: Removed}}}
+	assert.True(t, removed.Breaking())
+	failed := &Report{Error: "view product: connection refused"}
+	assert.False(t, failed.HasDrift())
+	assert.False(t, failed.Breaking())
+}
+
+func TestExclude(t *testing.T) {
+	columns := []*Column{{Name: "ID"}, {Name: "PASSWORD"}, {Name: "NAME"}}
+	assert.Equal(t, []*Column{{Name: "ID"}, {Name: "NAME"}}, exclude(columns, []string{"password"}))
+	assert.Equal(t, columns, exclude(columns, nil))
+}
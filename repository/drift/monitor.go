@@ -0,0 +1,137 @@
+package drift
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/viant/datly/repository"
+	"github.com/viant/xdatly/handler/response"
+)
+
+type (
+	// Config represents schema drift check settings
+	Config struct {
+		Interval  int  `json:",omitempty" yaml:",omitempty"` //seconds between periodic checks, startup check only when zero
+		Block     bool `json:",omitempty" yaml:",omitempty"` //fails startup when any route column was removed or changed type
+		Unhealthy bool `json:",omitempty" yaml:",omitempty"` //routes with removed or type changed columns respond with service unavailable status
+	}
+
+	// Monitor checks repository routes schema drift
+	Monitor struct {
+		config  *Config
+		mux     sync.RWMutex
+		reports map[string]*Report
+		cancel  context.CancelFunc
+	}
+)
+
+// Check detects schema drift of all repository routes, it returns drifted and failed routes reports
+func (m *Monitor) Check(ctx context.Context, service *repository.Service) []*Report {
+	var ret []*Report
+	reports := map[string]*Report{}
+	if container := service.Container(); container != nil {
+		for _, item := range container.Items {
+			for _, aPath := range item.Paths {
+				report := &Report{Method: aPath.Method, URI: aPath.URI}
+				provider, err := service.Registry().LookupProvider(ctx, &aPath.Path)
+				if err == nil {
+					var component *repository.Component
+					if component, err = provider.Component(ctx); err == nil {
+						report = DetectComponent(ctx, component)
+					}
+				}
+				if err != nil {
+					report.Error = err.Error()
+				}
+				if report.HasDrift() || report.Error != "" {
+					reports[key(report.Method, report.URI)] = report
+					ret = append(ret, report)
+				}
+			}
+		}
+	}
+	m.mux.Lock()
+	m.reports = reports
+	m.mux.Unlock()
+	return ret
+}
+
+// Report returns drift report for route or nil
+func (m *Monitor) Report(method, URI string) *Report {
+	if m == nil {
+		return nil
+	}
+	m.mux.RLock()
+	defer m.mux.RUnlock()
+	return m.reports[key(method, URI)]
+}
+
+// Err returns service unavailable error for route with breaking drift when unhealthy mode is enabled
+func (m *Monitor) Err(method, URI string) error {
+	if m == nil || !m.config.Unhealthy {
+		return nil
+	}
+	report := m.Report(method, URI)
+	if report == nil || !report.Breaking() {
+		return nil
+	}
+	return response.NewError(http.StatusServiceUnavailable, "route schema drifted: "+report.String())
+}
+
+// Start starts periodic checks, checks stop on Close
+func (m *Monitor) Start(ctx context.Context, service *repository.Service) {
+	if m.config.Interval <= 0 {
+		return
+	}
+	ctx, m.cancel = context.WithCancel(ctx)
+	go func() {
+		ticker := time.NewTicker(time.Duration(m.config.Interval) * time.Second)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				Log(m.Check(ctx, service))
+			}
+		}
+	}()
+}
+
+// Log prints drift and failed checks reports, it returns number of reports with breaking drift
+func Log(reports []*Report) int {
+	breaking := 0
+	for _, report := range reports {
+		if report.Breaking() {
+			breaking++
+		}
+		if report.Error != "" {
+			fmt.Printf("[WARN] schema drift check failed %v\n", report)
+			continue
+		}
+		fmt.Printf("[WARN] schema drift %v\n", report)
+	}
+	return breaking
+}
+
+// Close stops periodic checks
+func (m *Monitor) Close() {
+	if m.cancel != nil {
+		m.cancel()
+	}
+}
+
+func key(method, URI string) string {
+	return method + ":" + URI
+}
+
+// New creates drift monitor
+func New(config *Config) *Monitor {
+	if config == nil {
+		config = &Config{}
+	}
+	return &Monitor{config: config, reports: map[string]*Report{}}
+}
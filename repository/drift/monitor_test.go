@@ -0,0 +1,19 @@
+package drift
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMonitor_Err(t *testing.T) {
+	monitor := New(&Config{Unhealthy: true})
+	monitor.reports = map[string]*Report{
+		key("GET", "/v1/api/added"):   {Method: "GET", URI: "/v1/api/added", Changes: []*Change{{View: "product", Column: "STATUS", Kind: Added}}},
+		key("GET", "/v1/api/failed"):  {Method: "GET", URI: "/v1/api/failed", Error: "connection refused"},
+		key("GET", "/v1/api/removed"): {Method: "GET", URI: "/v1/api/removed", Changes: []*Change{{View: "product", Column: "LEGACY", Kind: Removed}}},
+	}
+	assert.Nil(t, monitor.Err("GET", "/v1/api/added"))
+	assert.Nil(t, monitor.Err("GET", "/v1/api/failed"))
+	assert.NotNil(t, monitor.Err("GET", "/v1/api/removed"))
+}
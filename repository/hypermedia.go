@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/viant/datly/gateway/router/marshal/hypermedia"
+	"github.com/viant/datly/view"
+	"github.com/viant/datly/view/state"
+	"github.com/viant/sqlx/io"
+	hstate "github.com/viant/xdatly/handler/state"
+)
+
+// HypermediaQuerySelectors returns view query selectors for JSON:API fields[type], include, sort and page[...] parameters
+func (c *Component) HypermediaQuerySelectors(request *http.Request) (hstate.QuerySelectors, error) {
+	if (c.hypermedia == nil && c.hypermediaErr == nil) || request == nil || request.URL == nil {
+		return nil, nil
+	}
+	query := request.URL.Query()
+	if strings.ToLower(c.Output.Format(query)) != hypermedia.JSONAPIFormat {
+		return nil, nil
+	}
+	if c.hypermediaErr != nil {
+		return nil, c.hypermediaErr
+	}
+	return hypermedia.QuerySelectors(c.hypermedia, query)
+}
+
+// formatResource returns component resource for hypermedia formats
+func (c *Component) formatResource(format string) (interface{}, error) {
+	if !hypermedia.IsFormat(format) {
+		return nil, nil
+	}
+	if c.hypermediaErr != nil {
+		return nil, c.hypermediaErr
+	}
+	return c.hypermedia, nil
+}
+
+// newHypermediaResource creates view resource, resource is identified by view primary key, id column or relation columns
+func newHypermediaResource(aView *view.View, keyColumns ...string) (*hypermedia.Resource, error) {
+	ret := &hypermedia.Resource{Type: aView.Name}
+	if aView.Selector != nil {
+		ret.Limit = aView.Selector.Limit
+	}
+	holders := map[string]bool{}
+	for _, relation := range aView.With {
+		holders[relation.Holder] = true
+		for _, link := range relation.On {
+			keyColumns = append(keyColumns, link.Column)
+		}
+	}
+	primaryKey, idKey := "", ""
+	byColumn := map[string]string{}
+	for _, column := range aView.Columns {
+		fieldName := column.FieldName()
+		field := column.Field()
+		if field != nil {
+			fieldName = field.Name
+		}
+		if fieldName == "" || holders[fieldName] {
+			continue
+		}
+		ret.Attributes = append(ret.Attributes, &hypermedia.Attribute{Name: column.Name, Field: fieldName})
+		byColumn[strings.ToLower(column.Name)] = fieldName
+		if primaryKey == "" && isPrimaryKey(column, field) {
+			primaryKey = fieldName
+		}
+		if idKey == "" && strings.EqualFold(column.Name, "id") {
+			idKey = fieldName
+		}
+	}
+	ret.Key = primaryKey
+	if ret.Key == "" {
+		ret.Key = idKey
+	}
+	for _, column := range keyColumns {
+		if ret.Key != "" {
+			break
+		}
+		ret.Key = byColumn[strings.ToLower(column)]
+	}
+	if ret.Key == "" {
+		return nil, fmt.Errorf("failed to identify %v hypermedia resource: view has no primary key nor relation columns", aView.Name)
+	}
+	for _, relation := range aView.With {
+		if relation.Of == nil {
+			continue
+		}
+		var relationKeys []string
+		if relation.Cardinality != state.Many {
+			for _, link := range relation.Of.On {
+				relationKeys = append(relationKeys, link.Column)
+			}
+		}
+		resource, err := newHypermediaResource(&relation.Of.View, relationKeys...)
+		if err != nil {
+			return nil, err
+		}
+		ret.Relations = append(ret.Relations, &hypermedia.Relation{
+			Name:     relation.Holder,
+			Many:     relation.Cardinality == state.Many,
+			Resource: resource,
+		})
+	}
+	return ret, nil
+}
+
+func isPrimaryKey(column *view.Column, field *reflect.StructField) bool {
+	if tag := io.ParseTag(reflect.StructTag(strings.TrimSpace(column.Tag))); tag != nil && tag.PrimaryKey {
+		return true
+	}
+	if field == nil {
+		return false
+	}
+	tag := io.ParseTag(field.Tag)
+	return tag != nil && tag.PrimaryKey
+}
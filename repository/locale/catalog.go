@@ -0,0 +1,102 @@
+package locale
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/viant/afs"
+	"gopkg.in/yaml.v3"
+)
+
+// Catalog represents message catalogs indexed by locale, each catalog maps message key to message template
+type Catalog struct {
+	mux      sync.RWMutex
+	messages map[string]map[string]string
+}
+
+var messages = NewCatalog()
+
+// Messages returns process wide message catalog
+func Messages() *Catalog {
+	return messages
+}
+
+// NewCatalog creates message catalog
+func NewCatalog() *Catalog {
+	return &Catalog{messages: map[string]map[string]string{}}
+}
+
+// Put adds locale messages, existing keys are replaced
+func (c *Catalog) Put(locale string, entries map[string]string) {
+	locale = normalize(locale)
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	catalog, ok := c.messages[locale]
+	if !ok {
+		catalog = map[string]string{}
+		c.messages[locale] = catalog
+	}
+	for key, template := range entries {
+		catalog[key] = template
+	}
+}
+
+// Lookup returns message template for locale and key
+func (c *Catalog) Lookup(locale, key string) (string, bool) {
+	if c == nil {
+		return "", false
+	}
+	c.mux.RLock()
+	defer c.mux.RUnlock()
+	ret, ok := c.messages[normalize(locale)][key]
+	return ret, ok
+}
+
+// Locales returns sorted catalog locales
+func (c *Catalog) Locales() []string {
+	c.mux.RLock()
+	defer c.mux.RUnlock()
+	var ret = make([]string, 0, len(c.messages))
+	for locale := range c.messages {
+		ret = append(ret, locale)
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+// Load loads <locale>.yaml, <locale>.yml or <locale>.json catalogs from URL folder
+func (c *Catalog) Load(ctx context.Context, fs afs.Service, URL string) error {
+	objects, err := fs.List(ctx, URL)
+	if err != nil {
+		return fmt.Errorf("failed to list message catalogs %v: %w", URL, err)
+	}
+	for _, object := range objects {
+		if object.IsDir() {
+			continue
+		}
+		ext := path.Ext(object.Name())
+		switch strings.ToLower(ext) {
+		case ".yaml", ".yml", ".json":
+		default:
+			continue
+		}
+		data, err := fs.Download(ctx, object)
+		if err != nil {
+			return fmt.Errorf("failed to download message catalog %v: %w", object.URL(), err)
+		}
+		entries := map[string]string{}
+		if err = yaml.Unmarshal(data, &entries); err != nil {
+			return fmt.Errorf("invalid message catalog %v: %w", object.URL(), err)
+		}
+		c.Put(strings.TrimSuffix(object.Name(), ext), entries)
+	}
+	return nil
+}
+
+func normalize(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+}
@@ -0,0 +1,282 @@
+package locale
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/viant/datly/utils/httputils"
+	"github.com/viant/xdatly/handler/response"
+	"github.com/viant/xdatly/handler/validator"
+)
+
+const (
+	// LocaleQuery selects locale, it takes precedence over Accept-Language header
+	LocaleQuery          = "_locale"
+	AcceptLanguageHeader = "Accept-Language"
+)
+
+type (
+	// Localizer translates violation and error messages for caller locales,
+	// violation Check is used as stable message key, <Check>.<Field> key overrides message for a field
+	Localizer struct {
+		locales  []string
+		catalogs []*Catalog
+	}
+
+	localizerKey string
+)
+
+const localizerContextKey = localizerKey("localizer")
+
+// NewLocalizer creates localizer, catalogs are consulted in order, so component catalog should precede process wide one
+func NewLocalizer(locales []string, catalogs ...*Catalog) *Localizer {
+	ret := &Localizer{}
+	for _, locale := range locales {
+		ret.addLocale(locale)
+	}
+	for _, catalog := range catalogs {
+		if catalog != nil {
+			ret.catalogs = append(ret.catalogs, catalog)
+		}
+	}
+	return ret
+}
+
+// WithLocalizer returns context with localizer
+func WithLocalizer(ctx context.Context, localizer *Localizer) context.Context {
+	return context.WithValue(ctx, localizerContextKey, localizer)
+}
+
+// LocalizerOf returns context localizer or nil
+func LocalizerOf(ctx context.Context) *Localizer {
+	ret, _ := ctx.Value(localizerContextKey).(*Localizer)
+	return ret
+}
+
+// RequestLocales returns caller locales from _locale query parameter or Accept-Language header ordered by preference
+func RequestLocales(request *http.Request) []string {
+	if request == nil {
+		return nil
+	}
+	if request.URL != nil {
+		if locale := request.URL.Query().Get(LocaleQuery); locale != "" {
+			return []string{locale}
+		}
+	}
+	return ParseAcceptLanguage(request.Header.Get(AcceptLanguageHeader))
+}
+
+// ParseAcceptLanguage returns Accept-Language locales ordered by quality
+func ParseAcceptLanguage(header string) []string {
+	type weighted struct {
+		locale  string
+		quality float64
+	}
+	var items []weighted
+	for _, part := range strings.Split(header, ",") {
+		locale, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if locale = strings.TrimSpace(locale); locale == "" || locale == "*" {
+			continue
+		}
+		quality := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if q, err := strconv.ParseFloat(value, 64); err == nil {
+				quality = q
+			}
+		}
+		if quality > 0 {
+			items = append(items, weighted{locale: locale, quality: quality})
+		}
+	}
+	sort.SliceStable(items, func(i, j int) bool { return items[i].quality > items[j].quality })
+	var ret = make([]string, 0, len(items))
+	for _, item := range items {
+		ret = append(ret, item.locale)
+	}
+	return ret
+}
+
+// Locales returns locale lookup candidates, regional locale is followed by its language
+func (l *Localizer) Locales() []string {
+	if l == nil {
+		return nil
+	}
+	return l.locales
+}
+
+// Lookup returns message template for the first matching locale
+func (l *Localizer) Lookup(keys ...string) (string, bool) {
+	if l == nil {
+		return "", false
+	}
+	for _, locale := range l.locales {
+		for _, catalog := range l.catalogs {
+			for _, key := range keys {
+				if key == "" {
+					continue
+				}
+				if template, ok := catalog.Lookup(locale, key); ok {
+					return template, true
+				}
+			}
+		}
+	}
+	return "", false
+}
+
+// Message translates message using message text as key
+func (l *Localizer) Message(message string) string {
+	if template, ok := l.Lookup(message); ok {
+		return template
+	}
+	return message
+}
+
+// Violation translates violation message, placeholders: ${Field}, ${Value}, ${Check}, ${Constraint}, ${Location}, ${Message}
+func (l *Localizer) Violation(violation *validator.Violation) {
+	if l == nil || violation == nil || violation.Check == "" {
+		return
+	}
+	var keys []string
+	if violation.Field != "" {
+		keys = append(keys, violation.Check+"."+violation.Field)
+	}
+	keys = append(keys, violation.Check)
+	template, ok := l.Lookup(keys...)
+	if !ok {
+		return
+	}
+	violation.Message = Expand(template, violation)
+}
+
+// Localize translates messages of violations, errors and status found in value or its top level fields
+func (l *Localizer) Localize(value interface{}) {
+	if l == nil || len(l.locales) == 0 || value == nil {
+		return
+	}
+	if l.localize(value) {
+		return
+	}
+	rValue := reflect.ValueOf(value)
+	if rValue.Kind() != reflect.Ptr || rValue.IsNil() || rValue.Elem().Kind() != reflect.Struct {
+		return
+	}
+	rValue = rValue.Elem()
+	for i := 0; i < rValue.NumField(); i++ {
+		if rValue.Type().Field(i).PkgPath != "" {
+			continue
+		}
+		fieldValue := rValue.Field(i)
+		switch fieldValue.Kind() {
+		case reflect.Ptr, reflect.Slice, reflect.Interface:
+			if !fieldValue.IsNil() {
+				l.localize(fieldValue.Interface())
+			}
+		case reflect.Struct:
+			l.localize(fieldValue.Addr().Interface())
+		}
+	}
+}
+
+func (l *Localizer) localize(value interface{}) bool {
+	switch actual := value.(type) {
+	case *validator.Violation:
+		l.Violation(actual)
+	case validator.Violations:
+		for _, violation := range actual {
+			l.Violation(violation)
+		}
+	case []*validator.Violation:
+		l.localize(validator.Violations(actual))
+	case *validator.Validation:
+		if actual != nil {
+			l.localize(actual.Violations)
+		}
+	case *response.Status:
+		if actual != nil {
+			actual.Message = l.Message(actual.Message)
+			if actual.Errors != nil {
+				l.localize(actual.Errors)
+			}
+		}
+	case *response.Error:
+		if actual != nil {
+			actual.Message = l.Message(actual.Message)
+			if actual.Object != nil {
+				l.localize(actual.Object)
+			}
+		}
+	case []*response.Error:
+		for _, anError := range actual {
+			l.localize(anError)
+		}
+	case *response.Errors:
+		if actual != nil {
+			actual.Message = l.Message(actual.Message)
+			l.localize(actual.Errors)
+		}
+	default:
+		return false
+	}
+	return true
+}
+
+// Expand interpolates violation placeholders
+func Expand(template string, violation *validator.Violation) string {
+	value := ""
+	if violation.Value != nil {
+		value = fmt.Sprintf("%v", violation.Value)
+	}
+	replacer := strings.NewReplacer(
+		"${Field}", violation.Field,
+		"${Value}", value,
+		"${Check}", violation.Check,
+		"${Constraint}", Constraint(violation),
+		"${Location}", violation.Location,
+		"${Message}", violation.Message,
+	)
+	return replacer.Replace(template)
+}
+
+// Constraint returns violated constraint name, parameter name for parameter errors, check name without arguments otherwise
+func Constraint(violation *validator.Violation) string {
+	if violation.Check == httputils.ParameterCheck {
+		name := violation.Location
+		if index := strings.LastIndex(name, "/"); index != -1 {
+			name = name[index+1:]
+		}
+		if name != "" {
+			return name
+		}
+	}
+	name := violation.Check
+	if index := strings.Index(name, "("); index != -1 {
+		name = name[:index]
+	}
+	return name
+}
+
+func (l *Localizer) addLocale(locale string) {
+	locale = normalize(locale)
+	if locale == "" {
+		return
+	}
+	l.appendLocale(locale)
+	if index := strings.Index(locale, "-"); index != -1 {
+		l.appendLocale(locale[:index])
+	}
+}
+
+func (l *Localizer) appendLocale(locale string) {
+	for _, candidate := range l.locales {
+		if candidate == locale {
+			return
+		}
+	}
+	l.locales = append(l.locales, locale)
+}
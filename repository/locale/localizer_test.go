@@ -0,0 +1,91 @@
+package locale
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/viant/afs"
+	"github.com/viant/xdatly/handler/response"
+	"github.com/viant/xdatly/handler/validator"
+)
+
+func TestParseAcceptLanguage(t *testing.T) {
+	assert.Equal(t, []string{"pl-PL", "pl", "en"}, ParseAcceptLanguage("en;q=0.5, pl-PL, pl;q=0.8, *;q=0.1, de;q=0"))
+	assert.Empty(t, ParseAcceptLanguage(""))
+
+	request, _ := http.NewRequest(http.MethodGet, "http://localhost/v1/api/emp?_locale=de_AT", nil)
+	request.Header.Set(AcceptLanguageHeader, "pl")
+	assert.Equal(t, []string{"de_AT"}, RequestLocales(request))
+}
+
+func TestCatalog_Load(t *testing.T) {
+	ctx := context.Background()
+	fs := afs.New()
+	baseURL := "mem://localhost/messages"
+	require.NoError(t, fs.Upload(ctx, baseURL+"/pl.yaml", 0644, stringReader("required: 'Pole ${Field} jest wymagane'\n")))
+	require.NoError(t, fs.Upload(ctx, baseURL+"/de-AT.json", 0644, stringReader(`{"required": "Feld ${Field} fehlt"}`)))
+	require.NoError(t, fs.Upload(ctx, baseURL+"/README.md", 0644, stringReader("ignored")))
+
+	catalog := NewCatalog()
+	require.NoError(t, catalog.Load(ctx, fs, baseURL))
+	assert.Equal(t, []string{"de-at", "pl"}, catalog.Locales())
+	template, ok := catalog.Lookup("PL", "required")
+	assert.True(t, ok)
+	assert.Equal(t, "Pole ${Field} jest wymagane", template)
+}
+
+func TestLocalizer_Localize(t *testing.T) {
+	global := NewCatalog()
+	global.Put("pl", map[string]string{
+		"required":        "Pole ${Field} jest wymagane",
+		"maxLength":       "Wartość ${Value} pola ${Field} jest za długa",
+		"invalid request": "nieprawidłowe żądanie",
+	})
+	component := NewCatalog()
+	component.Put("pl", map[string]string{"required.Email": "Podaj adres e-mail"})
+
+	localizer := NewLocalizer([]string{"pl-PL"}, component, nil, global)
+	assert.Equal(t, []string{"pl-pl", "pl"}, localizer.Locales())
+
+	type output struct {
+		Status     response.Status
+		Violations validator.Violations
+		Data       []string
+	}
+	anOutput := &output{
+		Status: response.Status{Message: "invalid request", Errors: []*response.Error{{Message: "invalid request"}}},
+		Violations: validator.Violations{
+			{Field: "Name", Check: "required", Message: "Name is required"},
+			{Field: "Email", Check: "required", Message: "Email is required"},
+			{Field: "Code", Check: "maxLength", Value: "ABCDEF", Message: "Code is too long"},
+			{Field: "Phone", Check: "phone", Message: "invalid phone"},
+		},
+	}
+	localizer.Localize(anOutput)
+	assert.Equal(t, "nieprawidłowe żądanie", anOutput.Status.Message)
+	assert.Equal(t, "nieprawidłowe żądanie", anOutput.Status.Errors.([]*response.Error)[0].Message)
+	assert.Equal(t, "Pole Name jest wymagane", anOutput.Violations[0].Message)
+	assert.Equal(t, "Podaj adres e-mail", anOutput.Violations[1].Message)
+	assert.Equal(t, "Wartość ABCDEF pola Code jest za długa", anOutput.Violations[2].Message)
+	assert.Equal(t, "invalid phone", anOutput.Violations[3].Message)
+
+	var unset *Localizer
+	unset.Localize(anOutput)
+	assert.Equal(t, "invalid request", unset.Message("invalid request"))
+	assert.Nil(t, LocalizerOf(context.Background()))
+}
+
+func stringReader(text string) *strings.Reader {
+	return strings.NewReader(text)
+}
+
+func TestExpand(t *testing.T) {
+	template := "${Field}: ${Check} ${Constraint} ${Value}"
+	assert.Equal(t, "Code: maxLength maxLength ABCDEF", Expand(template, &validator.Violation{Field: "Code", Check: "maxLength", Value: "ABCDEF"}))
+	assert.Equal(t, "Code: gt(3) gt 1", Expand(template, &validator.Violation{Field: "Code", Check: "gt(3)", Value: 1}))
+	assert.Equal(t, "Id: parameter id ", Expand(template, &validator.Violation{Field: "Id", Check: "parameter", Location: "emp/id"}))
+}
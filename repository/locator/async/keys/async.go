@@ -12,6 +12,7 @@ const ( //async kind
 	JobUserEmail = "job.useremail"
 	JobUserID    = "job.userid"
 	JobError     = "job.error"
+	JobToken     = "job.token"
 
 	JobEndUnixTimeInSec = "job.endunixtimeinsec"
 
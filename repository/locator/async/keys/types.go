@@ -11,6 +11,7 @@ var Types = map[string]reflect.Type{
 	Job:                  reflect.TypeOf(&async.Job{}),
 	JobCreationTime:      xreflect.TimeType,
 	JobError:             xreflect.StringType,
+	JobToken:             xreflect.StringType,
 	JobEndTime:           xreflect.TimePtrType,
 	JobEndUnixTimeInSec:  xreflect.IntType,
 	JobInfoStatus:        xreflect.StringType,
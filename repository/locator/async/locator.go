@@ -3,6 +3,7 @@ package async
 import (
 	"context"
 	"github.com/viant/datly/repository/locator/async/keys"
+	"github.com/viant/datly/service/jobs"
 	"github.com/viant/datly/view/state"
 	"github.com/viant/datly/view/state/kind"
 	"github.com/viant/datly/view/state/kind/locator"
@@ -59,6 +60,9 @@ func (l *Locator) getJobValue(ctx context.Context, name string) (interface{}, bo
 	case keys.JobEndTime:
 		val := job.EndTime
 		return val, val != nil, nil
+	case keys.JobToken:
+		token := jobs.Token(job.ID)
+		return token, token != "", nil
 	case keys.JobUserEmail:
 		return job.UserEmail, job.UserEmail != nil, nil
 	case keys.JobUserID:
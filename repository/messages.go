@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/viant/afs"
+	"github.com/viant/afs/url"
+	"github.com/viant/datly/repository/locale"
+)
+
+// Localizer returns request localizer, component message catalogs take precedence over gateway ones
+func (c *Component) Localizer(request *http.Request) *locale.Localizer {
+	return locale.NewLocalizer(locale.RequestLocales(request), c.messages, locale.Messages())
+}
+
+func (c *Component) initMessages(ctx context.Context) error {
+	if c.MessagesURL == "" {
+		return nil
+	}
+	URL := c.MessagesURL
+	if url.IsRelative(URL) && c.SourceURL != "" {
+		parent, _ := url.Split(c.SourceURL, "")
+		URL = url.Join(parent, URL)
+	}
+	c.messages = locale.NewCatalog()
+	return c.messages.Load(ctx, afs.New(), URL)
+}
@@ -2,17 +2,25 @@ package path
 
 import (
 	"context"
+	"crypto/subtle"
+	"fmt"
 	"github.com/viant/scy"
 	"sort"
 	"strings"
+	"sync/atomic"
+	"time"
 )
 
 type (
 	APIKey struct {
-		URI    string        `yaml:"URI,omitempty"`
-		Value  string        `yaml:"GetValue,omitempty"`
-		Header string        `yaml:"Header,omitempty"`
-		Secret *scy.Resource `yaml:"Secret,omitempty"`
+		URI               string        `yaml:"URI,omitempty"`
+		Value             string        `yaml:"GetValue,omitempty"`
+		Header            string        `yaml:"Header,omitempty"`
+		Secret            *scy.Resource `yaml:"Secret,omitempty"`
+		RefreshIntervalMs int           `json:",omitempty" yaml:"RefreshIntervalMs,omitempty"` // secret reload interval
+		_value            atomic.Value
+		_loaded           int64
+		_refreshing       int32
 	}
 
 	APIKeys []*APIKey
@@ -20,16 +28,62 @@ type (
 
 func (k *APIKey) Init(ctx context.Context) error {
 	if k.Secret != nil {
-		srv := scy.New()
-		secret, err := srv.Load(ctx, k.Secret)
-		if err != nil {
+		if err := k.Refresh(ctx); err != nil {
 			return err
 		}
-		k.Value = secret.String()
+		k.Value = k.Key()
 	}
 	return nil
 }
 
+// Refresh reloads API key secret, the new value is swapped in atomically
+func (k *APIKey) Refresh(ctx context.Context) error {
+	if k.Secret == nil {
+		return nil
+	}
+	srv := scy.New()
+	secret, err := srv.Load(ctx, k.Secret)
+	if err != nil {
+		return err
+	}
+	k._value.Store(secret.String())
+	atomic.StoreInt64(&k._loaded, time.Now().UnixNano())
+	return nil
+}
+
+// Key returns current API key value, secret based key is reloaded in the background once per RefreshIntervalMs
+func (k *APIKey) Key() string {
+	k.refreshIfDue()
+	if value, ok := k._value.Load().(string); ok {
+		return value
+	}
+	return k.Value
+}
+
+// Matches returns true if candidate matches current API key value
+func (k *APIKey) Matches(candidate string) bool {
+	return subtle.ConstantTimeCompare([]byte(candidate), []byte(k.Key())) == 1
+}
+
+func (k *APIKey) refreshIfDue() {
+	if k.Secret == nil || k.RefreshIntervalMs <= 0 {
+		return
+	}
+	loaded := time.Unix(0, atomic.LoadInt64(&k._loaded))
+	if time.Since(loaded) < time.Duration(k.RefreshIntervalMs)*time.Millisecond {
+		return
+	}
+	if !atomic.CompareAndSwapInt32(&k._refreshing, 0, 1) {
+		return
+	}
+	go func() {
+		defer atomic.StoreInt32(&k._refreshing, 0)
+		if err := k.Refresh(context.Background()); err != nil {
+			fmt.Printf("[WARN] failed to refresh api key %v secret: %v\n", k.URI, err)
+		}
+	}()
+}
+
 func (a APIKeys) Match(URI string) *APIKey {
 	//a needs to be sorted by longest URI
 	for _, candidate := range a {
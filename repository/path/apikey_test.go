@@ -0,0 +1,19 @@
+package path
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIKey_Matches(t *testing.T) {
+	key := &APIKey{Value: "initial"}
+	assert.True(t, key.Matches("initial"))
+	assert.False(t, key.Matches("other"))
+	assert.False(t, key.Matches(""))
+
+	key._value.Store("rotated")
+	assert.Equal(t, "rotated", key.Key())
+	assert.True(t, key.Matches("rotated"), "rotated value should be swapped in")
+	assert.False(t, key.Matches("initial"))
+}
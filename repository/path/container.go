@@ -30,6 +30,7 @@ type (
 		Logger       *Logger      `json:",omitempty"  yaml:"Logger,omitempty"`
 		RevealMetric *bool
 		With         []string `yaml:"With" json:"With"`
+		TimeoutMs    int      `json:",omitempty" yaml:"TimeoutMs,omitempty"` // route execution budget
 	}
 
 	Handler struct {
@@ -60,6 +61,7 @@ type (
 		contract.ModelContextProtocol `yaml:",inline"`
 		Handler                       *Handler         `yaml:"Handler" json:"Handler"`
 		Report                        *Report          `yaml:"Report,omitempty" json:"Report,omitempty"`
+		Schedule                      *Schedule        `yaml:"Schedule,omitempty" json:"Schedule,omitempty"`
 		Internal                      bool             `json:"Internal,omitempty" yaml:"Internal,omitempty" `
 		Connector                     string           `json:",omitempty"`
 		ContentURL                    string           `json:"ContentURL,omitempty" yaml:"ContentURL,omitempty" `
@@ -104,6 +106,9 @@ func (r *Settings) HasWith(candidate string) bool {
 }
 
 func (r *Settings) inherit(from *Settings) {
+	if r.TimeoutMs == 0 {
+		r.TimeoutMs = from.TimeoutMs
+	}
 	if r.Cors == nil {
 		r.Cors = from.Cors
 		return
@@ -162,6 +167,11 @@ func (p *Path) IsMetricDebug(req *http.Request) bool {
 	return strings.ToLower(value) == httputils.DatlyDebugHeaderValue
 }
 
+// Timeout returns route execution budget
+func (p *Path) Timeout() time.Duration {
+	return time.Duration(p.TimeoutMs) * time.Millisecond
+}
+
 func (p *Path) CorsEnabled() bool {
 	return p.Cors != nil
 }
@@ -0,0 +1,34 @@
+package path
+
+import (
+	"fmt"
+	"time"
+)
+
+// Schedule represents cron triggered component execution, run by standalone runtime
+type Schedule struct {
+	Cron      string `json:",omitempty" yaml:"Cron,omitempty"`      // 5 field cron expression or @hourly, @daily etc.
+	TimeZone  string `json:",omitempty" yaml:"TimeZone,omitempty"`  // IANA time zone, UTC by default
+	JitterMs  int    `json:",omitempty" yaml:"JitterMs,omitempty"`  // max random delay added to each run
+	Query     string `json:",omitempty" yaml:"Query,omitempty"`     // URL encoded query input
+	Body      string `json:",omitempty" yaml:"Body,omitempty"`      // request body input
+	Connector string `json:",omitempty" yaml:"Connector,omitempty"` // lock and jobs table connector, component connector by default
+	Disabled  bool   `json:",omitempty" yaml:"Disabled,omitempty"`
+}
+
+// Location returns schedule time zone
+func (s *Schedule) Location() (*time.Location, error) {
+	if s.TimeZone == "" {
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(s.TimeZone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schedule time zone %v: %w", s.TimeZone, err)
+	}
+	return loc, nil
+}
+
+// Jitter returns max schedule jitter
+func (s *Schedule) Jitter() time.Duration {
+	return time.Duration(s.JitterMs) * time.Millisecond
+}
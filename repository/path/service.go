@@ -63,6 +63,24 @@ func (s *Service) initMbusPaths() {
 	}
 }
 
+// ScheduledPaths returns paths with enabled cron schedule, paths of deleted rules are excluded
+func (s *Service) ScheduledPaths() []*Path {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+	var result []*Path
+	for _, item := range s.Container.Items {
+		if item.Version.ChangeKind() == version.ChangeKindDeleted {
+			continue
+		}
+		for i, aPath := range item.Paths {
+			if schedule := aPath.Schedule; schedule != nil && schedule.Cron != "" && !schedule.Disabled {
+				result = append(result, item.Paths[i])
+			}
+		}
+	}
+	return result
+}
+
 func (s *Service) GetPaths() Container {
 	s.mux.RLock()
 	ret := s.Container
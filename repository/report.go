@@ -6,3 +6,5 @@ type Report = reportmodel.Config
 type ReportMetadata = reportmodel.Metadata
 type ReportField = reportmodel.Field
 type ReportFilter = reportmodel.Filter
+type ReportTopN = reportmodel.TopN
+type ReportPivot = reportmodel.Pivot
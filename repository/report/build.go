@@ -43,19 +43,33 @@ func AssembleMetadata(component *Component, cfg *Config) (*Metadata, error) {
 		OrderBy:       cfg.OrderBy,
 		Limit:         cfg.Limit,
 		Offset:        cfg.Offset,
+		TimeGrainKey:  cfg.TimeGrain,
+		TimeZoneKey:   cfg.TimeZone,
+		RollupKey:     cfg.Rollup,
+		TopNKey:       cfg.TopN,
+		PivotKey:      cfg.Pivot,
+		ComparisonKey: cfg.Comparison,
+		MaxRows:       cfg.MaxRows,
+	}
+	var recordType reflect.Type
+	if viewRef.Schema != nil {
+		recordType = viewRef.Schema.CompType()
 	}
 	for _, column := range viewRef.Columns {
 		if column == nil || column.FieldName() == "" {
 			continue
 		}
 		fieldName := exportedFieldName(column.FieldName())
-		field := &Field{Name: column.FieldName(), FieldName: fieldName, Description: column.Name}
+		field := &Field{Name: column.FieldName(), FieldName: fieldName, Description: column.Name, Key: outputKey(recordType, column.FieldName())}
 		switch {
 		case column.Groupable:
 			field.Section = cfg.Dimensions
+			field.Time = isTimeType(column.ColumnType())
+			field.TimeLayout = column.TimeLayout()
 			result.Dimensions = append(result.Dimensions, field)
 		case column.Aggregate || (viewRef.Groupable && !column.Groupable):
 			field.Section = cfg.Measures
+			field.Aggregate = aggregateFunction(column.Expression)
 			result.Measures = append(result.Measures, field)
 		}
 	}
@@ -112,9 +126,109 @@ func BuildBodyType(metadata *Metadata) reflect.Type {
 		Type: reflect.TypeOf((*int)(nil)),
 		Tag:  buildTag(lowerCamel(metadata.Offset), "Row offset applied to the grouped result"),
 	})
+	fields = append(fields, shapingFields(metadata)...)
 	return reflect.StructOf(fields)
 }
 
+// shapingFields returns optional time grain, rollup, top-N and pivot body fields
+func shapingFields(metadata *Metadata) []reflect.StructField {
+	var fields []reflect.StructField
+	if timeDimensions := metadata.TimeDimensions(); len(timeDimensions) > 0 && metadata.TimeGrainKey != "" {
+		structFields := make([]reflect.StructField, 0, len(timeDimensions))
+		for _, dimension := range timeDimensions {
+			structFields = append(structFields, reflect.StructField{
+				Name: dimension.FieldName,
+				Type: reflect.TypeOf(""),
+				Tag:  buildTag(lowerCamel(dimension.Name), "Time grain of "+dimension.Name+": "+strings.Join(TimeGrains, ", ")),
+			})
+		}
+		fields = append(fields, reflect.StructField{
+			Name: metadata.TimeGrainKey,
+			Type: reflect.StructOf(structFields),
+			Tag:  buildTag(lowerCamel(metadata.TimeGrainKey), "Time grain buckets applied to time dimensions"),
+		})
+		if metadata.TimeZoneKey != "" {
+			fields = append(fields, reflect.StructField{
+				Name: metadata.TimeZoneKey,
+				Type: reflect.TypeOf(""),
+				Tag:  buildTag(lowerCamel(metadata.TimeZoneKey), "IANA time zone of time grain buckets, defaults to UTC"),
+			})
+		}
+	}
+	if metadata.RollupKey != "" {
+		fields = append(fields, reflect.StructField{
+			Name: metadata.RollupKey,
+			Type: reflect.TypeOf([]string{}),
+			Tag:  buildTag(lowerCamel(metadata.RollupKey), "Selected dimensions, in hierarchy order, producing subtotal rows with rolled up dimensions set to null"),
+		})
+	}
+	if metadata.TopNKey != "" {
+		fields = append(fields, reflect.StructField{
+			Name: metadata.TopNKey,
+			Type: reflect.TypeOf((*TopN)(nil)),
+			Tag:  buildTag(lowerCamel(metadata.TopNKey), "Keeps top N dimension values ranked by a measure, remaining values are combined into other bucket"),
+		})
+	}
+	if metadata.PivotKey != "" {
+		fields = append(fields, reflect.StructField{
+			Name: metadata.PivotKey,
+			Type: reflect.TypeOf((*Pivot)(nil)),
+			Tag:  buildTag(lowerCamel(metadata.PivotKey), "Turns dimension values into columns"),
+		})
+	}
+	if metadata.ComparisonKey != "" && len(metadata.Measures) > 0 {
+		fields = append(fields, reflect.StructField{
+			Name: metadata.ComparisonKey,
+			Type: reflect.PtrTo(comparisonStructType(metadata)),
+			Tag:  buildTag(lowerCamel(metadata.ComparisonKey), "Comparison period; each measure is returned with previous, delta and deltaPct values aligned on dimensions"),
+		})
+	}
+	return fields
+}
+
+func comparisonStructType(metadata *Metadata) reflect.Type {
+	fields := []reflect.StructField{{
+		Name: "Shift",
+		Type: reflect.TypeOf(""),
+		Tag:  buildTag("shift", "Shifts time filters to comparison period: "+ComparisonPrevious+" (preceding period of the same length) or "+ComparisonYear+" (year over year)"),
+	}}
+	if metadata.FiltersKey != "" {
+		fields = append(fields, reflect.StructField{
+			Name: metadata.FiltersKey,
+			Type: filterStructType(metadata.Filters),
+			Tag:  buildTag(lowerCamel(metadata.FiltersKey), "Comparison period filters overriding report filters"),
+		})
+	}
+	return reflect.StructOf(fields)
+}
+
+func isTimeType(rType reflect.Type) bool {
+	for rType != nil && rType.Kind() == reflect.Ptr {
+		rType = rType.Elem()
+	}
+	return rType == timeType
+}
+
+// aggregateFunction returns measure aggregate function, measures without recognized expression are treated as additive
+func aggregateFunction(expression string) string {
+	expression = strings.ToUpper(strings.Join(strings.Fields(expression), ""))
+	switch {
+	case expression == "":
+		return AggregateSum
+	case strings.HasPrefix(expression, "COUNT(DISTINCT"):
+		return ""
+	case strings.HasPrefix(expression, "SUM("):
+		return AggregateSum
+	case strings.HasPrefix(expression, "COUNT("):
+		return AggregateCount
+	case strings.HasPrefix(expression, "MIN("):
+		return AggregateMin
+	case strings.HasPrefix(expression, "MAX("):
+		return AggregateMax
+	}
+	return ""
+}
+
 func BuildInputType(component *Component, metadata *Metadata, cfg *Config) (*state.Type, error) {
 	if component == nil {
 		return nil, fmt.Errorf("report component was empty")
@@ -285,6 +399,24 @@ func buildTag(jsonName, description string) reflect.StructTag {
 	return reflect.StructTag(result)
 }
 
+// outputKey returns json key of the view record field
+func outputKey(recordType reflect.Type, fieldName string) string {
+	for recordType != nil && recordType.Kind() == reflect.Ptr {
+		recordType = recordType.Elem()
+	}
+	if recordType == nil || recordType.Kind() != reflect.Struct {
+		return fieldName
+	}
+	field, ok := recordType.FieldByName(fieldName)
+	if !ok {
+		return fieldName
+	}
+	if name := strings.Split(field.Tag.Get("json"), ",")[0]; name != "" && name != "-" {
+		return name
+	}
+	return fieldName
+}
+
 func lowerCamel(value string) string {
 	if value == "" {
 		return ""
@@ -279,3 +279,30 @@ func newComponentWithExplicitInput(t *testing.T) *Component {
 	component.Resource = component.View.Resource()
 	return component
 }
+
+func TestAggregateFunction(t *testing.T) {
+	for expression, expect := range map[string]string{
+		"":                          AggregateSum,
+		"SUM(t.SPEND)":              AggregateSum,
+		"count( * )":                AggregateCount,
+		"COUNT(DISTINCT t.USER_ID)": "",
+		"MAX(t.CREATED)":            AggregateMax,
+		"min(t.CREATED)":            AggregateMin,
+		"AVG(t.SPEND)":              "",
+	} {
+		assert.Equal(t, expect, aggregateFunction(expression), expression)
+	}
+}
+
+func TestOutputKey(t *testing.T) {
+	type record struct {
+		AccountID  int `json:"accountId,omitempty"`
+		TotalSpend float64
+		Hidden     string `json:"-"`
+	}
+	recordType := reflect.TypeOf(&record{})
+	assert.Equal(t, "accountId", outputKey(recordType, "AccountID"))
+	assert.Equal(t, "TotalSpend", outputKey(recordType, "TotalSpend"))
+	assert.Equal(t, "Hidden", outputKey(recordType, "Hidden"))
+	assert.Equal(t, "Missing", outputKey(nil, "Missing"))
+}
@@ -0,0 +1,162 @@
+package report
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// ComparisonPrevious compares with the preceding period of the same length
+	ComparisonPrevious = "previous"
+	// ComparisonYear compares with the same period of the previous year
+	ComparisonYear = "year"
+
+	PreviousSuffix = "Previous"
+	DeltaSuffix    = "Delta"
+	DeltaPctSuffix = "DeltaPct"
+)
+
+// Shift returns comparison period time for the current period time
+type Shift func(t time.Time) time.Time
+
+// NewShift returns comparison shift for time filter values (range bounds in ascending order)
+func NewShift(shift string, values []string, layout string) (Shift, error) {
+	if len(values) == 0 {
+		return nil, fmt.Errorf("%v comparison requires time filter", shift)
+	}
+	times := make([]time.Time, len(values))
+	layouts := make([]string, len(values))
+	for i, value := range values {
+		var err error
+		if times[i], layouts[i], err = parseTime(value, layout); err != nil {
+			return nil, fmt.Errorf("invalid comparison time filter value %v: %w", value, err)
+		}
+	}
+	switch strings.ToLower(shift) {
+	case ComparisonYear:
+		return func(t time.Time) time.Time { return t.AddDate(-1, 0, 0) }, nil
+	case ComparisonPrevious:
+		if len(times) < 2 {
+			return nil, fmt.Errorf("previous period comparison requires time range filters, but had: %v", strings.Join(values, ","))
+		}
+		from, to := times[0], times[len(times)-1]
+		if to.Before(from) {
+			from, to = to, from
+		}
+		period := to.Sub(from)
+		if isDateLayout(layouts[0]) { //date ranges are inclusive
+			period += 24 * time.Hour
+		}
+		return func(t time.Time) time.Time { return t.Add(-period) }, nil
+	}
+	return nil, fmt.Errorf("unsupported comparison shift %v, supported: %v, %v", shift, ComparisonPrevious, ComparisonYear)
+}
+
+// ShiftRange shifts time filter values (range bounds in ascending order) to the comparison period
+func ShiftRange(shift string, values []string, layout string) ([]string, error) {
+	shiftTime, err := NewShift(shift, values, layout)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]string, len(values))
+	for i, value := range values {
+		aTime, aLayout, _ := parseTime(value, layout)
+		result[i] = shiftTime(aTime).Format(aLayout)
+	}
+	return result, nil
+}
+
+// Compare aligns previous period rows with current rows on dimension values, adding previous, delta and deltaPct
+// values for each measure; time dimension values of current rows are shifted to the comparison period and bucketed
+// with shaping time grain before matching; rows present only in the previous period are not returned
+func Compare(current, previous []map[string]interface{}, dimensions, measures []*Field, shaping *Shaping, shift Shift) []map[string]interface{} {
+	if len(current) == 0 {
+		return current
+	}
+	s := &shaper{measures: measures, keys: map[*Field]string{}}
+	for _, field := range append(append([]*Field{}, dimensions...), measures...) {
+		s.keys[field] = field.OutputKey()
+	}
+	for _, dimension := range dimensions {
+		s.dimensions = append(s.dimensions, s.keys[dimension])
+	}
+	index := make(map[string]map[string]interface{}, len(previous))
+	for _, row := range previous {
+		index[s.groupKey(row, s.dimensions)] = row
+	}
+	for _, row := range current {
+		previousRow := index[s.groupKey(s.shiftRow(row, dimensions, shaping, shift), s.dimensions)]
+		for _, measure := range measures {
+			key := s.keys[measure]
+			var previousValue interface{}
+			if previousRow != nil {
+				previousValue = previousRow[key]
+			}
+			row[key+PreviousSuffix] = previousValue
+			row[key+DeltaSuffix] = nil
+			row[key+DeltaPctSuffix] = nil
+			value, ok := toFloat(row[key])
+			prior, hasPrior := toFloat(previousValue)
+			if !ok || !hasPrior {
+				continue
+			}
+			row[key+DeltaSuffix] = value - prior
+			if prior != 0 {
+				row[key+DeltaPctSuffix] = (value - prior) / prior * 100
+			}
+		}
+	}
+	return current
+}
+
+// shiftRow returns row copy with time dimension values shifted to the comparison period
+func (s *shaper) shiftRow(row map[string]interface{}, dimensions []*Field, shaping *Shaping, shift Shift) map[string]interface{} {
+	if shift == nil {
+		return row
+	}
+	location := time.UTC
+	grains := map[*Field]string{}
+	if shaping != nil {
+		if shaping.Location != nil {
+			location = shaping.Location
+		}
+		for name, grain := range shaping.TimeGrain {
+			grains[LookupField(dimensions, name)] = grain
+		}
+	}
+	var ret map[string]interface{}
+	for _, dimension := range dimensions {
+		if !dimension.Time {
+			continue
+		}
+		key := s.keys[dimension]
+		text, ok := row[key].(string)
+		if !ok {
+			continue
+		}
+		aTime, layout, err := parseTime(text, dimension.TimeLayout)
+		if err != nil {
+			continue
+		}
+		shifted := shift(aTime)
+		if grain, ok := grains[dimension]; ok {
+			shifted = truncateTime(shifted.In(location), grain)
+		}
+		if ret == nil {
+			ret = make(map[string]interface{}, len(row))
+			for k, v := range row {
+				ret[k] = v
+			}
+		}
+		ret[key] = shifted.Format(layout)
+	}
+	if ret == nil {
+		return row
+	}
+	return ret
+}
+
+func isDateLayout(layout string) bool {
+	return !strings.Contains(layout, "15") && !strings.Contains(layout, "03") && !strings.Contains(layout, "3:04")
+}
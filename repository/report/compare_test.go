@@ -0,0 +1,92 @@
+package report
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShiftRange(t *testing.T) {
+	tests := []struct {
+		name      string
+		shift     string
+		values    []string
+		layout    string
+		expect    []string
+		expectErr bool
+	}{
+		{name: "previous inclusive date range", shift: ComparisonPrevious, values: []string{"2024-02-01", "2024-02-29"}, layout: "2006-01-02", expect: []string{"2024-01-03", "2024-01-31"}},
+		{name: "previous time range", shift: ComparisonPrevious, values: []string{"2024-02-01T00:00:00Z", "2024-02-08T00:00:00Z"}, expect: []string{"2024-01-25T00:00:00Z", "2024-02-01T00:00:00Z"}},
+		{name: "year over year", shift: ComparisonYear, values: []string{"2024-02-01"}, expect: []string{"2023-02-01"}},
+		{name: "previous requires range", shift: ComparisonPrevious, values: []string{"2024-02-01"}, expectErr: true},
+		{name: "unsupported shift", shift: "quarter", values: []string{"2024-02-01"}, expectErr: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := ShiftRange(tc.shift, tc.values, tc.layout)
+			if tc.expectErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expect, actual)
+		})
+	}
+}
+
+func TestCompare(t *testing.T) {
+	region := &Field{Name: "Region", Key: "region"}
+	spend := &Field{Name: "Spend", Key: "spend", Aggregate: AggregateSum}
+	current := []map[string]interface{}{
+		{"region": "east", "spend": 12.0},
+		{"region": "west", "spend": 3.0},
+		{"region": "south", "spend": 1.0},
+	}
+	previous := []map[string]interface{}{
+		{"region": "east", "spend": 8.0},
+		{"region": "south", "spend": 0.0},
+		{"region": "north", "spend": 5.0},
+	}
+	actual := Compare(current, previous, []*Field{region}, []*Field{spend}, nil, nil)
+	assert.Equal(t, []map[string]interface{}{
+		{"region": "east", "spend": 12.0, "spendPrevious": 8.0, "spendDelta": 4.0, "spendDeltaPct": 50.0},
+		{"region": "west", "spend": 3.0, "spendPrevious": nil, "spendDelta": nil, "spendDeltaPct": nil},
+		{"region": "south", "spend": 1.0, "spendPrevious": 0.0, "spendDelta": 1.0, "spendDeltaPct": nil},
+	}, actual)
+}
+
+func TestCompare_TimeDimension(t *testing.T) {
+	day := &Field{Name: "Day", Key: "day", Time: true, TimeLayout: "2006-01-02"}
+	region := &Field{Name: "Region", Key: "region"}
+	spend := &Field{Name: "Spend", Key: "spend", Aggregate: AggregateSum}
+
+	t.Run("year over year", func(t *testing.T) {
+		shift, err := NewShift(ComparisonYear, []string{"2024-02-01", "2024-02-29"}, "2006-01-02")
+		require.NoError(t, err)
+		current := []map[string]interface{}{
+			{"day": "2024-02-01", "region": "east", "spend": 12.0},
+			{"day": "2024-02-02", "region": "east", "spend": 3.0},
+		}
+		previous := []map[string]interface{}{
+			{"day": "2023-02-01", "region": "east", "spend": 8.0},
+			{"day": "2023-02-02", "region": "west", "spend": 5.0},
+		}
+		actual := Compare(current, previous, []*Field{day, region}, []*Field{spend}, nil, shift)
+		assert.Equal(t, []map[string]interface{}{
+			{"day": "2024-02-01", "region": "east", "spend": 12.0, "spendPrevious": 8.0, "spendDelta": 4.0, "spendDeltaPct": 50.0},
+			{"day": "2024-02-02", "region": "east", "spend": 3.0, "spendPrevious": nil, "spendDelta": nil, "spendDeltaPct": nil},
+		}, actual)
+	})
+
+	t.Run("previous period with month grain", func(t *testing.T) {
+		shift, err := NewShift(ComparisonPrevious, []string{"2024-02-01", "2024-02-29"}, "2006-01-02")
+		require.NoError(t, err)
+		current := []map[string]interface{}{{"day": "2024-02-01", "spend": 12.0}}
+		previous := []map[string]interface{}{{"day": "2024-01-01", "spend": 6.0}}
+		actual := Compare(current, previous, []*Field{day}, []*Field{spend}, &Shaping{TimeGrain: map[string]string{"Day": TimeGrainMonth}}, shift)
+		assert.Equal(t, []map[string]interface{}{
+			{"day": "2024-02-01", "spend": 12.0, "spendPrevious": 6.0, "spendDelta": 6.0, "spendDeltaPct": 100.0},
+		}, actual)
+	})
+}
@@ -18,8 +18,18 @@ type Config struct {
 	OrderBy    string
 	Limit      string
 	Offset     string
+	TimeGrain  string
+	TimeZone   string
+	Rollup     string
+	TopN       string
+	Pivot      string
+	Comparison string
+	MaxRows    int `json:",omitempty" yaml:",omitempty"` //max grouped rows read for shaped or compared report, 10000 by default
 }
 
+// DefaultMaxRows represents default max grouped rows read for shaped or compared report
+const DefaultMaxRows = 10000
+
 type Metadata struct {
 	InputName     string
 	BodyFieldName string
@@ -32,6 +42,13 @@ type Metadata struct {
 	OrderBy       string
 	Limit         string
 	Offset        string
+	TimeGrainKey  string
+	TimeZoneKey   string
+	RollupKey     string
+	TopNKey       string
+	PivotKey      string
+	ComparisonKey string
+	MaxRows       int
 }
 
 type Field struct {
@@ -39,6 +56,24 @@ type Field struct {
 	FieldName   string
 	Section     string
 	Description string
+	Time        bool   // time dimension supporting time grain buckets
+	TimeLayout  string // time dimension layout
+	Aggregate   string // measure aggregate function used to re-aggregate shaped rows
+	Key         string // output row key, json name of the view column struct field
+}
+
+// TopN limits dimension to N values ranked by a measure, remaining values are combined into "other" bucket
+type TopN struct {
+	Dimension  string `json:"dimension,omitempty" desc:"Dimension name limited to top values"`
+	Measure    string `json:"measure,omitempty" desc:"Measure name used to rank dimension values, defaults to the first selected measure"`
+	Limit      int    `json:"limit,omitempty" desc:"Number of top dimension values to keep"`
+	OtherLabel string `json:"otherLabel,omitempty" desc:"Label of the bucket combining remaining values, defaults to Other"`
+}
+
+// Pivot turns dimension values into columns
+type Pivot struct {
+	Dimension string   `json:"dimension,omitempty" desc:"Dimension name whose values become columns"`
+	Measures  []string `json:"measures,omitempty" desc:"Measures rendered per pivoted value, defaults to all selected measures"`
 }
 
 type Filter struct {
@@ -69,6 +104,12 @@ func (c *Config) Normalize() *Config {
 	ret.OrderBy = defaultString(ret.OrderBy, "OrderBy")
 	ret.Limit = defaultString(ret.Limit, "Limit")
 	ret.Offset = defaultString(ret.Offset, "Offset")
+	ret.TimeGrain = defaultString(ret.TimeGrain, "TimeGrain")
+	ret.TimeZone = defaultString(ret.TimeZone, "TimeZone")
+	ret.Rollup = defaultString(ret.Rollup, "Rollup")
+	ret.TopN = defaultString(ret.TopN, "TopN")
+	ret.Pivot = defaultString(ret.Pivot, "Pivot")
+	ret.Comparison = defaultString(ret.Comparison, "Comparison")
 	return ret
 }
 
@@ -103,6 +144,33 @@ func (m *Metadata) ValidateSelection() error {
 	return nil
 }
 
+// TimeDimensions returns dimensions supporting time grain buckets
+func (m *Metadata) TimeDimensions() []*Field {
+	var result []*Field
+	for _, dimension := range m.Dimensions {
+		if dimension.Time {
+			result = append(result, dimension)
+		}
+	}
+	return result
+}
+
+// RowLimit returns max grouped rows read for shaped or compared report
+func (m *Metadata) RowLimit() int {
+	if m.MaxRows > 0 {
+		return m.MaxRows
+	}
+	return DefaultMaxRows
+}
+
+// OutputKey returns field key in dispatched output rows
+func (f *Field) OutputKey() string {
+	if f.Key != "" {
+		return f.Key
+	}
+	return f.Name
+}
+
 func (f *Filter) SchemaType() reflect.Type {
 	if f == nil || f.Parameter == nil || f.Parameter.Schema == nil {
 		return nil
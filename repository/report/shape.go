@@ -0,0 +1,471 @@
+package report
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	TimeGrainHour  = "hour"
+	TimeGrainDay   = "day"
+	TimeGrainWeek  = "week"
+	TimeGrainMonth = "month"
+
+	AggregateSum   = "sum"
+	AggregateCount = "count"
+	AggregateMin   = "min"
+	AggregateMax   = "max"
+
+	DefaultOtherLabel = "Other"
+)
+
+// TimeGrains lists supported time grain buckets
+var TimeGrains = []string{TimeGrainHour, TimeGrainDay, TimeGrainWeek, TimeGrainMonth}
+
+// Shaping represents post aggregation result shaping: time buckets, top-N, rollup subtotals and pivot
+type Shaping struct {
+	TimeGrain map[string]string // dimension name to time grain
+	Location  *time.Location
+	Rollup    []string
+	TopN      *TopN
+	Pivot     *Pivot
+}
+
+// Active returns true if any shaping was requested
+func (s *Shaping) Active() bool {
+	if s == nil {
+		return false
+	}
+	return len(s.TimeGrain) > 0 || len(s.Rollup) > 0 || (s.TopN != nil && s.TopN.Limit > 0) || (s.Pivot != nil && s.Pivot.Dimension != "")
+}
+
+// Validate checks that shaping references selected dimensions and measures
+func (s *Shaping) Validate(dimensions, measures []*Field) error {
+	for name, grain := range s.TimeGrain {
+		field := LookupField(dimensions, name)
+		if field == nil {
+			return fmt.Errorf("time grain dimension %v was not selected", name)
+		}
+		if !field.Time {
+			return fmt.Errorf("time grain dimension %v is not a time dimension", name)
+		}
+		if !isTimeGrain(grain) {
+			return fmt.Errorf("unsupported time grain %v for %v, supported: %v", grain, name, strings.Join(TimeGrains, ", "))
+		}
+	}
+	for _, name := range s.Rollup {
+		if LookupField(dimensions, name) == nil {
+			return fmt.Errorf("rollup dimension %v was not selected", name)
+		}
+	}
+	if topN := s.TopN; topN != nil && topN.Limit > 0 {
+		if LookupField(dimensions, topN.Dimension) == nil {
+			return fmt.Errorf("top-N dimension %v was not selected", topN.Dimension)
+		}
+		if topN.Measure != "" && LookupField(measures, topN.Measure) == nil {
+			return fmt.Errorf("top-N measure %v was not selected", topN.Measure)
+		}
+		if topN.Measure == "" && len(measures) == 0 {
+			return fmt.Errorf("top-N requires at least one measure")
+		}
+	}
+	if pivot := s.Pivot; pivot != nil && pivot.Dimension != "" {
+		if LookupField(dimensions, pivot.Dimension) == nil {
+			return fmt.Errorf("pivot dimension %v was not selected", pivot.Dimension)
+		}
+		for _, name := range pivot.Measures {
+			if LookupField(measures, name) == nil {
+				return fmt.Errorf("pivot measure %v was not selected", name)
+			}
+		}
+		for _, name := range s.Rollup {
+			if strings.EqualFold(name, pivot.Dimension) {
+				return fmt.Errorf("pivot dimension %v can not be rolled up", name)
+			}
+		}
+	}
+	return nil
+}
+
+// LookupField returns field matching name ignoring case and separators
+func LookupField(fields []*Field, name string) *Field {
+	normalized := normalizeName(name)
+	for _, field := range fields {
+		if normalizeName(field.Name) == normalized || normalizeName(field.FieldName) == normalized {
+			return field
+		}
+	}
+	return nil
+}
+
+// Shape applies time buckets, top-N, rollup and pivot to grouped rows, in that order; rows affected by
+// bucketing are re-aggregated with measure aggregate function, thus non-additive measures can not be combined
+func Shape(rows []map[string]interface{}, dimensions, measures []*Field, shaping *Shaping) ([]map[string]interface{}, error) {
+	if !shaping.Active() || len(rows) == 0 {
+		return rows, nil
+	}
+	if err := shaping.Validate(dimensions, measures); err != nil {
+		return nil, err
+	}
+	s := &shaper{measures: measures, keys: map[*Field]string{}}
+	for _, field := range append(append([]*Field{}, dimensions...), measures...) {
+		s.keys[field] = field.OutputKey()
+	}
+	for _, dimension := range dimensions {
+		s.dimensions = append(s.dimensions, s.keys[dimension])
+	}
+	var err error
+	if len(shaping.TimeGrain) > 0 {
+		location := shaping.Location
+		if location == nil {
+			location = time.UTC
+		}
+		for name, grain := range shaping.TimeGrain {
+			if err = s.bucketTime(rows, LookupField(dimensions, name), grain, location); err != nil {
+				return nil, err
+			}
+		}
+		if rows, err = s.aggregate(rows, s.dimensions); err != nil {
+			return nil, err
+		}
+	}
+	if topN := shaping.TopN; topN != nil && topN.Limit > 0 {
+		measure := measures[0]
+		if topN.Measure != "" {
+			measure = LookupField(measures, topN.Measure)
+		}
+		s.topN(rows, s.keys[LookupField(dimensions, topN.Dimension)], s.keys[measure], topN)
+		if rows, err = s.aggregate(rows, s.dimensions); err != nil {
+			return nil, err
+		}
+	}
+	if len(shaping.Rollup) > 0 {
+		var rollup []string
+		for _, name := range shaping.Rollup {
+			rollup = append(rollup, s.keys[LookupField(dimensions, name)])
+		}
+		if rows, err = s.rollup(rows, rollup); err != nil {
+			return nil, err
+		}
+	}
+	if pivot := shaping.Pivot; pivot != nil && pivot.Dimension != "" {
+		pivotMeasures := measures
+		if len(pivot.Measures) > 0 {
+			pivotMeasures = nil
+			for _, name := range pivot.Measures {
+				pivotMeasures = append(pivotMeasures, LookupField(measures, name))
+			}
+		}
+		if rows, err = s.pivot(rows, s.keys[LookupField(dimensions, pivot.Dimension)], pivotMeasures); err != nil {
+			return nil, err
+		}
+	}
+	return rows, nil
+}
+
+type shaper struct {
+	measures   []*Field
+	dimensions []string
+	keys       map[*Field]string
+}
+
+func (s *shaper) bucketTime(rows []map[string]interface{}, dimension *Field, grain string, location *time.Location) error {
+	key := s.keys[dimension]
+	for _, row := range rows {
+		value, ok := row[key]
+		if !ok || value == nil {
+			continue
+		}
+		text, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unsupported time value %v for %v", value, dimension.Name)
+		}
+		aTime, layout, err := parseTime(text, dimension.TimeLayout)
+		if err != nil {
+			return fmt.Errorf("invalid time value %v for %v: %w", text, dimension.Name, err)
+		}
+		row[key] = truncateTime(aTime.In(location), grain).Format(layout)
+	}
+	return nil
+}
+
+func (s *shaper) topN(rows []map[string]interface{}, dimensionKey, measureKey string, topN *TopN) {
+	totals := map[string]float64{}
+	var values []string
+	for _, row := range rows {
+		value := groupValue(row[dimensionKey])
+		if _, ok := totals[value]; !ok {
+			values = append(values, value)
+		}
+		number, _ := toFloat(row[measureKey])
+		totals[value] += number
+	}
+	if len(values) <= topN.Limit {
+		return
+	}
+	sort.SliceStable(values, func(i, j int) bool { return totals[values[i]] > totals[values[j]] })
+	kept := map[string]bool{}
+	for _, value := range values[:topN.Limit] {
+		kept[value] = true
+	}
+	label := topN.OtherLabel
+	if label == "" {
+		label = DefaultOtherLabel
+	}
+	for _, row := range rows {
+		if !kept[groupValue(row[dimensionKey])] {
+			row[dimensionKey] = label
+		}
+	}
+}
+
+// rollup appends subtotal rows for each rollup level, with rolled up dimensions set to null;
+// rows are ordered by dimensions, so subtotals follow the rows they summarize
+func (s *shaper) rollup(rows []map[string]interface{}, rollup []string) ([]map[string]interface{}, error) {
+	result := append([]map[string]interface{}{}, rows...)
+	for level := len(rollup) - 1; level >= 0; level-- {
+		rolledUp := map[string]bool{}
+		for _, key := range rollup[level:] {
+			rolledUp[key] = true
+		}
+		var groupBy []string
+		for _, key := range s.dimensions {
+			if !rolledUp[key] {
+				groupBy = append(groupBy, key)
+			}
+		}
+		var levelRows []map[string]interface{}
+		for _, row := range rows {
+			clone := make(map[string]interface{}, len(row))
+			for k, v := range row {
+				clone[k] = v
+			}
+			for key := range rolledUp {
+				clone[key] = nil
+			}
+			levelRows = append(levelRows, clone)
+		}
+		subtotals, err := s.aggregate(levelRows, groupBy)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, subtotals...)
+	}
+	order := append(append([]string{}, rollup...), s.dimensions...)
+	sort.SliceStable(result, func(i, j int) bool {
+		for _, key := range order {
+			if c := compareValues(result[i][key], result[j][key]); c != 0 {
+				return c < 0
+			}
+		}
+		return false
+	})
+	return result, nil
+}
+
+// pivot turns dimension values into columns named after the value, or value_measure for multiple measures
+func (s *shaper) pivot(rows []map[string]interface{}, dimensionKey string, measures []*Field) ([]map[string]interface{}, error) {
+	var groupBy []string
+	for _, key := range s.dimensions {
+		if key != dimensionKey {
+			groupBy = append(groupBy, key)
+		}
+	}
+	var result []map[string]interface{}
+	index := map[string]map[string]interface{}{}
+	for _, row := range rows {
+		groupKey := s.groupKey(row, groupBy)
+		target, ok := index[groupKey]
+		if !ok {
+			target = map[string]interface{}{}
+			for _, key := range groupBy {
+				if value, has := row[key]; has {
+					target[key] = value
+				}
+			}
+			index[groupKey] = target
+			result = append(result, target)
+		}
+		value := groupValue(row[dimensionKey])
+		for _, measure := range measures {
+			column := value
+			if len(measures) > 1 {
+				column += "_" + s.keys[measure]
+			}
+			if existing, has := target[column]; has {
+				combined, err := combine(measure, existing, row[s.keys[measure]])
+				if err != nil {
+					return nil, err
+				}
+				target[column] = combined
+				continue
+			}
+			target[column] = row[s.keys[measure]]
+		}
+	}
+	return result, nil
+}
+
+// aggregate groups rows by supplied keys, combining measures with their aggregate function
+func (s *shaper) aggregate(rows []map[string]interface{}, groupBy []string) ([]map[string]interface{}, error) {
+	var result []map[string]interface{}
+	index := map[string]map[string]interface{}{}
+	for _, row := range rows {
+		groupKey := s.groupKey(row, groupBy)
+		target, ok := index[groupKey]
+		if !ok {
+			index[groupKey] = row
+			result = append(result, row)
+			continue
+		}
+		for _, measure := range s.measures {
+			key := s.keys[measure]
+			combined, err := combine(measure, target[key], row[key])
+			if err != nil {
+				return nil, err
+			}
+			target[key] = combined
+		}
+	}
+	return result, nil
+}
+
+func (s *shaper) groupKey(row map[string]interface{}, groupBy []string) string {
+	builder := strings.Builder{}
+	for _, key := range groupBy {
+		value, ok := row[key]
+		if !ok || value == nil {
+			builder.WriteString("\x01")
+		} else {
+			builder.WriteString(groupValue(value))
+		}
+		builder.WriteString("\x00")
+	}
+	return builder.String()
+}
+
+func combine(measure *Field, left, right interface{}) (interface{}, error) {
+	if left == nil {
+		return right, nil
+	}
+	if right == nil {
+		return left, nil
+	}
+	x, ok := toFloat(left)
+	y, ok2 := toFloat(right)
+	if !ok || !ok2 {
+		return nil, fmt.Errorf("unable to combine non numeric measure %v", measure.Name)
+	}
+	switch measure.Aggregate {
+	case AggregateSum, AggregateCount:
+		return x + y, nil
+	case AggregateMin:
+		if y < x {
+			return y, nil
+		}
+		return x, nil
+	case AggregateMax:
+		if y > x {
+			return y, nil
+		}
+		return x, nil
+	}
+	return nil, fmt.Errorf("measure %v is not additive and can not be re-aggregated", measure.Name)
+}
+
+func truncateTime(t time.Time, grain string) time.Time {
+	year, month, day := t.Date()
+	switch grain {
+	case TimeGrainHour:
+		return time.Date(year, month, day, t.Hour(), 0, 0, 0, t.Location())
+	case TimeGrainDay:
+		return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+	case TimeGrainWeek:
+		offset := (int(t.Weekday()) + 6) % 7 //weeks start on Monday
+		return time.Date(year, month, day-offset, 0, 0, 0, 0, t.Location())
+	case TimeGrainMonth:
+		return time.Date(year, month, 1, 0, 0, 0, 0, t.Location())
+	}
+	return t
+}
+
+func parseTime(text, layout string) (time.Time, string, error) {
+	layouts := []string{time.RFC3339Nano, "2006-01-02 15:04:05", "2006-01-02"}
+	if layout != "" {
+		layouts = append([]string{layout}, layouts...)
+	}
+	var err error
+	for _, candidate := range layouts {
+		var aTime time.Time
+		if aTime, err = time.Parse(candidate, text); err == nil {
+			if candidate == time.RFC3339Nano {
+				candidate = time.RFC3339
+			}
+			return aTime, candidate, nil
+		}
+	}
+	return time.Time{}, "", err
+}
+
+func isTimeGrain(grain string) bool {
+	for _, candidate := range TimeGrains {
+		if candidate == grain {
+			return true
+		}
+	}
+	return false
+}
+
+func compareValues(left, right interface{}) int {
+	switch {
+	case left == nil && right == nil:
+		return 0
+	case left == nil:
+		return 1
+	case right == nil:
+		return -1
+	}
+	if x, ok := left.(float64); ok {
+		if y, ok := right.(float64); ok {
+			switch {
+			case x < y:
+				return -1
+			case x > y:
+				return 1
+			}
+			return 0
+		}
+	}
+	return strings.Compare(groupValue(left), groupValue(right))
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	switch actual := value.(type) {
+	case float64:
+		return actual, true
+	case float32:
+		return float64(actual), true
+	case int:
+		return float64(actual), true
+	case int64:
+		return float64(actual), true
+	case string:
+		number, err := strconv.ParseFloat(actual, 64)
+		return number, err == nil
+	}
+	return 0, false
+}
+
+func groupValue(value interface{}) string {
+	if text, ok := value.(string); ok {
+		return text
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+func normalizeName(name string) string {
+	return strings.ToLower(strings.NewReplacer("_", "", "-", "", " ", "").Replace(name))
+}
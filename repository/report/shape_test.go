@@ -0,0 +1,140 @@
+package report
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShape(t *testing.T) {
+	day := &Field{Name: "Day", Key: "day", Time: true}
+	region := &Field{Name: "Region", Key: "region"}
+	vendor := &Field{Name: "Vendor", Key: "vendor"}
+	spend := &Field{Name: "Spend", Key: "spend", Aggregate: AggregateSum}
+	maxSpend := &Field{Name: "MaxSpend", Key: "maxSpend", Aggregate: AggregateMax}
+	unique := &Field{Name: "Unique", Key: "unique"}
+
+	tests := []struct {
+		name       string
+		rows       []map[string]interface{}
+		dimensions []*Field
+		measures   []*Field
+		shaping    *Shaping
+		expect     []map[string]interface{}
+		expectErr  string
+	}{
+		{
+			name: "month time grain",
+			rows: []map[string]interface{}{
+				{"day": "2024-01-03T10:00:00Z", "spend": 1.0, "maxSpend": 1.0},
+				{"day": "2024-01-20T10:00:00Z", "spend": 2.0, "maxSpend": 2.0},
+				{"day": "2024-02-01T10:00:00Z", "spend": 4.0, "maxSpend": 4.0},
+			},
+			dimensions: []*Field{day},
+			measures:   []*Field{spend, maxSpend},
+			shaping:    &Shaping{TimeGrain: map[string]string{"day": TimeGrainMonth}},
+			expect: []map[string]interface{}{
+				{"day": "2024-01-01T00:00:00Z", "spend": 3.0, "maxSpend": 2.0},
+				{"day": "2024-02-01T00:00:00Z", "spend": 4.0, "maxSpend": 4.0},
+			},
+		},
+		{
+			name: "week time grain in time zone",
+			rows: []map[string]interface{}{
+				{"day": "2024-01-08T02:00:00Z", "spend": 1.0},
+				{"day": "2024-01-10T10:00:00Z", "spend": 2.0},
+			},
+			dimensions: []*Field{day},
+			measures:   []*Field{spend},
+			shaping:    &Shaping{TimeGrain: map[string]string{"Day": TimeGrainWeek}, Location: time.FixedZone("EST", -5*3600)},
+			expect: []map[string]interface{}{
+				{"day": "2024-01-01T00:00:00-05:00", "spend": 1.0},
+				{"day": "2024-01-08T00:00:00-05:00", "spend": 2.0},
+			},
+		},
+		{
+			name: "top N with other bucket",
+			rows: []map[string]interface{}{
+				{"vendor": "a", "spend": 5.0},
+				{"vendor": "b", "spend": 1.0},
+				{"vendor": "c", "spend": 7.0},
+				{"vendor": "d", "spend": 2.0},
+			},
+			dimensions: []*Field{vendor},
+			measures:   []*Field{spend},
+			shaping:    &Shaping{TopN: &TopN{Dimension: "vendor", Limit: 2}},
+			expect: []map[string]interface{}{
+				{"vendor": "a", "spend": 5.0},
+				{"vendor": "Other", "spend": 3.0},
+				{"vendor": "c", "spend": 7.0},
+			},
+		},
+		{
+			name: "rollup subtotals",
+			rows: []map[string]interface{}{
+				{"region": "east", "vendor": "b", "spend": 2.0},
+				{"region": "west", "vendor": "a", "spend": 3.0},
+				{"region": "east", "vendor": "a", "spend": 1.0},
+			},
+			dimensions: []*Field{region, vendor},
+			measures:   []*Field{spend},
+			shaping:    &Shaping{Rollup: []string{"region", "vendor"}},
+			expect: []map[string]interface{}{
+				{"region": "east", "vendor": "a", "spend": 1.0},
+				{"region": "east", "vendor": "b", "spend": 2.0},
+				{"region": "east", "vendor": nil, "spend": 3.0},
+				{"region": "west", "vendor": "a", "spend": 3.0},
+				{"region": "west", "vendor": nil, "spend": 3.0},
+				{"region": nil, "vendor": nil, "spend": 6.0},
+			},
+		},
+		{
+			name: "pivot",
+			rows: []map[string]interface{}{
+				{"region": "east", "vendor": "a", "spend": 1.0},
+				{"region": "east", "vendor": "b", "spend": 2.0},
+				{"region": "west", "vendor": "a", "spend": 3.0},
+			},
+			dimensions: []*Field{region, vendor},
+			measures:   []*Field{spend},
+			shaping:    &Shaping{Pivot: &Pivot{Dimension: "vendor"}},
+			expect: []map[string]interface{}{
+				{"region": "east", "a": 1.0, "b": 2.0},
+				{"region": "west", "a": 3.0},
+			},
+		},
+		{
+			name: "non additive measure",
+			rows: []map[string]interface{}{
+				{"region": "east", "unique": 1.0},
+				{"region": "west", "unique": 2.0},
+			},
+			dimensions: []*Field{region},
+			measures:   []*Field{unique},
+			shaping:    &Shaping{Rollup: []string{"region"}},
+			expectErr:  "not additive",
+		},
+		{
+			name:       "unselected dimension",
+			rows:       []map[string]interface{}{{"region": "east", "spend": 1.0}},
+			dimensions: []*Field{region},
+			measures:   []*Field{spend},
+			shaping:    &Shaping{Pivot: &Pivot{Dimension: "vendor"}},
+			expectErr:  "was not selected",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := Shape(tc.rows, tc.dimensions, tc.measures, tc.shaping)
+			if tc.expectErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expect, actual)
+		})
+	}
+}
@@ -53,6 +53,19 @@ func (r *cubeHandler) Exec(ctx context.Context, session xhandler.Session) (inter
 	if err != nil {
 		return nil, err
 	}
+	shaping, err := r.reportShaping(input)
+	if err != nil {
+		return nil, err
+	}
+	if comparison := r.reportComparison(input); shaping.Active() || comparison.IsValid() {
+		return r.execShaped(ctx, request, input, query, shaping, comparison)
+	}
+	internalReq := r.internalRequest(ctx, request, query)
+	redirect := &xdhttp.Route{URL: r.Path.URI, Method: r.Path.Method}
+	return nil, session.Http().Redirect(ctx, redirect, internalReq)
+}
+
+func (r *cubeHandler) internalRequest(ctx context.Context, request *http.Request, query url.Values) *http.Request {
 	internalReq := request.Clone(ctx)
 	internalReq.Method = r.Path.Method
 	internalReq.URL = cloneURL(request.URL)
@@ -61,8 +74,7 @@ func (r *cubeHandler) Exec(ctx context.Context, session xhandler.Session) (inter
 	internalReq.URL.RawPath = internalReq.URL.Path
 	internalReq.URL.RawQuery = query.Encode()
 	internalReq.RequestURI = internalReq.URL.RequestURI()
-	redirect := &xdhttp.Route{URL: r.Path.URI, Method: r.Path.Method}
-	return nil, session.Http().Redirect(ctx, redirect, internalReq)
+	return internalReq
 }
 
 func (r *cubeHandler) reportInput(ctx context.Context, request *http.Request) (interface{}, error) {
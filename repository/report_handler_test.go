@@ -9,6 +9,7 @@ import (
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"reflect"
 	"testing"
 	"time"
@@ -32,11 +33,15 @@ import (
 type captureDispatcher struct {
 	path    *contract.Path
 	options *contract.Options
+	output  interface{}
 }
 
 func (d *captureDispatcher) Dispatch(ctx context.Context, path *contract.Path, options ...contract.Option) (interface{}, error) {
 	d.path = path
 	d.options = contract.NewOptions(options...)
+	if d.output != nil {
+		return d.output, nil
+	}
 	return map[string]string{"status": "ok"}, nil
 }
 
@@ -278,6 +283,144 @@ func TestReportHandler_Exec_PreservesAuthorizationHeader(t *testing.T) {
 	assert.Equal(t, "101", query.Get("accountID"))
 }
 
+type reportHandlerShapedBody struct {
+	Dimensions struct {
+		Region    bool
+		AccountID bool
+	}
+	Measures reportHandlerMeasures
+	Rollup   []string
+	TopN     *ReportTopN
+	Limit    *int
+}
+
+func TestReportHandler_Exec_ShapesGroupedRows(t *testing.T) {
+	handler := testReportHandler()
+	handler.Metadata.RollupKey = "Rollup"
+	handler.Metadata.TopNKey = "TopN"
+	handler.Metadata.Dimensions = append([]*ReportField{{Name: "Region", FieldName: "Region", Section: "Dimensions"}}, handler.Metadata.Dimensions...)
+	handler.Metadata.Measures[0].Aggregate = "sum"
+	dispatcher := &captureDispatcher{output: []map[string]interface{}{
+		{"Region": "east", "AccountID": 1, "TotalSpend": 5},
+		{"Region": "east", "AccountID": 2, "TotalSpend": 1},
+		{"Region": "east", "AccountID": 3, "TotalSpend": 2},
+		{"Region": "west", "AccountID": 4, "TotalSpend": 4},
+	}}
+	handler.Dispatcher = dispatcher
+
+	req := httptest.NewRequest(http.MethodPost, "http://localhost/v1/api/vendors/cube", nil)
+	session := &reportTestSession{http: &reportTestHTTP{request: req}, logger: &reportTestLogger{}}
+	limit := 4
+	input := reportHandlerShapedBody{Rollup: []string{"region", "accountID"}, TopN: &ReportTopN{Dimension: "accountID", Limit: 2}, Limit: &limit}
+	input.Dimensions.Region = true
+	input.Dimensions.AccountID = true
+	input.Measures.TotalSpend = true
+	ctx := context.WithValue(context.Background(), xhandler.InputKey, input)
+
+	output, err := handler.Exec(ctx, session)
+	require.NoError(t, err)
+	require.NotNil(t, dispatcher.options)
+	assert.Equal(t, "/v1/api/vendors", dispatcher.path.URI)
+	assert.Equal(t, "", dispatcher.options.Query.Get("_limit"))
+	assert.Equal(t, []map[string]interface{}{
+		{"Region": "east", "AccountID": 1.0, "TotalSpend": 5.0},
+		{"Region": "east", "AccountID": "Other", "TotalSpend": 3.0},
+		{"Region": "east", "AccountID": nil, "TotalSpend": 8.0},
+		{"Region": "west", "AccountID": 4.0, "TotalSpend": 4.0},
+	}, output)
+}
+
+func TestReportHandler_Exec_RejectsRowsOverMaxRows(t *testing.T) {
+	handler := testReportHandler()
+	handler.Metadata.RollupKey = "Rollup"
+	handler.Metadata.MaxRows = 2
+	handler.Metadata.Measures[0].Aggregate = "sum"
+	handler.Dispatcher = &captureDispatcher{output: []map[string]interface{}{
+		{"AccountID": 1, "TotalSpend": 5},
+		{"AccountID": 2, "TotalSpend": 1},
+		{"AccountID": 3, "TotalSpend": 2},
+	}}
+
+	req := httptest.NewRequest(http.MethodPost, "http://localhost/v1/api/vendors/cube", nil)
+	session := &reportTestSession{http: &reportTestHTTP{request: req}, logger: &reportTestLogger{}}
+	input := reportHandlerShapedBody{Rollup: []string{"accountID"}}
+	input.Dimensions.AccountID = true
+	input.Measures.TotalSpend = true
+	ctx := context.WithValue(context.Background(), xhandler.InputKey, input)
+
+	_, err := handler.Exec(ctx, session)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "exceeded max rows 2")
+}
+
+type queryDispatcher struct {
+	queries []url.Values
+	outputs []interface{}
+}
+
+func (d *queryDispatcher) Dispatch(ctx context.Context, path *contract.Path, options ...contract.Option) (interface{}, error) {
+	opts := contract.NewOptions(options...)
+	d.queries = append(d.queries, opts.Query)
+	output := d.outputs[0]
+	d.outputs = d.outputs[1:]
+	return output, nil
+}
+
+type reportHandlerComparisonFilters struct {
+	From string
+	To   string
+}
+
+type reportHandlerComparisonBody struct {
+	Dimensions struct {
+		Region bool
+	}
+	Measures   reportHandlerMeasures
+	Filters    reportHandlerComparisonFilters
+	Comparison *struct {
+		Shift   string
+		Filters reportHandlerComparisonFilters
+	}
+}
+
+func TestReportHandler_Exec_ComparesPeriods(t *testing.T) {
+	handler := testReportHandler()
+	handler.Metadata.ComparisonKey = "Comparison"
+	handler.Metadata.Dimensions = []*ReportField{{Name: "Region", FieldName: "Region", Section: "Dimensions"}}
+	timeSchema := state.NewSchema(reflect.TypeOf(time.Time{}))
+	handler.Metadata.Filters = []*ReportFilter{
+		{Name: "from", FieldName: "From", Parameter: &state.Parameter{Name: "from", In: state.NewQueryLocation("from"), Schema: timeSchema, DateFormat: "yyyy-MM-dd"}},
+		{Name: "to", FieldName: "To", Parameter: &state.Parameter{Name: "to", In: state.NewQueryLocation("to"), Schema: timeSchema, DateFormat: "yyyy-MM-dd"}},
+	}
+	dispatcher := &queryDispatcher{outputs: []interface{}{
+		[]map[string]interface{}{{"Region": "east", "TotalSpend": 15}, {"Region": "west", "TotalSpend": 4}},
+		[]map[string]interface{}{{"Region": "east", "TotalSpend": 10}, {"Region": "north", "TotalSpend": 7}},
+	}}
+	handler.Dispatcher = dispatcher
+
+	input := reportHandlerComparisonBody{Filters: reportHandlerComparisonFilters{From: "2024-02-01", To: "2024-02-29"}}
+	input.Dimensions.Region = true
+	input.Measures.TotalSpend = true
+	input.Comparison = &struct {
+		Shift   string
+		Filters reportHandlerComparisonFilters
+	}{Shift: "previous"}
+	req := httptest.NewRequest(http.MethodPost, "http://localhost/v1/api/vendors/cube", nil)
+	session := &reportTestSession{http: &reportTestHTTP{request: req}, logger: &reportTestLogger{}}
+	ctx := context.WithValue(context.Background(), xhandler.InputKey, input)
+
+	output, err := handler.Exec(ctx, session)
+	require.NoError(t, err)
+	require.Len(t, dispatcher.queries, 2)
+	assert.Equal(t, "2024-02-01", dispatcher.queries[0].Get("from"))
+	assert.Equal(t, "2024-01-03", dispatcher.queries[1].Get("from"))
+	assert.Equal(t, "2024-01-31", dispatcher.queries[1].Get("to"))
+	assert.Equal(t, []map[string]interface{}{
+		{"Region": "east", "TotalSpend": 15.0, "TotalSpendPrevious": 10.0, "TotalSpendDelta": 5.0, "TotalSpendDeltaPct": 50.0},
+		{"Region": "west", "TotalSpend": 4.0, "TotalSpendPrevious": nil, "TotalSpendDelta": nil, "TotalSpendDeltaPct": nil},
+	}, output)
+}
+
 func TestReportHandler_ReportInput_AcceptsUnwrappedBody(t *testing.T) {
 	handler := testReportHandler()
 	handler.BodyType = reflect.TypeOf(&reportHandlerBody{})
@@ -383,3 +526,28 @@ func TestReportHandler_BuildQuery_FilterSerialization_DataDriven(t *testing.T) {
 		})
 	}
 }
+
+func TestReportHandler_Exec_ComparisonRequiresTimeFilter(t *testing.T) {
+	handler := testReportHandler()
+	handler.Metadata.ComparisonKey = "Comparison"
+	handler.Metadata.Dimensions = []*ReportField{{Name: "Region", FieldName: "Region", Section: "Dimensions"}}
+	handler.Metadata.Filters = nil
+	dispatcher := &queryDispatcher{}
+	handler.Dispatcher = dispatcher
+
+	input := reportHandlerComparisonBody{}
+	input.Dimensions.Region = true
+	input.Measures.TotalSpend = true
+	input.Comparison = &struct {
+		Shift   string
+		Filters reportHandlerComparisonFilters
+	}{Shift: "previous"}
+	req := httptest.NewRequest(http.MethodPost, "http://localhost/v1/api/vendors/cube", nil)
+	session := &reportTestSession{http: &reportTestHTTP{request: req}, logger: &reportTestLogger{}}
+	ctx := context.WithValue(context.Background(), xhandler.InputKey, input)
+
+	_, err := handler.Exec(ctx, session)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "requires a time filter")
+	assert.Empty(t, dispatcher.queries)
+}
@@ -0,0 +1,267 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/viant/datly/repository/contract"
+	reportmodel "github.com/viant/datly/repository/report"
+	"github.com/viant/datly/service/reader"
+	"github.com/viant/xdatly/handler/response"
+)
+
+// reportShaping returns requested time grain, rollup, top-N and pivot shaping
+func (r *cubeHandler) reportShaping(input interface{}) (*reportmodel.Shaping, error) {
+	root := bodyRoot(indirectValue(reflect.ValueOf(input)), r.Metadata.BodyFieldName)
+	ret := &reportmodel.Shaping{}
+	if !root.IsValid() || root.Kind() != reflect.Struct {
+		return ret, nil
+	}
+	if grains := indirectValue(fieldByName(root, r.Metadata.TimeGrainKey)); grains.IsValid() {
+		for _, dimension := range r.Metadata.TimeDimensions() {
+			value := indirectValue(fieldByName(grains, dimension.FieldName))
+			if value.IsValid() && value.Kind() == reflect.String && value.Len() > 0 {
+				if ret.TimeGrain == nil {
+					ret.TimeGrain = map[string]string{}
+				}
+				ret.TimeGrain[dimension.Name] = value.String()
+			}
+		}
+	}
+	if zone := indirectValue(fieldByName(root, r.Metadata.TimeZoneKey)); zone.IsValid() && zone.Kind() == reflect.String && zone.Len() > 0 {
+		location, err := time.LoadLocation(zone.String())
+		if err != nil {
+			return nil, fmt.Errorf("invalid report time zone %v: %w", zone.String(), err)
+		}
+		ret.Location = location
+	}
+	if rollup := indirectValue(fieldByName(root, r.Metadata.RollupKey)); rollup.IsValid() && rollup.Kind() == reflect.Slice {
+		for i := 0; i < rollup.Len(); i++ {
+			if item := indirectValue(rollup.Index(i)); item.IsValid() && item.Kind() == reflect.String && item.Len() > 0 {
+				ret.Rollup = append(ret.Rollup, item.String())
+			}
+		}
+	}
+	if topN := fieldByName(root, r.Metadata.TopNKey); topN.IsValid() && topN.CanInterface() {
+		ret.TopN, _ = topN.Interface().(*reportmodel.TopN)
+	}
+	if pivot := fieldByName(root, r.Metadata.PivotKey); pivot.IsValid() && pivot.CanInterface() {
+		ret.Pivot, _ = pivot.Interface().(*reportmodel.Pivot)
+	}
+	return ret, nil
+}
+
+// execShaped dispatches the original component without paging, reading up to report max rows instead of view default
+// limit, shapes all grouped rows, adds comparison period measures and pages the final result
+func (r *cubeHandler) execShaped(ctx context.Context, request *http.Request, input interface{}, query url.Values, shaping *reportmodel.Shaping, comparison reflect.Value) (interface{}, error) {
+	root := bodyRoot(indirectValue(reflect.ValueOf(input)), r.Metadata.BodyFieldName)
+	dimensions := r.selectedFields(root, r.Metadata.Dimensions)
+	measures := r.selectedFields(root, r.Metadata.Measures)
+	if err := shaping.Validate(dimensions, measures); err != nil {
+		return nil, err
+	}
+	if comparison.IsValid() {
+		if shaping.Pivot != nil && shaping.Pivot.Dimension != "" {
+			return nil, fmt.Errorf("report comparison can not be combined with pivot")
+		}
+		if len(measures) == 0 {
+			return nil, fmt.Errorf("report comparison requires at least one measure")
+		}
+	}
+	limitKey := r.selectorName(r.Original.View.Selector.LimitParameter, "_limit")
+	offsetKey := r.selectorName(r.Original.View.Selector.OffsetParameter, "_offset")
+	limit, _ := strconv.Atoi(query.Get(limitKey))
+	offset, _ := strconv.Atoi(query.Get(offsetKey))
+	query.Del(limitKey)
+	query.Del(offsetKey)
+	ctx = reader.WithRowLimit(ctx, r.Metadata.RowLimit()+1)
+	var previousQuery url.Values
+	var shift reportmodel.Shift
+	if comparison.IsValid() {
+		var err error
+		if previousQuery, shift, err = r.comparisonQuery(root, comparison, query); err != nil {
+			return nil, err
+		}
+	}
+
+	output, rows, replace, err := r.dispatchRows(ctx, request, query)
+	if err != nil || rows == nil {
+		return output, err
+	}
+	if rows, err = reportmodel.Shape(rows, dimensions, measures, shaping); err != nil {
+		return nil, err
+	}
+	if comparison.IsValid() {
+		_, previous, _, err := r.dispatchRows(ctx, request, previousQuery)
+		if err != nil {
+			return nil, err
+		}
+		if previous, err = reportmodel.Shape(previous, dimensions, measures, shaping); err != nil {
+			return nil, err
+		}
+		rows = reportmodel.Compare(rows, previous, dimensions, measures, shaping, shift)
+	}
+	if offset > 0 {
+		if offset > len(rows) {
+			offset = len(rows)
+		}
+		rows = rows[offset:]
+	}
+	if limit > 0 && limit < len(rows) {
+		rows = rows[:limit]
+	}
+	return replace(rows), nil
+}
+
+// dispatchRows dispatches the original component and returns decoded output with its grouped rows, it fails when
+// grouped rows exceed report max rows
+func (r *cubeHandler) dispatchRows(ctx context.Context, request *http.Request, query url.Values) (interface{}, []map[string]interface{}, func([]map[string]interface{}) interface{}, error) {
+	internalReq := r.internalRequest(ctx, request, query)
+	output, err := r.Dispatcher.Dispatch(ctx, r.Path, contract.WithRequest(internalReq), contract.WithQuery(query), contract.WithHeader(internalReq.Header))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	data, err := json.Marshal(output)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	var decoded interface{}
+	if err = json.Unmarshal(data, &decoded); err != nil {
+		return nil, nil, nil, err
+	}
+	rows, replace := reportRows(decoded)
+	if limit := r.Metadata.RowLimit(); len(rows) > limit {
+		return nil, nil, nil, response.NewError(http.StatusUnprocessableEntity, fmt.Sprintf("report grouped rows exceeded max rows %v, narrow report filters or dimensions", limit))
+	}
+	return decoded, rows, replace, nil
+}
+
+// reportComparison returns comparison section if requested
+func (r *cubeHandler) reportComparison(input interface{}) reflect.Value {
+	root := bodyRoot(indirectValue(reflect.ValueOf(input)), r.Metadata.BodyFieldName)
+	comparison := indirectValue(fieldByName(root, r.Metadata.ComparisonKey))
+	if !comparison.IsValid() || comparison.Kind() != reflect.Struct {
+		return reflect.Value{}
+	}
+	if shift := fieldByName(comparison, "Shift"); shift.IsValid() && shift.Kind() == reflect.String && shift.Len() > 0 {
+		return comparison
+	}
+	if filters := fieldByName(comparison, r.Metadata.FiltersKey); filters.IsValid() && !filters.IsZero() {
+		return comparison
+	}
+	return reflect.Value{}
+}
+
+// comparisonQuery returns comparison period query with time dimension shift: explicit comparison filters override
+// report filters, otherwise time filters are shifted to the comparison period
+func (r *cubeHandler) comparisonQuery(root reflect.Value, comparison reflect.Value, query url.Values) (url.Values, reportmodel.Shift, error) {
+	result := url.Values{}
+	for k, v := range query {
+		result[k] = append([]string{}, v...)
+	}
+	overrides := url.Values{}
+	if err := r.collectFilters(comparison, overrides); err != nil {
+		return nil, nil, err
+	}
+	for k, v := range overrides {
+		result[k] = v
+	}
+	shift := fieldByName(comparison, "Shift")
+	if !shift.IsValid() || shift.Kind() != reflect.String || shift.Len() == 0 {
+		return result, nil, nil
+	}
+	filters := indirectValue(fieldByName(root, r.Metadata.FiltersKey))
+	var keys []string
+	var values []string
+	layout := ""
+	for _, filter := range r.Metadata.Filters {
+		if filter.Parameter == nil || filter.Parameter.In == nil || !isTimeFilter(filter) {
+			continue
+		}
+		key := filter.Parameter.In.Name
+		if _, overridden := overrides[key]; overridden || len(result[key]) == 0 {
+			continue
+		}
+		if _, field, ok := fieldByNameDetails(filters, filter.FieldName); ok && layout == "" {
+			if formatTag, err := resolveFilterFormatTag(filter, field); err == nil && formatTag != nil {
+				layout = formatTag.TimeLayout
+			}
+		}
+		keys = append(keys, key)
+		values = append(values, result[key]...)
+	}
+	if len(keys) == 0 {
+		return nil, nil, fmt.Errorf("report comparison shift %v requires a time filter", shift.String())
+	}
+	shifted, err := reportmodel.ShiftRange(shift.String(), values, layout)
+	if err != nil {
+		return nil, nil, err
+	}
+	shiftTime, err := reportmodel.NewShift(shift.String(), values, layout)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, key := range keys {
+		count := len(result[key])
+		result[key], shifted = shifted[:count], shifted[count:]
+	}
+	return result, shiftTime, nil
+}
+
+func isTimeFilter(filter *ReportFilter) bool {
+	schemaType := filter.SchemaType()
+	for schemaType != nil && schemaType.Kind() == reflect.Ptr {
+		schemaType = schemaType.Elem()
+	}
+	return schemaType == timeType
+}
+
+func (r *cubeHandler) selectedFields(root reflect.Value, fields []*ReportField) []*ReportField {
+	var result []*ReportField
+	for _, field := range fields {
+		section := indirectValue(fieldByName(root, field.Section))
+		value := fieldByName(section, field.FieldName)
+		if value.IsValid() && value.Kind() == reflect.Bool && value.Bool() {
+			result = append(result, field)
+		}
+	}
+	return result
+}
+
+// reportRows returns grouped rows, either top level collection or first collection of an output envelope,
+// with function placing shaped rows back into the output
+func reportRows(output interface{}) ([]map[string]interface{}, func([]map[string]interface{}) interface{}) {
+	toRows := func(items []interface{}) []map[string]interface{} {
+		var result []map[string]interface{}
+		for _, item := range items {
+			if row, ok := item.(map[string]interface{}); ok {
+				result = append(result, row)
+			}
+		}
+		return result
+	}
+	if envelope, ok := output.(map[string]interface{}); ok {
+		keys := make([]string, 0, len(envelope))
+		for key := range envelope {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if items, ok := envelope[key].([]interface{}); ok {
+				return toRows(items), func(rows []map[string]interface{}) interface{} {
+					envelope[key] = rows
+					return envelope
+				}
+			}
+		}
+	}
+	items, _ := output.([]interface{})
+	return toRows(items), func(rows []map[string]interface{}) interface{} { return rows }
+}
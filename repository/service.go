@@ -66,6 +66,11 @@ func (s *Service) Container() *path.Container {
 	return &s.paths.Container
 }
 
+// ScheduledPaths returns paths with enabled cron schedule, the list reflects synchronised repository changes
+func (s *Service) ScheduledPaths() []*path.Path {
+	return s.paths.ScheduledPaths()
+}
+
 // SyncChanges checks if resource, plugin or components have changes
 // if so it would increase individual or all component/paths version number resulting in lazy reload
 func (s *Service) SyncChanges(ctx context.Context) (bool, error) {
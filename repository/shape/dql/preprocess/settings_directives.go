@@ -6,6 +6,7 @@ import (
 	"strconv"
 	"strings"
 
+	_ "github.com/viant/datly/gateway/router/marshal/hypermedia" //registers JSON:API and HAL formats
 	"github.com/viant/datly/repository/content"
 	dqldiag "github.com/viant/datly/repository/shape/dql/diag"
 	dqlshape "github.com/viant/datly/repository/shape/dql/shape"
@@ -732,6 +733,10 @@ func parseFormatDirectiveCalls(calls []directiveCall) []string {
 			result = append(result, content.JSONDataFormatTabular)
 		case content.JSONFormat, content.XMLFormat, content.CSVFormat, content.JSONDataFormatTabular:
 			result = append(result, raw)
+		default:
+			if _, ok := content.Formats().Lookup(raw); ok {
+				result = append(result, raw)
+			}
 		}
 	}
 	return result
@@ -0,0 +1,198 @@
+package migration
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+const (
+	// AddColumn represents entity field without table column
+	AddColumn = Kind("add")
+	// DropColumn represents table column without entity field
+	DropColumn = Kind("drop")
+	// AlterColumn represents column with incompatible entity and table type
+	AlterColumn = Kind("alter")
+)
+
+type (
+	// Kind represents migration change kind
+	Kind string
+
+	// Dialect generates dialect specific column DDL, it is implemented by dbms providers
+	Dialect interface {
+		AddColumn(table, column, dataType string, nullable bool) string
+		DropColumn(table, column string) string
+		AlterColumn(table, column, dataType string, nullable bool) string
+	}
+
+	// Column represents entity or table column
+	Column struct {
+		Name     string
+		DataType string
+		Nullable bool
+		Type     reflect.Type //entity field type, empty for table columns
+	}
+
+	// Change represents column change
+	Change struct {
+		Column   string
+		Kind     Kind
+		Declared string `json:",omitempty"`
+		Actual   string `json:",omitempty"`
+		Skipped  bool   `json:",omitempty"` //drop without drop flag
+	}
+
+	// Migration represents table migration with up and down statements
+	Migration struct {
+		Table   string
+		Changes []*Change
+		Up      []string
+		Down    []string
+	}
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// Diff compares entity columns with table columns, table columns without entity field are dropped only with drop flag
+func Diff(table string, declared, actual []*Column, dialect Dialect, drop bool) *Migration {
+	ret := &Migration{Table: table}
+	actualByName := index(actual)
+	declaredByName := index(declared)
+	for _, column := range declared {
+		match, ok := actualByName[strings.ToLower(column.Name)]
+		if !ok {
+			ret.Changes = append(ret.Changes, &Change{Column: column.Name, Kind: AddColumn, Declared: column.definition()})
+			ret.Up = append(ret.Up, dialect.AddColumn(table, column.Name, column.DataType, column.Nullable))
+			ret.Down = append(ret.Down, dialect.DropColumn(table, column.Name))
+			continue
+		}
+		if column.Type == nil || !isChanged(goCategory(column.Type), dbCategory(match.DataType)) {
+			continue
+		}
+		ret.Changes = append(ret.Changes, &Change{Column: column.Name, Kind: AlterColumn, Declared: column.definition(), Actual: match.definition()})
+		ret.Up = append(ret.Up, dialect.AlterColumn(table, match.Name, column.DataType, column.Nullable))
+		ret.Down = append(ret.Down, dialect.AlterColumn(table, match.Name, match.DataType, match.Nullable))
+	}
+	for _, column := range actual {
+		if _, ok := declaredByName[strings.ToLower(column.Name)]; ok {
+			continue
+		}
+		ret.Changes = append(ret.Changes, &Change{Column: column.Name, Kind: DropColumn, Actual: column.definition(), Skipped: !drop})
+		if drop {
+			ret.Up = append(ret.Up, dialect.DropColumn(table, column.Name))
+			ret.Down = append(ret.Down, dialect.AddColumn(table, column.Name, column.DataType, column.Nullable))
+		}
+	}
+	reverse(ret.Down)
+	return ret
+}
+
+// UpScript returns up migration script
+func (m *Migration) UpScript() string {
+	return script(m.Up)
+}
+
+// DownScript returns down migration script, it reverts up statements in reverse order
+func (m *Migration) DownScript() string {
+	return script(m.Down)
+}
+
+// Report returns dry run report
+func (m *Migration) Report() string {
+	builder := strings.Builder{}
+	builder.WriteString(m.Table)
+	if len(m.Changes) == 0 {
+		builder.WriteString(": up to date")
+	}
+	for _, change := range m.Changes {
+		builder.WriteString(fmt.Sprintf("\n\t%v %v", change.Kind, change.Column))
+		if change.Declared != "" {
+			builder.WriteString(" entity: " + change.Declared)
+		}
+		if change.Actual != "" {
+			builder.WriteString(" table: " + change.Actual)
+		}
+		if change.Skipped {
+			builder.WriteString(" (skipped, enable drop to generate)")
+		}
+	}
+	return builder.String()
+}
+
+func script(statements []string) string {
+	if len(statements) == 0 {
+		return ""
+	}
+	return strings.Join(statements, ";\n") + ";\n"
+}
+
+func reverse(statements []string) {
+	for i, j := 0, len(statements)-1; i < j; i, j = i+1, j-1 {
+		statements[i], statements[j] = statements[j], statements[i]
+	}
+}
+
+func index(columns []*Column) map[string]*Column {
+	ret := make(map[string]*Column, len(columns))
+	for _, column := range columns {
+		ret[strings.ToLower(column.Name)] = column
+	}
+	return ret
+}
+
+func (c *Column) definition() string {
+	if c.Nullable {
+		return c.DataType
+	}
+	return c.DataType + " NOT NULL"
+}
+
+// isChanged returns true for different known categories, i.e. JSON encoded struct fields are not compared
+func isChanged(declared, actual string) bool {
+	if declared == "bool" && actual == "integer" { //i.e. MySQL TINYINT
+		return false
+	}
+	return declared != "other" && actual != "other" && declared != actual
+}
+
+// goCategory returns entity field type category
+func goCategory(rType reflect.Type) string {
+	for rType.Kind() == reflect.Ptr {
+		rType = rType.Elem()
+	}
+	if rType == timeType {
+		return "time"
+	}
+	switch rType.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "float"
+	case reflect.Bool:
+		return "bool"
+	case reflect.String:
+		return "string"
+	}
+	return "other"
+}
+
+// dbCategory returns table column type category
+func dbCategory(dataType string) string {
+	dataType = strings.ToUpper(dataType)
+	switch {
+	case strings.Contains(dataType, "BOOL") || dataType == "BIT" || strings.HasPrefix(dataType, "TINYINT(1)"):
+		return "bool"
+	case strings.Contains(dataType, "INT"):
+		return "integer"
+	case strings.Contains(dataType, "DEC") || strings.Contains(dataType, "NUMERIC") || strings.Contains(dataType, "FLOAT") || strings.Contains(dataType, "DOUBLE") || strings.Contains(dataType, "REAL"):
+		return "float"
+	case strings.Contains(dataType, "CHAR") || strings.Contains(dataType, "TEXT") || strings.Contains(dataType, "STRING"):
+		return "string"
+	case strings.Contains(dataType, "DATE") || strings.Contains(dataType, "TIME"):
+		return "time"
+	}
+	return "other"
+}
@@ -0,0 +1,57 @@
+package migration
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testDialect struct{}
+
+func (d testDialect) AddColumn(table, column, dataType string, nullable bool) string {
+	return "ADD " + table + "." + column + " " + (&Column{DataType: dataType, Nullable: nullable}).definition()
+}
+
+func (d testDialect) DropColumn(table, column string) string {
+	return "DROP " + table + "." + column
+}
+
+func (d testDialect) AlterColumn(table, column, dataType string, nullable bool) string {
+	return "ALTER " + table + "." + column + " " + (&Column{DataType: dataType, Nullable: nullable}).definition()
+}
+
+func TestDiff(t *testing.T) {
+	declared := []*Column{
+		{Name: "ID", DataType: "INTEGER", Type: reflect.TypeOf(0)},
+		{Name: "NAME", DataType: "TEXT", Nullable: true, Type: reflect.TypeOf(new(string))},
+		{Name: "PRICE", DataType: "DECIMAL", Type: reflect.TypeOf(0.0)},
+		{Name: "ACTIVE", DataType: "BOOLEAN", Type: reflect.TypeOf(true)},
+		{Name: "CREATED", DataType: "DATETIME", Nullable: true, Type: reflect.TypeOf(&time.Time{})},
+	}
+	actual := []*Column{
+		{Name: "id", DataType: "bigint"},
+		{Name: "NAME", DataType: "varchar(255)", Nullable: true},
+		{Name: "PRICE", DataType: "varchar(20)", Nullable: true},
+		{Name: "ACTIVE", DataType: "tinyint"},
+		{Name: "LEGACY", DataType: "int", Nullable: true},
+	}
+	migration := Diff("PRODUCT", declared, actual, testDialect{}, false)
+	assert.Equal(t, []*Change{
+		{Column: "PRICE", Kind: AlterColumn, Declared: "DECIMAL NOT NULL", Actual: "varchar(20)"},
+		{Column: "CREATED", Kind: AddColumn, Declared: "DATETIME"},
+		{Column: "LEGACY", Kind: DropColumn, Actual: "int", Skipped: true},
+	}, migration.Changes)
+	assert.Equal(t, "ALTER PRODUCT.PRICE DECIMAL NOT NULL;\nADD PRODUCT.CREATED DATETIME;\n", migration.UpScript())
+	assert.Equal(t, "DROP PRODUCT.CREATED;\nALTER PRODUCT.PRICE varchar(20);\n", migration.DownScript())
+	assert.Contains(t, migration.Report(), "drop LEGACY table: int (skipped")
+
+	migration = Diff("PRODUCT", declared, actual, testDialect{}, true)
+	assert.Equal(t, "ADD PRODUCT.LEGACY int", migration.Down[0])
+	assert.Equal(t, "DROP PRODUCT.LEGACY", migration.Up[2])
+
+	migration = Diff("PRODUCT", declared[:1], actual[:1], testDialect{}, false)
+	assert.Empty(t, migration.UpScript())
+	assert.Equal(t, "PRODUCT: up to date", migration.Report())
+}
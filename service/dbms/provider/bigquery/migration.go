@@ -0,0 +1,36 @@
+package bigquery
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/viant/sqlx/io"
+)
+
+// ColumnType returns column database type and nullability
+func (s *SQLSource) ColumnType(column io.Column) (string, bool, error) {
+	dataType, _, err := s.normalizeType(column)
+	return dataType, column.ScanType().Kind() == reflect.Ptr, err
+}
+
+// AddColumn returns add column statement, BigQuery adds only nullable columns
+func (s *SQLSource) AddColumn(table, column, dataType string, _ bool) string {
+	return "ALTER TABLE " + s.tableName(table) + " ADD COLUMN `" + column + "` " + dataType
+}
+
+// DropColumn returns drop column statement
+func (s *SQLSource) DropColumn(table, column string) string {
+	return "ALTER TABLE " + s.tableName(table) + " DROP COLUMN `" + column + "`"
+}
+
+// AlterColumn returns column data type change statement, BigQuery supports only widening conversions
+func (s *SQLSource) AlterColumn(table, column, dataType string, _ bool) string {
+	return "ALTER TABLE " + s.tableName(table) + " ALTER COLUMN `" + column + "` SET DATA TYPE " + dataType
+}
+
+func (s *SQLSource) tableName(table string) string {
+	if strings.Contains(table, ".") {
+		return "`" + table + "`"
+	}
+	return "`" + s.dataset + "." + table + "`"
+}
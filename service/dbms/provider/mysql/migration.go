@@ -0,0 +1,42 @@
+package mysql
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/viant/sqlx/io"
+)
+
+// ColumnType returns column database type and nullability
+func (s *SQLSource) ColumnType(column io.Column) (string, bool, error) {
+	dataType, _, err := s.normalizeType(column)
+	return dataType, column.ScanType().Kind() == reflect.Ptr, err
+}
+
+// AddColumn returns add column statement
+func (s *SQLSource) AddColumn(table, column, dataType string, nullable bool) string {
+	return "ALTER TABLE " + s.tableName(table) + " ADD COLUMN " + s.columnDefinition(column, dataType, nullable)
+}
+
+// DropColumn returns drop column statement
+func (s *SQLSource) DropColumn(table, column string) string {
+	return "ALTER TABLE " + s.tableName(table) + " DROP COLUMN `" + column + "`"
+}
+
+// AlterColumn returns modify column statement
+func (s *SQLSource) AlterColumn(table, column, dataType string, nullable bool) string {
+	return "ALTER TABLE " + s.tableName(table) + " MODIFY COLUMN " + s.columnDefinition(column, dataType, nullable)
+}
+
+// tableName returns quoted table name, database qualified name parts are quoted separately
+func (s *SQLSource) tableName(table string) string {
+	return "`" + strings.ReplaceAll(table, ".", "`.`") + "`"
+}
+
+func (s *SQLSource) columnDefinition(column, dataType string, nullable bool) string {
+	ret := "`" + column + "` " + dataType
+	if !nullable {
+		ret += " NOT NULL"
+	}
+	return ret
+}
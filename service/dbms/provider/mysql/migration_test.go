@@ -0,0 +1,15 @@
+package mysql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSQLSource_AddColumn(t *testing.T) {
+	source := NewSQLSource()
+	assert.Equal(t, "ALTER TABLE `PRODUCT` ADD COLUMN `NAME` VARCHAR(255)", source.AddColumn("PRODUCT", "NAME", "VARCHAR(255)", true))
+	assert.Equal(t, "ALTER TABLE `shop`.`PRODUCT` ADD COLUMN `ID` INT NOT NULL", source.AddColumn("shop.PRODUCT", "ID", "INT", false))
+	assert.Equal(t, "ALTER TABLE `shop`.`PRODUCT` DROP COLUMN `ID`", source.DropColumn("shop.PRODUCT", "ID"))
+	assert.Equal(t, "ALTER TABLE `shop`.`PRODUCT` MODIFY COLUMN `ID` BIGINT NOT NULL", source.AlterColumn("shop.PRODUCT", "ID", "BIGINT", false))
+}
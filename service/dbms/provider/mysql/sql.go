@@ -34,9 +34,7 @@ func (s *SQLSource) CreateTable(recordType reflect.Type, tableName string, tagNa
 
 	buffer := bytes.NewBuffer(nil)
 	buffer.WriteString("CREATE TABLE IF NOT EXISTS ")
-	buffer.WriteByte('`')
-	buffer.WriteString(tableName)
-	buffer.WriteByte('`')
+	buffer.WriteString(s.tableName(tableName))
 	buffer.WriteString(" (\n")
 	for i, column := range columns {
 		if i != 0 {
@@ -0,0 +1,76 @@
+package dbms
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
+	"github.com/viant/datly/view"
+	"github.com/viant/sqlx/metadata/info"
+)
+
+// Bind returns SQL with ? placeholders replaced with connector dialect placeholders, i.e. $1, $2 for postgres
+func Bind(ctx context.Context, connector *view.Connector, SQL string) (string, error) {
+	dialect, err := connector.Dialect(ctx)
+	if err != nil {
+		return "", err
+	}
+	return BindDialect(dialect, SQL), nil
+}
+
+// BindDialect returns SQL with ? placeholders replaced with dialect placeholders, SQL can not use ? in literals
+func BindDialect(dialect *info.Dialect, SQL string) string {
+	if dialect == nil || !strings.Contains(SQL, "?") {
+		return SQL
+	}
+	next := dialect.PlaceholderGetter()
+	builder := strings.Builder{}
+	for i := 0; i < len(SQL); i++ {
+		if SQL[i] == '?' {
+			builder.WriteString(next())
+			continue
+		}
+		builder.WriteByte(SQL[i])
+	}
+	return builder.String()
+}
+
+// IsDuplicateKey returns true if driver error code indicates unique or primary key violation
+func IsDuplicateKey(driver string, err error) bool {
+	if err == nil {
+		return false
+	}
+	switch strings.ToLower(driver) {
+	case "mysql":
+		var mysqlErr *mysql.MySQLError
+		//1062: ER_DUP_ENTRY
+		return errors.As(err, &mysqlErr) && mysqlErr.Number == 1062
+	case "postgres", "pgx":
+		//23505: unique_violation
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) {
+			return pqErr.Code == "23505"
+		}
+		var stateErr interface{ SQLState() string }
+		return errors.As(err, &stateErr) && stateErr.SQLState() == "23505"
+	case "sqlite", "sqlite3":
+		return strings.Contains(err.Error(), "UNIQUE constraint failed")
+	case "oracle", "godror":
+		//ORA-00001: unique constraint violated
+		var codeErr interface{ Code() int }
+		if errors.As(err, &codeErr) {
+			return codeErr.Code() == 1
+		}
+		return strings.HasPrefix(err.Error(), "ORA-00001")
+	case "sqlserver", "mssql":
+		//2627: unique constraint, 2601: unique index
+		var numberErr interface{ SQLErrorNumber() int32 }
+		if errors.As(err, &numberErr) {
+			number := numberErr.SQLErrorNumber()
+			return number == 2627 || number == 2601
+		}
+	}
+	return false
+}
@@ -10,3 +10,13 @@ type DBSource interface {
 	Db(ctx context.Context) (*sql.DB, error)
 	Dialect(ctx context.Context) (*info.Dialect, error)
 }
+
+// Limiter represents DBSource with concurrent statement limit
+type Limiter interface {
+	Acquire(ctx context.Context) (func(), error)
+}
+
+// SecretRefresher represents DBSource reloading rotated credentials once database rejected them
+type SecretRefresher interface {
+	RefreshedSecret(ctx context.Context, err error) bool
+}
@@ -17,8 +17,19 @@ func NewViewDBSource(view *view.View) *ViewDBSource {
 	}
 }
 
-func (v *ViewDBSource) Db(_ context.Context) (*sql.DB, error) {
-	return v.view.Db()
+// Db returns primary database, session reads are pinned to the primary for connector ReadYourWritesMs
+func (v *ViewDBSource) Db(ctx context.Context) (*sql.DB, error) {
+	return v.view.WriterDb(ctx)
+}
+
+// Acquire reserves view connector query slot
+func (v *ViewDBSource) Acquire(ctx context.Context) (func(), error) {
+	return v.view.Connector.Acquire(ctx)
+}
+
+// RefreshedSecret reloads view connector secret when database rejected credentials
+func (v *ViewDBSource) RefreshedSecret(ctx context.Context, err error) bool {
+	return v.view.Connector.RefreshedSecret(ctx, err)
 }
 
 func (v *ViewDBSource) Dialect(ctx context.Context) (*info.Dialect, error) {
@@ -0,0 +1,58 @@
+package executor
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/viant/datly/service/executor/expand"
+	"github.com/viant/datly/view"
+)
+
+func TestViewDBSource_WritePinsReads(t *testing.T) {
+	view.ResetConnectionHealth()
+	defer view.ResetConnectionHealth()
+	primaryDSN := "file:executor_ryw_primary?mode=memory&cache=shared"
+	db, err := sql.Open("sqlite3", primaryDSN)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if _, err = db.Exec("CREATE TABLE audit (id INTEGER PRIMARY KEY)"); err != nil {
+		t.Fatal(err)
+	}
+	primary := &view.Connection{DBConfig: view.DBConfig{Name: "primary", Driver: "sqlite3", DSN: primaryDSN}}
+	replica := &view.Connection{DBConfig: view.DBConfig{Name: "replica", Driver: "sqlite3", DSN: "file:executor_ryw_replica?mode=memory&cache=shared", Role: view.ConnectionRoleReplica}}
+	connector := &view.Connector{
+		Connection:       view.Connection{DBConfig: view.DBConfig{Name: "executor_ryw"}},
+		Connections:      []*view.Connection{primary, replica},
+		ReadYourWritesMs: 60000,
+	}
+	aView := &view.View{Name: "audit", Connector: connector}
+	ctx := view.WithConnectorSession(context.Background(), "user1")
+
+	conn, err := connector.ReadConnection(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if conn.Name != "replica" {
+		t.Fatalf("expected read from replica before write, but had %v", conn.Name)
+	}
+	stmt := &expand.SQLStatment{SQL: "INSERT INTO audit(id) VALUES (1)"}
+	if err = New().ExecuteStmts(ctx, NewViewDBSource(aView), &executionTestIterator{items: []interface{}{stmt}}); err != nil {
+		t.Fatal(err)
+	}
+	if conn, err = connector.ReadConnection(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if conn.Name != "primary" {
+		t.Fatalf("expected read after executor write pinned to primary, but had %v", conn.Name)
+	}
+	if conn, err = connector.ReadConnection(view.WithConnectorSession(context.Background(), "user2")); err != nil {
+		t.Fatal(err)
+	}
+	if conn.Name != "replica" {
+		t.Fatalf("expected other session read from replica, but had %v", conn.Name)
+	}
+}
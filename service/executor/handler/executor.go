@@ -58,8 +58,9 @@ type (
 	}
 
 	DbSource struct {
-		db      *sql.DB
-		dialect *info.Dialect
+		db        *sql.DB
+		dialect   *info.Dialect
+		connector *view.Connector
 	}
 )
 
@@ -71,6 +72,16 @@ func (d *DbSource) Db(_ context.Context) (*sql.DB, error) {
 	return d.db, nil
 }
 
+// Acquire reserves unit connector query slot
+func (d *DbSource) Acquire(ctx context.Context) (func(), error) {
+	return d.connector.Acquire(ctx)
+}
+
+// RefreshedSecret reloads unit connector secret when database rejected credentials
+func (d *DbSource) RefreshedSecret(ctx context.Context, err error) bool {
+	return d.connector != nil && d.connector.RefreshedSecret(ctx, err)
+}
+
 func (d *DbSource) Dialect(ctx context.Context) (*info.Dialect, error) {
 	if d.dialect != nil {
 		return d.dialect, nil
@@ -80,6 +91,24 @@ func (d *DbSource) Dialect(ctx context.Context) (*info.Dialect, error) {
 	return d.dialect, err
 }
 
+// writerDb returns unit primary database, session reads are pinned to the primary after the write
+func writerDb(ctx context.Context, unit *expand.DataUnit) (*sql.DB, error) {
+	if writer, ok := unit.MetaSource.(interface {
+		WriterDb(ctx context.Context) (*sql.DB, error)
+	}); ok {
+		return writer.WriterDb(ctx)
+	}
+	return unit.MetaSource.Db()
+}
+
+// unitConnector returns unit view connector
+func unitConnector(unit *expand.DataUnit) *view.Connector {
+	if aView, ok := unit.MetaSource.(*view.View); ok {
+		return aView.Connector
+	}
+	return nil
+}
+
 func NewExecutor(aView *view.View, aSession *session.Session, opts ...Option) *Executor {
 	return &Executor{
 		view:     aView,
@@ -249,7 +278,7 @@ func (e *Executor) newSqlService(options *sqlx.Options) (sqlx.Sqlx, error) {
 		options.WithTx = e.tx
 	}
 	if e.scope != nil && options.WithTx != nil {
-		db, dbErr := unit.MetaSource.Db()
+		db, dbErr := writerDb(e.invocationContext(context.Background()), unit)
 		if dbErr != nil {
 			return nil, dbErr
 		}
@@ -389,8 +418,8 @@ func (e *Executor) Execute(ctx context.Context) error {
 	}
 	e.unitMu.Unlock()
 	for _, unit := range units {
-		dbSource := &DbSource{}
-		dbSource.db, _ = unit.MetaSource.Db()
+		dbSource := &DbSource{connector: unitConnector(unit)}
+		dbSource.db, _ = writerDb(ctx, unit)
 		unitOptions := []executor.DBOption(nil)
 		if tx := unitTx[unit]; tx != nil {
 			unitOptions = append(unitOptions, executor.WithTx(tx))
@@ -485,11 +514,11 @@ func (e *Executor) ensureUnitOfWork(ctx context.Context) error {
 	if e.unitsByDB == nil {
 		e.unitsByDB = map[*sql.DB]*expand.DataUnit{}
 	}
-	return e.attachBuffer(e.dataUnit, func(context.Context) (*sql.DB, error) {
+	return e.attachBuffer(e.dataUnit, func(ctx context.Context) (*sql.DB, error) {
 		if e.view == nil || e.view.Connector == nil {
 			return nil, fmt.Errorf("view connector is required")
 		}
-		return e.view.Connector.DB()
+		return e.view.Connector.WriterDB(ctx)
 	}, e.tx)
 }
 
@@ -514,7 +543,7 @@ func (e *Executor) attachBuffer(unit *expand.DataUnit, resolve func(context.Cont
 		if err != nil {
 			return err
 		}
-		source := &DbSource{db: db}
+		source := &DbSource{db: db, connector: unitConnector(unit)}
 		return executor.New().ExecuteStmts(ctx, source, &sqlxIterator{toExecute: []interface{}{value}}, executor.WithTx(transaction))
 	})
 	buffer.SetBatchExecutor(func(ctx context.Context, transaction *sql.Tx, values []any) error {
@@ -522,7 +551,7 @@ func (e *Executor) attachBuffer(unit *expand.DataUnit, resolve func(context.Cont
 		if err != nil {
 			return err
 		}
-		return executor.New().ExecuteStmts(ctx, &DbSource{db: db}, newSqlxIterator(values), executor.WithTx(transaction))
+		return executor.New().ExecuteStmts(ctx, &DbSource{db: db, connector: unitConnector(unit)}, newSqlxIterator(values), executor.WithTx(transaction))
 	})
 	e.buffers[unit] = buffer
 	unit.SetTransactionRunner(e.ctx, func(fn func(*sql.Tx) error) error {
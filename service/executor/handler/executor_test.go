@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/viant/datly/service/executor"
+	"github.com/viant/datly/service/executor/expand"
+	"github.com/viant/datly/view"
+)
+
+func TestDbSource_AcquiresConnectorSlot(t *testing.T) {
+	connector := view.NewConnector("executor_bulkhead", "sqlite3", t.TempDir()+"/bulkhead.db")
+	connector.MaxConcurrentQueries = 1
+	connector.QueueTimeoutMs = 10
+	db, err := connector.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if _, err = db.Exec("CREATE TABLE audit (id INTEGER PRIMARY KEY)"); err != nil {
+		t.Fatal(err)
+	}
+	unit := expand.NewDataUnit(&view.View{Connector: connector})
+	source := &DbSource{db: db, connector: unitConnector(unit)}
+	release, err := connector.Acquire(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	stmt := &expand.SQLStatment{SQL: "INSERT INTO audit(id) VALUES (1)"}
+	err = executor.New().ExecuteStmts(context.Background(), source, newSqlxIterator([]interface{}{stmt}))
+	if !errors.Is(err, view.ErrConnectorBusy) {
+		t.Fatalf("expected executor to wait for connector slot, but had %v", err)
+	}
+	release()
+	if err = executor.New().ExecuteStmts(context.Background(), source, newSqlxIterator([]interface{}{stmt})); err != nil {
+		t.Fatal(err)
+	}
+}
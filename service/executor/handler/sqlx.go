@@ -10,8 +10,10 @@ import (
 	"github.com/viant/datly/service/executor/uow"
 	"github.com/viant/datly/view"
 	"github.com/viant/sqlx/io/config"
+	"github.com/viant/sqlx/io/load"
 	"github.com/viant/sqlx/io/read"
 	"github.com/viant/sqlx/metadata/info"
+	"github.com/viant/sqlx/option"
 	"github.com/viant/xdatly/handler/sqlx"
 	"github.com/viant/xdatly/handler/validator"
 	"github.com/viant/xunsafe"
@@ -44,8 +46,34 @@ type (
 	}
 )
 
+// Load bulk loads data into table, load joins component transaction if one was started
 func (s *Service) Load(ctx context.Context, tableName string, data interface{}) error {
-	panic("function not yet implemented")
+	db, err := s.Db(ctx)
+	if err != nil {
+		return err
+	}
+	loader, err := load.New(ctx, db, tableName)
+	if err != nil {
+		return err
+	}
+	if s.buffer != nil {
+		return s.buffer.UseTransaction(ctx, func(tx *sql.Tx) error {
+			_, err := loader.Exec(ctx, data, tx)
+			return err
+		})
+	}
+	var options []option.Option
+	tx := s.options.WithTx
+	if tx == nil {
+		tx = s.tx
+	}
+	if tx != nil {
+		options = append(options, tx)
+	}
+	if _, err = loader.Exec(ctx, data, options...); err != nil {
+		s.RefreshedSecret(ctx, err)
+	}
+	return err
 }
 
 func (s *sqlxIterator) HasNext() bool {
@@ -167,20 +195,47 @@ func (s *Service) Db(ctx context.Context) (*sql.DB, error) {
 		return s.db, nil
 	}
 
-	db, err := s.openDBConnection()
+	db, err := s.openDBConnection(ctx)
 	s.db = db
 
 	return db, err
 }
 
-func (s *Service) openDBConnection() (*sql.DB, error) {
+// Acquire reserves service connector query slot
+func (s *Service) Acquire(ctx context.Context) (func(), error) {
+	return s.connector().Acquire(ctx)
+}
+
+// RefreshedSecret reloads service connector secret when database rejected credentials
+func (s *Service) RefreshedSecret(ctx context.Context, err error) bool {
+	connector := s.connector()
+	if connector == nil || !connector.RefreshedSecret(ctx, err) {
+		return false
+	}
+	s.db = nil
+	return true
+}
+
+// connector returns connector backing service database, nil for caller supplied database
+func (s *Service) connector() *view.Connector {
+	if s.options.WithConnector != "" {
+		connector, _ := s.connectors.Lookup(s.options.WithConnector)
+		return connector
+	}
+	if s.options.WithDb != nil {
+		return nil
+	}
+	return s.mainConnector
+}
+
+func (s *Service) openDBConnection(ctx context.Context) (*sql.DB, error) {
 	if s.options.WithConnector != "" {
 		connector, err := s.connectors.Lookup(s.options.WithConnector)
 		if err != nil {
 			return nil, err
 		}
 
-		db, err := connector.DB()
+		db, err := connector.WriterDB(ctx)
 		return db, err
 	}
 
@@ -189,7 +244,7 @@ func (s *Service) openDBConnection() (*sql.DB, error) {
 	}
 
 	if s.mainConnector != nil {
-		return s.mainConnector.DB()
+		return s.mainConnector.WriterDB(ctx)
 	}
 
 	return nil, fmt.Errorf("unspecified DB source")
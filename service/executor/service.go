@@ -78,6 +78,8 @@ func (e *Executor) Execute(ctx context.Context, aView *view.View, options ...Opt
 // TODO: remove reflection
 // TODO: customize global batch collector
 func (e *Executor) Exec(ctx context.Context, sess *Session, options ...DBOption) error {
+	ctx, cancel := sess.View.WithTimeout(ctx)
+	defer cancel()
 	state, data, err := e.sqlBuilder.Build(ctx, sess.View, sess.Lookup(sess.View), sess.SessionHandler, sess.DataUnit)
 	if state != nil {
 		sess.TemplateState = state
@@ -132,14 +134,21 @@ func (e *Executor) ExecuteStmts(ctx context.Context, dbSource DBSource, it StmtI
 	for _, apply := range options {
 		apply(ops)
 	}
+	if limiter, ok := dbSource.(Limiter); ok {
+		release, err := limiter.Acquire(ctx)
+		if err != nil {
+			return err
+		}
+		defer release()
+	}
 	db, err := dbSource.Db(ctx)
 	if err != nil {
-		return err
+		return refreshSecret(ctx, dbSource, err)
 	}
 
 	dialect, err := dbSource.Dialect(ctx)
 	if err != nil {
-		return err
+		return refreshSecret(ctx, dbSource, err)
 	}
 
 	aTx := newLazyTx(db, ops.tx)
@@ -149,11 +158,19 @@ func (e *Executor) ExecuteStmts(ctx context.Context, dbSource DBSource, it StmtI
 		next := it.Next()
 		if err = e.execData(ctx, aDbSession, next, db); err != nil {
 			_ = aTx.RollbackIfNeeded()
-			return err
+			return refreshSecret(ctx, dbSource, err)
 		}
 	}
 
-	return aTx.CommitIfNeeded()
+	return refreshSecret(ctx, dbSource, aTx.CommitIfNeeded())
+}
+
+// refreshSecret reloads db source credentials when database rejected them, subsequent executions use rotated secret
+func refreshSecret(ctx context.Context, dbSource DBSource, err error) error {
+	if refresher, ok := dbSource.(SecretRefresher); ok && err != nil {
+		refresher.RefreshedSecret(ctx, err)
+	}
+	return err
 }
 
 func (e *Executor) execData(ctx context.Context, sess *dbSession, data interface{}, db *sql.DB) error {
@@ -335,7 +352,9 @@ func (e *Executor) executeStatement(ctx context.Context, tx *sql.Tx, stmt *expan
 		if sess.logger != nil {
 			sess.logger.LogDatabaseErr(ctx, databaseLogView(ctx), stmt.SQL, err, stmt.Args...)
 		}
-
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return fmt.Errorf("error occured while connecting to database: %w", ctxErr)
+		}
 		err = fmt.Errorf("error occured while connecting to database")
 	}
 
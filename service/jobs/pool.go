@@ -0,0 +1,245 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/viant/datly/shared/logging"
+	"github.com/viant/xdatly/handler/async"
+	"github.com/viant/xdatly/handler/logger"
+)
+
+const (
+	defaultWorkers      = 4
+	defaultVisibility   = 5 * time.Minute
+	defaultPollInterval = time.Second
+)
+
+type (
+	// Handler executes leased job
+	Handler func(ctx context.Context, job *async.Job) error
+
+	// Pool represents local in-process worker pool, it leases jobs from all registered queues
+	Pool struct {
+		handler      Handler
+		workers      int
+		visibility   time.Duration
+		pollInterval time.Duration
+		owner        string
+		slots        chan struct{}
+		logger       logger.Logger
+		wg           sync.WaitGroup
+	}
+
+	// PoolOption represents pool option
+	PoolOption func(p *Pool)
+
+	progressKey string
+
+	progressReporter struct {
+		queue *Queue
+		ID    string
+	}
+)
+
+var jobProgressKey = progressKey("jobProgress")
+
+// WithWorkers sets max concurrently running jobs
+func WithWorkers(workers int) PoolOption {
+	return func(p *Pool) {
+		if workers > 0 {
+			p.workers = workers
+		}
+	}
+}
+
+// WithVisibilityTimeout sets job lease duration, lease is extended while job is running
+func WithVisibilityTimeout(visibility time.Duration) PoolOption {
+	return func(p *Pool) {
+		if visibility > 0 {
+			p.visibility = visibility
+		}
+	}
+}
+
+// WithPollInterval sets queue poll interval
+func WithPollInterval(interval time.Duration) PoolOption {
+	return func(p *Pool) {
+		if interval > 0 {
+			p.pollInterval = interval
+		}
+	}
+}
+
+// WithLogger sets pool logger
+func WithLogger(logger logger.Logger) PoolOption {
+	return func(p *Pool) {
+		if logger != nil {
+			p.logger = logger
+		}
+	}
+}
+
+// ReportProgress reports running job progress, it's no-op outside queue worker context
+func ReportProgress(ctx context.Context, percent int, message string) error {
+	reporter, ok := ctx.Value(jobProgressKey).(*progressReporter)
+	if !ok {
+		return nil
+	}
+	if percent < 0 {
+		percent = 0
+	}
+	if percent > 100 {
+		percent = 100
+	}
+	return reporter.queue.Progress(ctx, reporter.ID, percent, message)
+}
+
+// Start polls queues until context is cancelled
+func (p *Pool) Start(ctx context.Context) {
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		p.loop(ctx)
+	}()
+}
+
+// Wait waits for polling and running jobs to finish once pool context is cancelled
+func (p *Pool) Wait() {
+	p.wg.Wait()
+}
+
+func (p *Pool) loop(ctx context.Context) {
+	ticker := time.NewTicker(p.pollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		for _, queue := range Queues() {
+			free := cap(p.slots) - len(p.slots)
+			if free == 0 {
+				break
+			}
+			entries, err := queue.Lease(ctx, p.owner, free, p.visibility)
+			if err != nil {
+				p.logger.Warnc(ctx, "job queue lease failed", "error", err)
+			}
+			for _, entry := range entries {
+				p.slots <- struct{}{}
+				p.wg.Add(1)
+				go p.run(ctx, queue, entry)
+			}
+		}
+	}
+}
+
+// run executes leased entry, failed job is retried with backoff or dead-lettered
+func (p *Pool) run(ctx context.Context, queue *Queue, entry *QueueEntry) {
+	defer func() {
+		<-p.slots
+		p.wg.Done()
+	}()
+	service := queue.Service()
+	if entry.Attempts > entry.MaxAttempts { //lease expired on the last attempt
+		entry.Attempts = entry.MaxAttempts
+		p.fail(ctx, queue, entry, nil, fmt.Errorf("job %v lease expired after %v attempts", entry.ID, entry.MaxAttempts))
+		return
+	}
+	job, err := service.JobById(ctx, entry.ID)
+	if err == nil && job == nil {
+		err = fmt.Errorf("job %v not found", entry.ID)
+	}
+	if err != nil {
+		p.fail(ctx, queue, entry, nil, err)
+		return
+	}
+	runCtx, cancel := context.WithCancel(context.WithValue(ctx, jobProgressKey, &progressReporter{queue: queue, ID: entry.ID}))
+	defer cancel()
+	lost := make(chan bool, 1)
+	done := make(chan bool)
+	go p.heartbeat(runCtx, queue, entry, cancel, lost, done)
+	err = p.handler(runCtx, job)
+	close(done)
+	if <-lost { //cancelled or leased by other worker
+		return
+	}
+	if err == nil {
+		if err = queue.Complete(ctx, entry); err != nil {
+			p.logger.Warnc(ctx, "failed to complete job queue entry", "job", entry.ID, "error", err)
+		}
+		return
+	}
+	p.fail(ctx, queue, entry, job, err)
+}
+
+// heartbeat extends lease while job is running, lost lease cancels job context
+func (p *Pool) heartbeat(ctx context.Context, queue *Queue, entry *QueueEntry, cancel context.CancelFunc, lost chan bool, done chan bool) {
+	ticker := time.NewTicker(p.visibility / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			lost <- false
+			return
+		case <-ticker.C:
+			if extended, err := queue.Extend(ctx, entry, p.visibility); err == nil && !extended {
+				cancel()
+				<-done
+				lost <- true
+				return
+			}
+		}
+	}
+}
+
+func (p *Pool) fail(ctx context.Context, queue *Queue, entry *QueueEntry, job *async.Job, cause error) {
+	dead, err := queue.Fail(ctx, entry, cause)
+	if err != nil {
+		p.logger.Warnc(ctx, "failed to update job queue entry", "job", entry.ID, "error", err)
+	}
+	if job == nil {
+		if job, _ = queue.Service().JobById(ctx, entry.ID); job == nil {
+			return
+		}
+	}
+	message := cause.Error()
+	job.Error = &message
+	job.Status = string(async.StatusPending)
+	if dead {
+		job.Status = string(StatusDead)
+		p.logger.Warnc(ctx, "job dead-lettered", "job", entry.ID, "attempts", entry.Attempts, "error", cause)
+	}
+	if err = queue.Service().UpdateJob(ctx, job); err != nil {
+		p.logger.Warnc(ctx, "failed to update job", "job", entry.ID, "error", err)
+		return
+	}
+	if dead {
+		queue.notify(ctx, job)
+	}
+}
+
+// NewPool creates worker pool
+func NewPool(handler Handler, opts ...PoolOption) *Pool {
+	host, _ := os.Hostname()
+	ret := &Pool{
+		handler:      handler,
+		workers:      defaultWorkers,
+		visibility:   defaultVisibility,
+		pollInterval: defaultPollInterval,
+		owner:        fmt.Sprintf("%v:%v", host, os.Getpid()),
+	}
+	for _, opt := range opts {
+		opt(ret)
+	}
+	if ret.logger == nil {
+		ret.logger = logging.New(logging.INFO, nil)
+	}
+	ret.slots = make(chan struct{}, ret.workers)
+	return ret
+}
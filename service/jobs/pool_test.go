@@ -0,0 +1,26 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/viant/xdatly/handler/async"
+)
+
+func TestPool_Wait(t *testing.T) {
+	pool := NewPool(func(ctx context.Context, job *async.Job) error { return nil }, WithPollInterval(time.Millisecond))
+	ctx, cancel := context.WithCancel(context.Background())
+	pool.Start(ctx)
+	cancel()
+	done := make(chan bool)
+	go func() {
+		pool.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("pool did not stop after context was cancelled")
+	}
+}
@@ -0,0 +1,396 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/viant/datly/service/dbms"
+	"github.com/viant/xdatly/handler/async"
+)
+
+// QueueTable defines durable job queue table
+const QueueTable = "DATLY_JOB_QUEUE"
+
+const (
+	QueueStatusPending   = "PENDING"
+	QueueStatusLeased    = "LEASED"
+	QueueStatusDone      = "DONE"
+	QueueStatusDead      = "DEAD"
+	QueueStatusCancelled = "CANCELLED"
+)
+
+const (
+	// StatusDead represents job which exhausted all retry attempts
+	StatusDead async.Status = "DEAD"
+	// StatusCancelled represents job cancelled by client
+	StatusCancelled async.Status = "CANCELLED"
+)
+
+const (
+	defaultBackoffMs    = 1000
+	defaultMaxBackoffMs = 5 * 60 * 1000
+)
+
+type (
+	// RetryPolicy defines component job retries, backoff doubles with each attempt
+	RetryPolicy struct {
+		MaxAttempts  int `json:",omitempty" yaml:",omitempty"` // total attempts, 1 by default (no retries)
+		BackoffMs    int `json:",omitempty" yaml:",omitempty"` // first retry delay, 1s by default
+		MaxBackoffMs int `json:",omitempty" yaml:",omitempty"` // max retry delay, 5 min by default
+	}
+
+	// QueueEntry represents job queue row, worker leases entry for the visibility timeout
+	QueueEntry struct {
+		ID              string `sqlx:"primaryKey=true,name=ID"`
+		Status          string
+		Attempts        int
+		MaxAttempts     int
+		BackoffMs       int
+		MaxBackoffMs    int
+		Owner           string
+		LeaseExpiry     int // unix ms
+		NextRunTime     int // unix ms
+		Progress        int
+		ProgressMessage string
+		Error           string
+		CreationTime    time.Time
+		UpdateTime      time.Time
+	}
+
+	// Queue represents durable DB backed job queue
+	Queue struct {
+		service   *Service
+		dbms      *dbms.Service
+		mux       sync.Mutex
+		ready     bool
+		observers map[string]*componentObserver
+	}
+
+	// Observer is notified when queue moves job to dead letter or cancelled state
+	Observer func(ctx context.Context, job *async.Job)
+
+	componentObserver struct {
+		method   string
+		URI      string
+		observer Observer
+	}
+
+	queueRegistry struct {
+		mux   sync.RWMutex
+		index map[string]*Queue
+	}
+)
+
+var aQueues = &queueRegistry{index: map[string]*Queue{}}
+
+// QueueFor returns a queue shared by all components using the same jobs connector
+func QueueFor(service *Service) *Queue {
+	aQueues.mux.Lock()
+	defer aQueues.mux.Unlock()
+	if ret, ok := aQueues.index[service.connector.Name]; ok {
+		return ret
+	}
+	ret := &Queue{service: service, dbms: dbms.New(), observers: map[string]*componentObserver{}}
+	aQueues.index[service.connector.Name] = ret
+	return ret
+}
+
+// Queues returns registered queues
+func Queues() []*Queue {
+	aQueues.mux.RLock()
+	defer aQueues.mux.RUnlock()
+	var result = make([]*Queue, 0, len(aQueues.index))
+	for _, queue := range aQueues.index {
+		result = append(result, queue)
+	}
+	return result
+}
+
+// Attempts returns max job attempts
+func (p *RetryPolicy) Attempts() int {
+	if p == nil || p.MaxAttempts <= 0 {
+		return 1
+	}
+	return p.MaxAttempts
+}
+
+// Backoff returns delay before the next attempt
+func (e *QueueEntry) Backoff() time.Duration {
+	backoff := e.BackoffMs
+	if backoff <= 0 {
+		backoff = defaultBackoffMs
+	}
+	limit := e.MaxBackoffMs
+	if limit <= 0 {
+		limit = defaultMaxBackoffMs
+	}
+	for i := 1; i < e.Attempts && backoff < limit; i++ {
+		backoff *= 2
+	}
+	if backoff > limit {
+		backoff = limit
+	}
+	return time.Duration(backoff) * time.Millisecond
+}
+
+// Exhausted returns true if entry has no more attempts left
+func (e *QueueEntry) Exhausted() bool {
+	return e.Attempts >= e.MaxAttempts
+}
+
+// Init creates queue table if needed
+func (q *Queue) Init(ctx context.Context) error {
+	q.mux.Lock()
+	defer q.mux.Unlock()
+	if q.ready {
+		return nil
+	}
+	_, err := q.dbms.EnsureTable(ctx, q.service.connector, &dbms.TableConfig{
+		RecordType:     reflect.TypeOf(&QueueEntry{}),
+		TableName:      QueueTable,
+		CreateIfNeeded: true,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to initialise job queue: %w", err)
+	}
+	q.ready = true
+	return nil
+}
+
+// Observe registers component job observer, component is identified by its path method and URI template
+func (q *Queue) Observe(method, URI string, observer Observer) {
+	q.mux.Lock()
+	defer q.mux.Unlock()
+	q.observers[strings.ToUpper(method)+":"+URI] = &componentObserver{method: method, URI: URI, observer: observer}
+}
+
+// notify notifies observer of the component issuing job, literal URI segments take precedence over path parameters
+func (q *Queue) notify(ctx context.Context, job *async.Job) {
+	q.mux.Lock()
+	var observer Observer
+	best := -1
+	for _, candidate := range q.observers {
+		if wildcards, ok := candidate.matches(job); ok && (best == -1 || wildcards < best) {
+			observer, best = candidate.observer, wildcards
+		}
+	}
+	q.mux.Unlock()
+	if observer != nil {
+		observer(ctx, job)
+	}
+}
+
+// matches returns true with number of matched path parameters if job request was issued for observed component
+func (o *componentObserver) matches(job *async.Job) (int, bool) {
+	if !strings.EqualFold(o.method, job.Method) {
+		return 0, false
+	}
+	URI := job.URI
+	if index := strings.IndexAny(URI, "?#"); index != -1 {
+		URI = URI[:index]
+	}
+	expected := strings.Split(strings.Trim(o.URI, "/"), "/")
+	actual := strings.Split(strings.Trim(URI, "/"), "/")
+	if len(expected) != len(actual) {
+		return 0, false
+	}
+	wildcards := 0
+	for i, segment := range expected {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			wildcards++
+			continue
+		}
+		if segment != actual[i] {
+			return 0, false
+		}
+	}
+	return wildcards, true
+}
+
+// Service returns jobs service
+func (q *Queue) Service() *Service {
+	return q.service
+}
+
+// Enqueue adds job to the queue
+func (q *Queue) Enqueue(ctx context.Context, job *async.Job, policy *RetryPolicy) error {
+	db, err := q.service.connector.DB()
+	if err != nil {
+		return err
+	}
+	entry := &QueueEntry{ID: job.ID, Status: QueueStatusPending, MaxAttempts: policy.Attempts(), CreationTime: time.Now().UTC()}
+	if policy != nil {
+		entry.BackoffMs = policy.BackoffMs
+		entry.MaxBackoffMs = policy.MaxBackoffMs
+	}
+	entry.UpdateTime = entry.CreationTime
+	entry.NextRunTime = int(entry.CreationTime.UnixMilli())
+	SQL, err := q.bind(ctx, "INSERT INTO "+QueueTable+"(ID, Status, Attempts, MaxAttempts, BackoffMs, MaxBackoffMs, Owner, LeaseExpiry, NextRunTime, Progress, ProgressMessage, Error, CreationTime, UpdateTime) VALUES(?, ?, 0, ?, ?, ?, '', 0, ?, 0, '', '', ?, ?)")
+	if err != nil {
+		return err
+	}
+	_, err = db.ExecContext(ctx, SQL,
+		entry.ID, entry.Status, entry.MaxAttempts, entry.BackoffMs, entry.MaxBackoffMs, entry.NextRunTime, entry.CreationTime, entry.UpdateTime)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue job %v: %w", job.ID, err)
+	}
+	return nil
+}
+
+// Lease claims up to limit due entries for the owner, expired leases are claimed again
+func (q *Queue) Lease(ctx context.Context, owner string, limit int, visibility time.Duration) ([]*QueueEntry, error) {
+	db, err := q.service.connector.DB()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	nowMs := int(now.UnixMilli())
+	SQL, err := q.bind(ctx, "SELECT ID FROM "+QueueTable+" WHERE (Status = ? AND NextRunTime <= ?) OR (Status = ? AND LeaseExpiry < ?) ORDER BY NextRunTime LIMIT ?")
+	if err != nil {
+		return nil, err
+	}
+	rows, err := db.QueryContext(ctx, SQL, QueueStatusPending, nowMs, QueueStatusLeased, nowMs, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lease jobs: %w", err)
+	}
+	var candidates []string
+	for rows.Next() {
+		var ID string
+		if err = rows.Scan(&ID); err != nil {
+			_ = rows.Close()
+			return nil, err
+		}
+		candidates = append(candidates, ID)
+	}
+	_ = rows.Close()
+	var result []*QueueEntry
+	expiry := int(now.Add(visibility).UnixMilli())
+	leaseSQL, err := q.bind(ctx, "UPDATE "+QueueTable+" SET Status = ?, Owner = ?, LeaseExpiry = ?, Attempts = Attempts + 1, UpdateTime = ? WHERE ID = ? AND ((Status = ? AND NextRunTime <= ?) OR (Status = ? AND LeaseExpiry < ?))")
+	if err != nil {
+		return nil, err
+	}
+	for _, ID := range candidates {
+		claimed, err := db.ExecContext(ctx, leaseSQL,
+			QueueStatusLeased, owner, expiry, now, ID, QueueStatusPending, nowMs, QueueStatusLeased, nowMs)
+		if err != nil {
+			return result, fmt.Errorf("failed to lease job %v: %w", ID, err)
+		}
+		if affected, _ := claimed.RowsAffected(); affected == 0 {
+			continue //leased by other worker
+		}
+		entry, err := q.entry(ctx, db, ID)
+		if err != nil {
+			return result, err
+		}
+		if entry != nil {
+			result = append(result, entry)
+		}
+	}
+	return result, nil
+}
+
+// Extend extends owned lease, false means the lease was lost or the job was cancelled
+func (q *Queue) Extend(ctx context.Context, entry *QueueEntry, visibility time.Duration) (bool, error) {
+	expiry := int(time.Now().Add(visibility).UnixMilli())
+	return q.exec(ctx, "UPDATE "+QueueTable+" SET LeaseExpiry = ?, UpdateTime = ? WHERE ID = ? AND Owner = ? AND Status = ?", expiry, time.Now().UTC(), entry.ID, entry.Owner, QueueStatusLeased)
+}
+
+// Complete marks owned entry as done
+func (q *Queue) Complete(ctx context.Context, entry *QueueEntry) error {
+	_, err := q.exec(ctx, "UPDATE "+QueueTable+" SET Status = ?, Progress = 100, LeaseExpiry = 0, UpdateTime = ? WHERE ID = ? AND Owner = ? AND Status = ?", QueueStatusDone, time.Now().UTC(), entry.ID, entry.Owner, QueueStatusLeased)
+	return err
+}
+
+// Fail schedules retry with exponential backoff or moves entry to dead letter state once attempts are exhausted
+func (q *Queue) Fail(ctx context.Context, entry *QueueEntry, cause error) (bool, error) {
+	message := ""
+	if cause != nil {
+		message = cause.Error()
+	}
+	now := time.Now().UTC()
+	if entry.Exhausted() {
+		_, err := q.exec(ctx, "UPDATE "+QueueTable+" SET Status = ?, Error = ?, LeaseExpiry = 0, UpdateTime = ? WHERE ID = ? AND Owner = ? AND Status = ?", QueueStatusDead, message, now, entry.ID, entry.Owner, QueueStatusLeased)
+		return true, err
+	}
+	next := int(now.Add(entry.Backoff()).UnixMilli())
+	_, err := q.exec(ctx, "UPDATE "+QueueTable+" SET Status = ?, Error = ?, NextRunTime = ?, LeaseExpiry = 0, UpdateTime = ? WHERE ID = ? AND Owner = ? AND Status = ?", QueueStatusPending, message, next, now, entry.ID, entry.Owner, QueueStatusLeased)
+	return false, err
+}
+
+// Cancel cancels pending or running job, running job context is cancelled with the next lease extension
+func (q *Queue) Cancel(ctx context.Context, ID string) (bool, error) {
+	cancelled, err := q.exec(ctx, "UPDATE "+QueueTable+" SET Status = ?, UpdateTime = ? WHERE ID = ? AND Status IN (?, ?)", QueueStatusCancelled, time.Now().UTC(), ID, QueueStatusPending, QueueStatusLeased)
+	if err != nil || !cancelled {
+		return cancelled, err
+	}
+	job, err := q.service.JobById(ctx, ID)
+	if err != nil || job == nil {
+		return true, err
+	}
+	job.Status = string(StatusCancelled)
+	endTime := time.Now()
+	job.EndTime = &endTime
+	if err = q.service.UpdateJob(ctx, job); err != nil {
+		return true, err
+	}
+	q.notify(ctx, job)
+	return true, nil
+}
+
+// Progress updates job progress
+func (q *Queue) Progress(ctx context.Context, ID string, percent int, message string) error {
+	_, err := q.exec(ctx, "UPDATE "+QueueTable+" SET Progress = ?, ProgressMessage = ?, UpdateTime = ? WHERE ID = ?", percent, message, time.Now().UTC(), ID)
+	return err
+}
+
+// Entry returns queue entry or nil if not found
+func (q *Queue) Entry(ctx context.Context, ID string) (*QueueEntry, error) {
+	db, err := q.service.connector.DB()
+	if err != nil {
+		return nil, err
+	}
+	return q.entry(ctx, db, ID)
+}
+
+func (q *Queue) entry(ctx context.Context, db *sql.DB, ID string) (*QueueEntry, error) {
+	SQL, err := q.bind(ctx, "SELECT ID, Status, Attempts, MaxAttempts, BackoffMs, MaxBackoffMs, Owner, LeaseExpiry, NextRunTime, Progress, ProgressMessage, Error FROM "+QueueTable+" WHERE ID = ?")
+	if err != nil {
+		return nil, err
+	}
+	entry := &QueueEntry{}
+	err = db.QueryRowContext(ctx, SQL, ID).
+		Scan(&entry.ID, &entry.Status, &entry.Attempts, &entry.MaxAttempts, &entry.BackoffMs, &entry.MaxBackoffMs, &entry.Owner, &entry.LeaseExpiry, &entry.NextRunTime, &entry.Progress, &entry.ProgressMessage, &entry.Error)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read job queue entry %v: %w", ID, err)
+	}
+	return entry, nil
+}
+
+func (q *Queue) exec(ctx context.Context, SQL string, args ...interface{}) (bool, error) {
+	db, err := q.service.connector.DB()
+	if err != nil {
+		return false, err
+	}
+	if SQL, err = q.bind(ctx, SQL); err != nil {
+		return false, err
+	}
+	result, err := db.ExecContext(ctx, SQL, args...)
+	if err != nil {
+		return false, err
+	}
+	affected, _ := result.RowsAffected()
+	return affected > 0, nil
+}
+
+// bind replaces ? placeholders with jobs connector dialect placeholders
+func (q *Queue) bind(ctx context.Context, SQL string) (string, error) {
+	return dbms.Bind(ctx, q.service.connector, SQL)
+}
@@ -0,0 +1,139 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/viant/datly/service/dbms"
+	"github.com/viant/datly/view"
+	"github.com/viant/xdatly/handler/async"
+)
+
+func TestQueueEntry_Backoff(t *testing.T) {
+	var testCases = []struct {
+		description string
+		entry       *QueueEntry
+		expect      time.Duration
+	}{
+		{description: "default first retry", entry: &QueueEntry{Attempts: 1}, expect: time.Second},
+		{description: "exponential", entry: &QueueEntry{Attempts: 3, BackoffMs: 100}, expect: 400 * time.Millisecond},
+		{description: "capped", entry: &QueueEntry{Attempts: 10, BackoffMs: 100, MaxBackoffMs: 1500}, expect: 1500 * time.Millisecond},
+	}
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.expect, testCase.entry.Backoff(), testCase.description)
+	}
+}
+
+func TestRetryPolicy_Attempts(t *testing.T) {
+	var policy *RetryPolicy
+	assert.Equal(t, 1, policy.Attempts())
+	assert.Equal(t, 3, (&RetryPolicy{MaxAttempts: 3}).Attempts())
+	entry := &QueueEntry{Attempts: 2, MaxAttempts: 3}
+	assert.False(t, entry.Exhausted())
+	entry.Attempts++
+	assert.True(t, entry.Exhausted())
+}
+
+func TestReportProgress_NoWorker(t *testing.T) {
+	assert.Nil(t, ReportProgress(context.Background(), 50, "half way"))
+}
+
+func newTestQueue(t *testing.T) *Queue {
+	connector := view.NewConnector("job_queue", "sqlite3", t.TempDir()+"/queue.db")
+	db, err := connector.DB()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+	_, err = db.Exec("CREATE TABLE " + QueueTable + ` (ID TEXT PRIMARY KEY, Status TEXT, Attempts INTEGER, MaxAttempts INTEGER,
+		BackoffMs INTEGER, MaxBackoffMs INTEGER, Owner TEXT, LeaseExpiry INTEGER, NextRunTime INTEGER, Progress INTEGER,
+		ProgressMessage TEXT, Error TEXT, CreationTime DATETIME, UpdateTime DATETIME)`)
+	require.NoError(t, err)
+	return &Queue{service: New(connector), dbms: dbms.New(), observers: map[string]*componentObserver{}, ready: true}
+}
+
+func TestQueue_LeaseCompleteFail(t *testing.T) {
+	queue := newTestQueue(t)
+	ctx := context.Background()
+	require.NoError(t, queue.Enqueue(ctx, &async.Job{ID: "j1"}, &RetryPolicy{MaxAttempts: 2, BackoffMs: 60000}))
+	require.NoError(t, queue.Enqueue(ctx, &async.Job{ID: "j2"}, nil))
+
+	leased, err := queue.Lease(ctx, "w1", 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, leased, 2)
+	for _, entry := range leased {
+		assert.Equal(t, QueueStatusLeased, entry.Status)
+		assert.Equal(t, "w1", entry.Owner)
+		assert.Equal(t, 1, entry.Attempts)
+	}
+	again, err := queue.Lease(ctx, "w2", 10, time.Minute)
+	require.NoError(t, err)
+	assert.Empty(t, again, "leased entries should not be leased by other worker")
+
+	byID := map[string]*QueueEntry{}
+	for _, entry := range leased {
+		byID[entry.ID] = entry
+	}
+	dead, err := queue.Fail(ctx, byID["j1"], errors.New("timeout"))
+	require.NoError(t, err)
+	assert.False(t, dead, "entry with attempts left should be retried")
+	entry, err := queue.Entry(ctx, "j1")
+	require.NoError(t, err)
+	assert.Equal(t, QueueStatusPending, entry.Status)
+	assert.Equal(t, "timeout", entry.Error)
+	assert.Greater(t, entry.NextRunTime, int(time.Now().UnixMilli()), "retry should be delayed by backoff")
+
+	require.NoError(t, queue.Complete(ctx, byID["j2"]))
+	entry, err = queue.Entry(ctx, "j2")
+	require.NoError(t, err)
+	assert.Equal(t, QueueStatusDone, entry.Status)
+	assert.Equal(t, 100, entry.Progress)
+
+	require.NoError(t, queue.Enqueue(ctx, &async.Job{ID: "j4"}, nil))
+	leased, err = queue.Lease(ctx, "w1", 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, leased, 1)
+	dead, err = queue.Fail(ctx, leased[0], errors.New("failed"))
+	require.NoError(t, err)
+	assert.True(t, dead, "entry with exhausted attempts should be dead-lettered")
+	entry, err = queue.Entry(ctx, "j4")
+	require.NoError(t, err)
+	assert.Equal(t, QueueStatusDead, entry.Status)
+}
+
+func TestQueue_LeaseExpiry(t *testing.T) {
+	queue := newTestQueue(t)
+	ctx := context.Background()
+	require.NoError(t, queue.Enqueue(ctx, &async.Job{ID: "j1"}, &RetryPolicy{MaxAttempts: 3}))
+	leased, err := queue.Lease(ctx, "w1", 1, -time.Millisecond)
+	require.NoError(t, err)
+	require.Len(t, leased, 1)
+
+	reclaimed, err := queue.Lease(ctx, "w2", 1, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, reclaimed, 1, "expired lease should be claimed again")
+	assert.Equal(t, "w2", reclaimed[0].Owner)
+	assert.Equal(t, 2, reclaimed[0].Attempts)
+
+	extended, err := queue.Extend(ctx, leased[0], time.Minute)
+	require.NoError(t, err)
+	assert.False(t, extended, "worker with lost lease should not extend it")
+	require.NoError(t, queue.Complete(ctx, leased[0]))
+	entry, err := queue.Entry(ctx, "j1")
+	require.NoError(t, err)
+	assert.Equal(t, QueueStatusLeased, entry.Status, "worker with lost lease should not complete entry")
+}
+
+func TestQueue_Notify(t *testing.T) {
+	queue := &Queue{observers: map[string]*componentObserver{}}
+	var notified []string
+	queue.Observe("GET", "/v1/api/report/{id}", func(ctx context.Context, job *async.Job) { notified = append(notified, "report") })
+	queue.Observe("GET", "/v1/api/report/summary", func(ctx context.Context, job *async.Job) { notified = append(notified, "summary") })
+	queue.notify(context.Background(), &async.Job{Method: "GET", URI: "/v1/api/report/1?fields=id", MainView: "report"})
+	queue.notify(context.Background(), &async.Job{Method: "GET", URI: "/v1/api/report/summary", MainView: "report"})
+	queue.notify(context.Background(), &async.Job{Method: "POST", URI: "/v1/api/report/1", MainView: "report"})
+	assert.Equal(t, []string{"report", "summary"}, notified)
+}
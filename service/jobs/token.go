@@ -0,0 +1,42 @@
+package jobs
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+)
+
+var (
+	tokenSecret     []byte
+	tokenSecretLock sync.RWMutex
+)
+
+// SetTokenSecret sets job access token HMAC secret, shared across instances so tokens issued by one instance are accepted by others
+func SetTokenSecret(secret []byte) {
+	tokenSecretLock.Lock()
+	defer tokenSecretLock.Unlock()
+	tokenSecret = secret
+}
+
+// Token returns job access token or empty string when token secret is not configured
+func Token(jobID string) string {
+	tokenSecretLock.RLock()
+	secret := tokenSecret
+	tokenSecretLock.RUnlock()
+	if len(secret) == 0 || jobID == "" {
+		return ""
+	}
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(jobID))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// ValidToken returns true if token was issued for the job
+func ValidToken(jobID, token string) bool {
+	expected := Token(jobID)
+	if expected == "" || token == "" {
+		return false
+	}
+	return hmac.Equal([]byte(expected), []byte(token))
+}
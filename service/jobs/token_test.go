@@ -0,0 +1,19 @@
+package jobs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidToken(t *testing.T) {
+	SetTokenSecret(nil)
+	assert.False(t, ValidToken("job-1", Token("job-1")), "token requires secret")
+	SetTokenSecret([]byte("secret"))
+	defer SetTokenSecret(nil)
+	token := Token("job-1")
+	assert.NotEmpty(t, token)
+	assert.True(t, ValidToken("job-1", token))
+	assert.False(t, ValidToken("job-2", token))
+	assert.False(t, ValidToken("job-1", ""))
+}
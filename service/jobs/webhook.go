@@ -0,0 +1,368 @@
+package jobs
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/viant/datly/service/dbms"
+	"github.com/viant/scy"
+	"github.com/viant/xdatly/handler/async"
+)
+
+// WebhookTable defines webhook deliveries log table
+const WebhookTable = "DATLY_WEBHOOK_DELIVERIES"
+
+const (
+	DeliveryStatusPending   = "PENDING"
+	DeliveryStatusDelivered = "DELIVERED"
+	DeliveryStatusFailed    = "FAILED"
+)
+
+const (
+	defaultSignatureHeader = "X-Datly-Signature"
+	defaultWebhookAttempts = 3
+	defaultWebhookTimeout  = 10 * time.Second
+)
+
+type (
+	// Webhook represents async job completion callback, payload is signed with HMAC SHA256 when secret is configured
+	Webhook struct {
+		URL         string        `json:",omitempty" yaml:",omitempty"` // URL template, supports ${jobId}, ${matchKey}, ${status}, ${view}
+		Secret      *scy.Resource `json:",omitempty" yaml:",omitempty"` // signing secret
+		Header      string        `json:",omitempty" yaml:",omitempty"` // signature header, X-Datly-Signature by default
+		MaxAttempts int           `json:",omitempty" yaml:",omitempty"` // 3 by default
+		BackoffMs   int           `json:",omitempty" yaml:",omitempty"` // first retry delay, doubles with each attempt, 1s by default
+		TimeoutMs   int           `json:",omitempty" yaml:",omitempty"` // delivery timeout, 10s by default
+		secret      []byte
+		service     *Service
+		dbms        *dbms.Service
+		client      *http.Client
+		mux         sync.Mutex
+		ready       bool
+	}
+
+	// WebhookPayload represents job completion notification
+	WebhookPayload struct {
+		JobID          string
+		MatchKey       string `json:",omitempty"`
+		Status         string
+		View           string     `json:",omitempty"`
+		ResultLocation string     `json:",omitempty"`
+		Error          string     `json:",omitempty"`
+		CreationTime   time.Time  `json:",omitempty"`
+		EndTime        *time.Time `json:",omitempty"`
+	}
+
+	// WebhookDelivery represents webhook delivery log row
+	WebhookDelivery struct {
+		ID           string `sqlx:"primaryKey=true,name=ID"`
+		JobID        string
+		View         string
+		URL          string
+		Payload      string
+		Status       string
+		Attempts     int
+		StatusCode   int
+		Error        string
+		CreationTime time.Time
+		UpdateTime   time.Time
+	}
+)
+
+// IsTerminal returns true if job reached final status
+func IsTerminal(status string) bool {
+	switch status {
+	case string(async.StatusDone), string(async.StatusError), string(StatusDead), string(StatusCancelled):
+		return true
+	}
+	return false
+}
+
+// Init loads signing secret and creates deliveries table if needed
+func (w *Webhook) Init(ctx context.Context, service *Service) error {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+	if w.ready {
+		return nil
+	}
+	if w.URL == "" {
+		return fmt.Errorf("webhook URL was empty")
+	}
+	if w.Secret != nil {
+		secret, err := scy.New().Load(ctx, w.Secret)
+		if err != nil {
+			return fmt.Errorf("failed to load webhook secret: %w", err)
+		}
+		w.secret = []byte(secret.String())
+	}
+	w.service = service
+	w.dbms = dbms.New()
+	timeout := defaultWebhookTimeout
+	if w.TimeoutMs > 0 {
+		timeout = time.Duration(w.TimeoutMs) * time.Millisecond
+	}
+	w.client = &http.Client{Timeout: timeout}
+	if _, err := w.dbms.EnsureTable(ctx, service.connector, &dbms.TableConfig{
+		RecordType:     reflect.TypeOf(&WebhookDelivery{}),
+		TableName:      WebhookTable,
+		CreateIfNeeded: true,
+	}); err != nil {
+		return fmt.Errorf("failed to initialise webhook deliveries: %w", err)
+	}
+	w.ready = true
+	return nil
+}
+
+// Notify delivers job completion in the background
+func (w *Webhook) Notify(ctx context.Context, job *async.Job) {
+	payload, err := json.Marshal(NewWebhookPayload(job))
+	if err != nil {
+		fmt.Printf("[WARN] webhook job %v: %v\n", job.ID, err)
+		return
+	}
+	now := time.Now().UTC()
+	delivery := &WebhookDelivery{
+		ID:           uuid.New().String(),
+		JobID:        job.ID,
+		View:         job.MainView,
+		URL:          w.expandURL(job),
+		Payload:      string(payload),
+		Status:       DeliveryStatusPending,
+		CreationTime: now,
+		UpdateTime:   now,
+	}
+	if err = w.insert(ctx, delivery); err != nil {
+		fmt.Printf("[WARN] webhook job %v: failed to log delivery: %v\n", job.ID, err)
+	}
+	go w.deliver(context.Background(), delivery)
+}
+
+// deliver posts payload with retries, each attempt outcome is logged
+func (w *Webhook) deliver(ctx context.Context, delivery *WebhookDelivery) {
+	backoff := time.Duration(w.BackoffMs) * time.Millisecond
+	if backoff <= 0 {
+		backoff = time.Second
+	}
+	for attempt := 0; attempt < w.attempts(); attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+		}
+		delivery.Attempts++
+		delivery.StatusCode, delivery.Error = w.post(ctx, delivery)
+		delivery.Status = DeliveryStatusFailed
+		if delivery.Error == "" {
+			delivery.Status = DeliveryStatusDelivered
+		}
+		delivery.UpdateTime = time.Now().UTC()
+		if err := w.update(ctx, delivery); err != nil {
+			fmt.Printf("[WARN] webhook job %v: failed to log delivery: %v\n", delivery.JobID, err)
+		}
+		if delivery.Status == DeliveryStatusDelivered {
+			return
+		}
+	}
+	fmt.Printf("[WARN] webhook job %v delivery to %v failed after %v attempts: %v\n", delivery.JobID, delivery.URL, delivery.Attempts, delivery.Error)
+}
+
+// Replay re-sends logged delivery payload, new attempts are recorded on the delivery
+func (w *Webhook) Replay(ctx context.Context, ID string) (*WebhookDelivery, error) {
+	deliveries, err := w.deliveries(ctx, "ID = ?", ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(deliveries) == 0 {
+		return nil, fmt.Errorf("webhook delivery %v not found", ID)
+	}
+	w.deliver(ctx, deliveries[0])
+	return deliveries[0], nil
+}
+
+// ReplayFailed re-sends failed deliveries updated since the given time, empty view replays deliveries of all views
+func (w *Webhook) ReplayFailed(ctx context.Context, view string, since time.Time) ([]*WebhookDelivery, error) {
+	criteria, args := "Status = ? AND UpdateTime >= ?", []interface{}{DeliveryStatusFailed, since.UTC()}
+	if view != "" {
+		criteria += " AND View = ?"
+		args = append(args, view)
+	}
+	deliveries, err := w.deliveries(ctx, criteria, args...)
+	if err != nil {
+		return nil, err
+	}
+	for _, delivery := range deliveries {
+		w.deliver(ctx, delivery)
+	}
+	return deliveries, nil
+}
+
+func (w *Webhook) post(ctx context.Context, delivery *WebhookDelivery) (int, string) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, strings.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err.Error()
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("X-Datly-Delivery", delivery.ID)
+	if signature := w.Sign([]byte(delivery.Payload)); signature != "" {
+		request.Header.Set(w.signatureHeader(), signature)
+	}
+	response, err := w.client.Do(request)
+	if err != nil {
+		return 0, err.Error()
+	}
+	defer response.Body.Close()
+	_, _ = io.Copy(io.Discard, response.Body)
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return response.StatusCode, fmt.Sprintf("unexpected status code: %v", response.StatusCode)
+	}
+	return response.StatusCode, ""
+}
+
+// Sign returns payload HMAC SHA256 signature or empty string if secret was not configured
+func (w *Webhook) Sign(payload []byte) string {
+	if len(w.secret) == 0 {
+		return ""
+	}
+	mac := hmac.New(sha256.New, w.secret)
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func (w *Webhook) insert(ctx context.Context, delivery *WebhookDelivery) error {
+	db, err := w.service.connector.DB()
+	if err != nil {
+		return err
+	}
+	SQL, err := dbms.Bind(ctx, w.service.connector, "INSERT INTO "+WebhookTable+"(ID, JobID, View, URL, Payload, Status, Attempts, StatusCode, Error, CreationTime, UpdateTime) VALUES(?, ?, ?, ?, ?, ?, 0, 0, '', ?, ?)")
+	if err != nil {
+		return err
+	}
+	_, err = db.ExecContext(ctx, SQL, delivery.ID, delivery.JobID, delivery.View, delivery.URL, delivery.Payload, delivery.Status, delivery.CreationTime, delivery.UpdateTime)
+	return err
+}
+
+func (w *Webhook) update(ctx context.Context, delivery *WebhookDelivery) error {
+	db, err := w.service.connector.DB()
+	if err != nil {
+		return err
+	}
+	SQL, err := dbms.Bind(ctx, w.service.connector, "UPDATE "+WebhookTable+" SET Status = ?, Attempts = ?, StatusCode = ?, Error = ?, UpdateTime = ? WHERE ID = ?")
+	if err != nil {
+		return err
+	}
+	_, err = db.ExecContext(ctx, SQL, delivery.Status, delivery.Attempts, delivery.StatusCode, delivery.Error, delivery.UpdateTime, delivery.ID)
+	return err
+}
+
+func (w *Webhook) deliveries(ctx context.Context, criteria string, args ...interface{}) ([]*WebhookDelivery, error) {
+	db, err := w.service.connector.DB()
+	if err != nil {
+		return nil, err
+	}
+	SQL, err := dbms.Bind(ctx, w.service.connector, "SELECT ID, JobID, View, URL, Payload, Status, Attempts, StatusCode, Error, CreationTime, UpdateTime FROM "+WebhookTable+" WHERE "+criteria+" ORDER BY CreationTime")
+	if err != nil {
+		return nil, err
+	}
+	rows, err := db.QueryContext(ctx, SQL, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+	var result []*WebhookDelivery
+	for rows.Next() {
+		delivery := &WebhookDelivery{}
+		if err = rows.Scan(&delivery.ID, &delivery.JobID, &delivery.View, &delivery.URL, &delivery.Payload, &delivery.Status,
+			&delivery.Attempts, &delivery.StatusCode, &delivery.Error, &delivery.CreationTime, &delivery.UpdateTime); err != nil {
+			return nil, err
+		}
+		result = append(result, delivery)
+	}
+	return result, rows.Err()
+}
+
+// expandURL substitutes job values, values are path escaped in URL path and query escaped in URL query
+func (w *Webhook) expandURL(job *async.Job) string {
+	path, query := w.URL, ""
+	if index := strings.IndexAny(path, "?#"); index != -1 {
+		path, query = path[:index], path[index:]
+	}
+	return webhookReplacer(job, url.PathEscape).Replace(path) + webhookReplacer(job, url.QueryEscape).Replace(query)
+}
+
+func webhookReplacer(job *async.Job, escape func(string) string) *strings.Replacer {
+	return strings.NewReplacer(
+		"${jobId}", escape(job.ID),
+		"${matchKey}", escape(job.MatchKey),
+		"${status}", escape(job.Status),
+		"${view}", escape(job.MainView),
+	)
+}
+
+func (w *Webhook) attempts() int {
+	if w.MaxAttempts <= 0 {
+		return defaultWebhookAttempts
+	}
+	return w.MaxAttempts
+}
+
+func (w *Webhook) signatureHeader() string {
+	if w.Header == "" {
+		return defaultSignatureHeader
+	}
+	return w.Header
+}
+
+// NewWebhookPayload creates job completion payload
+func NewWebhookPayload(job *async.Job) *WebhookPayload {
+	ret := &WebhookPayload{
+		JobID:          job.ID,
+		MatchKey:       job.MatchKey,
+		Status:         job.Status,
+		View:           job.MainView,
+		ResultLocation: resultLocation(job),
+		CreationTime:   job.CreationTime,
+		EndTime:        job.EndTime,
+	}
+	if job.Error != nil {
+		ret.Error = *job.Error
+	}
+	return ret
+}
+
+// resultLocation returns job destination: table, cache entry or event URL
+func resultLocation(job *async.Job) string {
+	if job.TableName != nil && *job.TableName != "" {
+		var parts []string
+		for _, part := range []*string{job.TableDataset, job.TableName} {
+			if part != nil && *part != "" {
+				parts = append(parts, *part)
+			}
+		}
+		return strings.Join(parts, ".")
+	}
+	if job.CacheKey != nil && *job.CacheKey != "" {
+		var parts []string
+		for _, part := range []*string{job.CacheNamespace, job.CacheSet, job.CacheKey} {
+			if part != nil && *part != "" {
+				parts = append(parts, *part)
+			}
+		}
+		return strings.Join(parts, "/")
+	}
+	return job.EventURL
+}
@@ -0,0 +1,113 @@
+package jobs
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/viant/datly/view"
+	"github.com/viant/xdatly/handler/async"
+)
+
+func TestWebhook_Post(t *testing.T) {
+	secret := []byte("s3cr3t")
+	var body []byte
+	var signature string
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		body, _ = io.ReadAll(request.Body)
+		signature = request.Header.Get("X-Partner-Signature")
+		writer.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	tableName, dataset := "REPORT_1", "reports"
+	job := &async.Job{ID: "1", MatchKey: "report/1", Status: string(async.StatusDone), MainView: "report"}
+	job.TableName, job.TableDataset = &tableName, &dataset
+	webhook := &Webhook{URL: server.URL + "/hook/${view}/${jobId}?status=${status}", Header: "X-Partner-Signature", secret: secret, client: server.Client()}
+	assert.Equal(t, server.URL+"/hook/report/1?status=DONE", webhook.expandURL(job))
+
+	payload := NewWebhookPayload(job)
+	assert.Equal(t, "reports.REPORT_1", payload.ResultLocation)
+
+	statusCode, errMessage := webhook.post(context.Background(), &WebhookDelivery{ID: "d1", URL: webhook.expandURL(job), Payload: `{"JobID":"1"}`})
+	require.Empty(t, errMessage)
+	assert.Equal(t, http.StatusAccepted, statusCode)
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	assert.Equal(t, "sha256="+hex.EncodeToString(mac.Sum(nil)), signature)
+}
+
+func TestWebhook_ExpandURL(t *testing.T) {
+	job := &async.Job{ID: "1", MatchKey: "a/b?c=1&d", Status: string(async.StatusDone), MainView: "report"}
+	webhook := &Webhook{URL: "http://localhost/hook/${matchKey}?key=${matchKey}"}
+	assert.Equal(t, "http://localhost/hook/a%2Fb%3Fc=1&d?key=a%2Fb%3Fc%3D1%26d", webhook.expandURL(job))
+}
+
+func TestIsTerminal(t *testing.T) {
+	assert.True(t, IsTerminal(string(async.StatusDone)))
+	assert.True(t, IsTerminal(string(StatusDead)))
+	assert.True(t, IsTerminal(string(StatusCancelled)))
+	assert.False(t, IsTerminal(string(async.StatusRunning)))
+	assert.False(t, IsTerminal(string(async.StatusPending)))
+}
+
+func newTestWebhook(t *testing.T, URL string, client *http.Client) *Webhook {
+	connector := view.NewConnector("webhook", "sqlite3", t.TempDir()+"/webhook.db")
+	db, err := connector.DB()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+	_, err = db.Exec("CREATE TABLE " + WebhookTable + ` (ID TEXT PRIMARY KEY, JobID TEXT, View TEXT, URL TEXT, Payload TEXT,
+		Status TEXT, Attempts INTEGER, StatusCode INTEGER, Error TEXT, CreationTime DATETIME, UpdateTime DATETIME)`)
+	require.NoError(t, err)
+	return &Webhook{URL: URL, MaxAttempts: 1, service: New(connector), client: client, ready: true}
+}
+
+func TestWebhook_Replay(t *testing.T) {
+	var payloads []string
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		body, _ := io.ReadAll(request.Body)
+		payloads = append(payloads, string(body))
+		writer.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	webhook := newTestWebhook(t, server.URL, server.Client())
+	ctx := context.Background()
+	now := time.Now().UTC()
+	for _, delivery := range []*WebhookDelivery{
+		{ID: "d1", JobID: "j1", View: "report", URL: server.URL, Payload: `{"JobID":"j1"}`, CreationTime: now.Add(-2 * time.Hour), UpdateTime: now.Add(-2 * time.Hour)},
+		{ID: "d2", JobID: "j2", View: "report", URL: server.URL, Payload: `{"JobID":"j2"}`, CreationTime: now, UpdateTime: now},
+		{ID: "d3", JobID: "j3", View: "orders", URL: server.URL, Payload: `{"JobID":"j3"}`, CreationTime: now, UpdateTime: now},
+	} {
+		delivery.Status, delivery.Attempts, delivery.Error = DeliveryStatusFailed, 3, "unexpected status code: 500"
+		require.NoError(t, webhook.insert(ctx, delivery))
+		require.NoError(t, webhook.update(ctx, delivery))
+	}
+
+	replayed, err := webhook.ReplayFailed(ctx, "report", now.Add(-time.Hour))
+	require.NoError(t, err)
+	require.Len(t, replayed, 1, "only failed report deliveries since given time should be replayed")
+	assert.Equal(t, []string{`{"JobID":"j2"}`}, payloads)
+
+	delivery, err := webhook.Replay(ctx, "d1")
+	require.NoError(t, err)
+	assert.Equal(t, `{"JobID":"j1"}`, payloads[1])
+	stored, err := webhook.deliveries(ctx, "ID = ?", "d1")
+	require.NoError(t, err)
+	require.Len(t, stored, 1)
+	assert.Equal(t, DeliveryStatusDelivered, stored[0].Status)
+	assert.Equal(t, 4, stored[0].Attempts, "replay attempt should be recorded")
+	assert.Equal(t, http.StatusOK, stored[0].StatusCode)
+	assert.Empty(t, stored[0].Error)
+	assert.Equal(t, delivery.Attempts, stored[0].Attempts)
+
+	_, err = webhook.Replay(ctx, "missing")
+	assert.Error(t, err)
+}
@@ -0,0 +1,93 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+
+	"github.com/viant/datly/repository"
+	"github.com/viant/datly/view"
+	"github.com/viant/datly/view/state"
+	"github.com/viant/xdatly/handler/response"
+)
+
+const maxAuthorizeDepth = 8
+
+// authorizeInput rejects executor input writing fields caller is not allowed to see, data fetched by view parameters is skipped
+func authorizeInput(ctx context.Context, aComponent *repository.Component, input interface{}) error {
+	rValue := reflect.ValueOf(input)
+	for rValue.Kind() == reflect.Ptr && !rValue.IsNil() {
+		rValue = rValue.Elem()
+	}
+	if rValue.Kind() != reflect.Struct {
+		return nil
+	}
+	fetched := map[string]bool{}
+	for _, parameter := range aComponent.Input.Type.Parameters {
+		if parameter.In != nil && parameter.In.Kind == state.KindView {
+			fetched[parameter.Name] = true
+		}
+	}
+	grants := view.GrantsOf(ctx)
+	rType := rValue.Type()
+	for i := 0; i < rType.NumField(); i++ {
+		field := rType.Field(i)
+		if field.PkgPath != "" || fetched[field.Name] {
+			continue
+		}
+		if err := authorizeField(field, rValue.Field(i), grants, "", 0); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func authorizeField(field reflect.StructField, value reflect.Value, grants *view.Grants, path string, depth int) error {
+	fieldPath := field.Name
+	if path != "" {
+		fieldPath = path + "." + field.Name
+	}
+	tag, ok := field.Tag.Lookup(view.AuthTag)
+	if !ok {
+		return authorizeValue(value, grants, fieldPath, depth+1)
+	}
+	auth, err := view.ParseColumnAuth(tag)
+	if err != nil {
+		return fmt.Errorf("invalid %v auth tag: %w", fieldPath, err)
+	}
+	if !auth.Allowed(grants) && !value.IsZero() {
+		return response.NewError(http.StatusForbidden, fmt.Sprintf("not authorized to write %v", fieldPath))
+	}
+	return nil
+}
+
+func authorizeValue(value reflect.Value, grants *view.Grants, path string, depth int) error {
+	if depth > maxAuthorizeDepth {
+		return nil
+	}
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return nil
+		}
+		value = value.Elem()
+	}
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			if err := authorizeValue(value.Index(i), grants, path, depth+1); err != nil {
+				return err
+			}
+		}
+	case reflect.Struct:
+		rType := value.Type()
+		for i := 0; i < rType.NumField(); i++ {
+			if field := rType.Field(i); field.PkgPath == "" {
+				if err := authorizeField(field, value.Field(i), grants, path, depth); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
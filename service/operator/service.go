@@ -64,7 +64,7 @@ func (s *Service) Operate(ctx context.Context, aSession *session.Session, aCompo
 		}
 	}()
 	if tx := aSession.Options.SqlTx(); tx != nil && aComponent.View != nil && aComponent.View.Connector != nil {
-		db, dbErr := aComponent.View.Connector.DB()
+		db, dbErr := aComponent.View.Connector.WriterDB(ctx)
 		if dbErr != nil {
 			return nil, dbErr
 		}
@@ -170,6 +170,12 @@ func (s *Service) operate(ctx context.Context, aComponent *repository.Component,
 			}
 			return nil, err
 		}
+		if err = authorizeInput(ctx, aComponent, ctx.Value(xhandler.InputKey)); err != nil {
+			if onDone != nil {
+				onDone(time.Now(), err)
+			}
+			return nil, err
+		}
 		ret, err := s.execute(ctx, aComponent, aSession, onDone)
 		if err != nil {
 			if statusCoder, ok := err.(response.StatusCoder); ok {
@@ -480,6 +486,8 @@ func (s *Service) publishEvent(ctx context.Context, asyncModule *rasync.Config,
 		if err = s.fs.Upload(ctx, job.EventURL, file.DefaultFileOsMode, bytes.NewReader(payload)); err != nil {
 			return err
 		}
+	case rasync.NotificationMethodQueue:
+		return asyncModule.Enqueue(ctx, job)
 	//case async.NotificationMethodMessageBus:
 	default:
 		return fmt.Errorf("unsupported event destination: %v", asyncModule.Method)
@@ -0,0 +1,159 @@
+package reader
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/viant/datly/view"
+	"github.com/viant/xdatly/handler/response"
+)
+
+// authorizeProjection drops columns caller is not allowed to see from selector projection, criteria, filters and
+// order by referencing unauthorized (forbidden or masked) columns are rejected
+func authorizeProjection(ctx context.Context, aView *view.View, selector *view.Statelet) error {
+	forbidden, masked := view.Columns(aView.Columns).Authorize(view.GrantsOf(ctx))
+	if len(forbidden) == 0 && len(masked) == 0 {
+		return nil
+	}
+	if err := authorizePredicates(aView, selector, append(append([]*view.Column{}, forbidden...), masked...)); err != nil {
+		return err
+	}
+	if len(forbidden) == 0 {
+		return nil
+	}
+	isForbidden := map[*view.Column]bool{}
+	for _, column := range forbidden {
+		isForbidden[column] = true
+	}
+	candidates := aView.Columns
+	if len(selector.Columns) > 0 {
+		candidates = nil
+		for _, name := range selector.Columns {
+			if column, ok := aView.ColumnByName(name); ok {
+				candidates = append(candidates, column)
+			}
+		}
+	}
+	var columns, fields []string
+	for _, column := range candidates {
+		if isForbidden[column] {
+			continue
+		}
+		columns = append(columns, column.Name)
+		fieldName := column.FieldName()
+		if fieldName == "" {
+			fieldName = column.Name
+		}
+		fields = append(fields, fieldName)
+	}
+	if len(columns) == 0 {
+		names := make([]string, 0, len(forbidden))
+		for _, column := range forbidden {
+			names = append(names, column.Name)
+		}
+		return response.NewError(http.StatusForbidden, fmt.Sprintf("not authorized to read %v columns: %v", aView.Name, strings.Join(names, ",")))
+	}
+	selector.SetColumns(columns)
+	selector.Fields = fields
+	return nil
+}
+
+// authorizePredicates returns forbidden error when selector criteria, filters or order by reference unauthorized column
+func authorizePredicates(aView *view.View, selector *view.Statelet, unauthorized []*view.Column) error {
+	names := map[string]string{}
+	for _, column := range unauthorized {
+		names[strings.ToLower(column.Name)] = column.Name
+		if fieldName := column.FieldName(); fieldName != "" {
+			names[strings.ToLower(fieldName)] = column.Name
+		}
+	}
+	reject := func(kind, name string) error {
+		return response.NewError(http.StatusForbidden, fmt.Sprintf("not authorized to %v %v by column: %v", kind, aView.Name, name))
+	}
+	var orderByColumns map[string]string
+	if aView.Selector != nil && aView.Selector.Constraints != nil {
+		orderByColumns = aView.Selector.Constraints.OrderByColumn
+	}
+	for _, item := range strings.Split(strings.ReplaceAll(selector.OrderBy, ":", " "), ",") {
+		column := strings.TrimSpace(item)
+		if index := strings.Index(column, " "); index != -1 {
+			column = column[:index]
+		}
+		if mapped, ok := orderByColumns[column]; ok {
+			column = mapped
+		}
+		if name, ok := names[strings.ToLower(column)]; ok {
+			return reject("order", name)
+		}
+	}
+	for _, filter := range selector.Filters {
+		if name, ok := names[strings.ToLower(filter.Name)]; ok {
+			return reject("filter", name)
+		}
+	}
+	for _, identifier := range criteriaIdentifiers(selector.Criteria) {
+		if name, ok := names[strings.ToLower(identifier)]; ok {
+			return reject("filter", name)
+		}
+	}
+	return nil
+}
+
+// criteriaIdentifiers returns criteria words outside of string literals
+func criteriaIdentifiers(criteria string) []string {
+	var ret []string
+	quote := rune(0)
+	start := -1
+	for i, r := range criteria + " " {
+		if quote != 0 {
+			if r == quote {
+				quote = 0
+			}
+			continue
+		}
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+			if start == -1 {
+				start = i
+			}
+			continue
+		}
+		if start != -1 {
+			ret = append(ret, criteria[start:i])
+			start = -1
+		}
+		if r == '\'' {
+			quote = r
+		}
+	}
+	return ret
+}
+
+// maskVisitor masks columns caller is not allowed to see in fetched rows
+func maskVisitor(ctx context.Context, aView *view.View, visitor view.VisitorFn) view.VisitorFn {
+	_, masked := view.Columns(aView.Columns).Authorize(view.GrantsOf(ctx))
+	if len(masked) == 0 {
+		return visitor
+	}
+	return func(row interface{}) error {
+		rValue := reflect.ValueOf(row)
+		for rValue.Kind() == reflect.Ptr && !rValue.IsNil() {
+			rValue = rValue.Elem()
+		}
+		if rValue.Kind() == reflect.Struct {
+			for _, column := range masked {
+				field := column.Field()
+				if field == nil {
+					continue
+				}
+				if value := rValue.FieldByName(field.Name); value.IsValid() {
+					column.Auth.MaskValue(value)
+				}
+			}
+		}
+		return visitor(row)
+	}
+}
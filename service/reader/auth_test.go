@@ -0,0 +1,32 @@
+package reader
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/datly/view"
+	"github.com/viant/datly/view/state/predicate"
+	"github.com/viant/xdatly/handler/state"
+)
+
+func TestAuthorizePredicates(t *testing.T) {
+	salary := &view.Column{Name: "SALARY", Auth: &view.ColumnAuth{Roles: []string{"hr"}}}
+	aView := &view.View{Name: "employee", Selector: &view.Config{Constraints: &view.Constraints{OrderByColumn: map[string]string{"pay": "SALARY"}}}}
+	var testCases = []struct {
+		description string
+		selector    *view.Statelet
+		expectErr   bool
+	}{
+		{description: "allowed", selector: &view.Statelet{}},
+		{description: "allowed order by", selector: &view.Statelet{QuerySelector: state.QuerySelector{OrderBy: "name:desc,id"}}},
+		{description: "order by", selector: &view.Statelet{QuerySelector: state.QuerySelector{OrderBy: "name,salary desc"}}, expectErr: true},
+		{description: "order by alias", selector: &view.Statelet{QuerySelector: state.QuerySelector{OrderBy: "pay:asc"}}, expectErr: true},
+		{description: "criteria", selector: &view.Statelet{QuerySelector: state.QuerySelector{Criteria: "(SALARY > 100)"}}, expectErr: true},
+		{description: "allowed criteria", selector: &view.Statelet{QuerySelector: state.QuerySelector{Criteria: "SALARY_BAND = 1 AND NAME = 'salary'"}}},
+		{description: "filter", selector: &view.Statelet{Filters: predicate.Filters{{Name: "salary"}}}, expectErr: true},
+	}
+	for _, testCase := range testCases {
+		err := authorizePredicates(aView, testCase.selector, []*view.Column{salary})
+		assert.Equal(t, testCase.expectErr, err != nil, testCase.description)
+	}
+}
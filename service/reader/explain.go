@@ -0,0 +1,58 @@
+package reader
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/viant/datly/internal/queryplan"
+	"github.com/viant/datly/view"
+	"github.com/viant/sqlx/io/read/cache"
+)
+
+const explainTimeout = 30 * time.Second
+
+// explaining prevents concurrent plan capture of the same query
+var explaining sync.Map
+
+// explainIfSlow captures query plan in the background when read exceeded view ExplainMs threshold
+func (s *Service) explainIfSlow(ctx context.Context, aView *view.View, collector *view.Collector, query *cache.ParmetrizedQuery, cacheStats *cache.Stats, elapsed time.Duration) {
+	if aView.ExplainMs <= 0 || elapsed < time.Duration(aView.ExplainMs)*time.Millisecond || aView.Connector == nil {
+		return
+	}
+	if cacheStats != nil && (cacheStats.FoundWarmup || cacheStats.FoundLazy) {
+		return
+	}
+	key := aView.Name + ":" + query.SQL
+	if _, loaded := explaining.LoadOrStore(key, true); loaded {
+		return
+	}
+	traceID := reqTraceID(ctx)
+	relation := collector.Relation() != nil
+	go func() {
+		defer explaining.Delete(key)
+		explainCtx, cancel := context.WithTimeout(context.Background(), explainTimeout)
+		defer cancel()
+		db, err := aView.ReaderDb(explainCtx)
+		if err != nil {
+			return
+		}
+		dsn, err := aView.Connector.ResolvedDSN(explainCtx)
+		if err != nil {
+			return
+		}
+		plan, err := queryplan.Explain(explainCtx, db, aView.Connector.Driver, dsn, query.SQL, query.Args, relation)
+		plan.TraceID = traceID
+		plan.View = aView.Name
+		plan.ElapsedMs = elapsed.Milliseconds()
+		queryplan.Default().Add(plan)
+		if err != nil {
+			fmt.Printf("[WARN] query plan capture failed reqTraceId=%s view=%s: %v\n", traceID, aView.Name, err)
+			return
+		}
+		if plan.Flagged() {
+			fmt.Printf("[WARN] slow query plan reqTraceId=%s view=%s elapsed=%s warnings=%v\n", traceID, aView.Name, elapsed, plan.Warnings)
+		}
+	}()
+}
@@ -0,0 +1,53 @@
+package reader
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/viant/datly/view"
+	"github.com/viant/sqlx/io/read/cache"
+	"github.com/viant/xdatly/handler/response"
+)
+
+type (
+	// Generator produces view records in place of view data source, it is used by mock mode to serve components before database exists
+	Generator interface {
+		// Generate emits view records, parentKeys are set for relation view with relation batch parent key values
+		Generate(ctx context.Context, aView *view.View, selector *view.Statelet, relation *view.Relation, parentKeys []interface{}, newRow func() interface{}, visit func(row interface{}) error) error
+	}
+
+	generatorKey string
+)
+
+const generatorContextKey = generatorKey("generator")
+
+// WithGenerator returns context with record generator
+func WithGenerator(ctx context.Context, generator Generator) context.Context {
+	return context.WithValue(ctx, generatorContextKey, generator)
+}
+
+func generatorOf(ctx context.Context) Generator {
+	ret, _ := ctx.Value(generatorContextKey).(Generator)
+	return ret
+}
+
+// queryGenerated reads view records from context generator
+func (s *Service) queryGenerated(ctx context.Context, session *Session, aView *view.View, selector *view.Statelet, batchData *view.BatchData, collector *view.Collector, visitor view.VisitorFn, generator Generator) ([]*response.SQLExecution, error) {
+	var parentKeys []interface{}
+	if collector.Relation() != nil {
+		parentKeys = batchData.ValuesBatch
+		if len(parentKeys) == 0 && batchData.HasComposite() {
+			return nil, fmt.Errorf("view %v: generated records do not support composite relation keys", aView.Name)
+		}
+	}
+	stats, onDone := NewExecutionInfo(&cache.ParmetrizedQuery{SQL: "GENERATE " + aView.Name, Args: parentKeys}, nil, collector)
+	defer onDone()
+	if session.DryRun {
+		return []*response.SQLExecution{stats}, nil
+	}
+	err := generator.Generate(ctx, aView, selector, collector.Relation(), parentKeys, collector.NewItem(), s.fetchVisitor(ctx, aView, collector, visitor))
+	if err != nil {
+		stats.SetError(err)
+	}
+	return []*response.SQLExecution{stats}, err
+}
@@ -0,0 +1,189 @@
+package reader
+
+import (
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"os"
+	"reflect"
+)
+
+type (
+	// hashJoin matches streamed child rows with parent keys, when parent keys exceed limit, keys and child rows
+	// are partitioned by key hash, the first partition is matched in memory, the others spill to disk
+	// and are matched by finish. Spilled rows are gob encoded, so that fields excluded from JSON are preserved
+	hashJoin struct {
+		partitions int
+		keys       map[string]bool
+		spilled    []*joinPartition
+		newRow     func() interface{}
+	}
+
+	joinPartition struct {
+		keys    *os.File
+		rows    *os.File
+		encoder *gob.Encoder
+	}
+)
+
+func newHashJoin(parentKeys []interface{}, limit int, dir string, newRow func() interface{}) (ret *hashJoin, err error) {
+	ret = &hashJoin{partitions: 1, keys: map[string]bool{}, newRow: newRow}
+	if limit > 0 && len(parentKeys) > limit {
+		ret.partitions = (len(parentKeys) + limit - 1) / limit
+	}
+	defer func() {
+		if err != nil {
+			ret.close()
+		}
+	}()
+	var encoders []*json.Encoder
+	for i := 1; i < ret.partitions; i++ {
+		partition := &joinPartition{}
+		ret.spilled = append(ret.spilled, partition)
+		if partition.keys, err = os.CreateTemp(dir, "datly-join-keys-*"); err != nil {
+			return ret, fmt.Errorf("failed to create join spill file: %w", err)
+		}
+		if partition.rows, err = os.CreateTemp(dir, "datly-join-rows-*"); err != nil {
+			return ret, fmt.Errorf("failed to create join spill file: %w", err)
+		}
+		partition.encoder = gob.NewEncoder(partition.rows)
+		encoders = append(encoders, json.NewEncoder(partition.keys))
+	}
+	for _, parentKey := range parentKeys {
+		key, ok := joinKey(parentKey)
+		if !ok {
+			continue
+		}
+		index := ret.partition(key)
+		if index == 0 {
+			ret.keys[key] = true
+			continue
+		}
+		if err = encoders[index-1].Encode(key); err != nil {
+			return ret, fmt.Errorf("failed to spill join keys: %w", err)
+		}
+	}
+	return ret, nil
+}
+
+// probe matches in-memory partition row or spills it
+func (j *hashJoin) probe(childKey interface{}, row interface{}, emit func(row interface{}) error) error {
+	key, ok := joinKey(childKey)
+	if !ok {
+		return nil
+	}
+	index := j.partition(key)
+	if index == 0 {
+		if j.keys[key] {
+			return emit(row)
+		}
+		return nil
+	}
+	encoder := j.spilled[index-1].encoder
+	if err := encoder.Encode(key); err != nil {
+		return fmt.Errorf("failed to spill join row: %w", err)
+	}
+	if err := encoder.Encode(row); err != nil {
+		return fmt.Errorf("failed to spill join row: %w", err)
+	}
+	return nil
+}
+
+// finish matches spilled partitions one by one
+func (j *hashJoin) finish(emit func(row interface{}) error) error {
+	for _, partition := range j.spilled {
+		keys, err := partition.loadKeys()
+		if err != nil {
+			return err
+		}
+		if _, err = partition.rows.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+		decoder := gob.NewDecoder(partition.rows)
+		for {
+			var key string
+			if err = decoder.Decode(&key); err == io.EOF {
+				break
+			} else if err != nil {
+				return fmt.Errorf("failed to read join spill file: %w", err)
+			}
+			row := j.newRow()
+			if err = decoder.Decode(row); err != nil {
+				return fmt.Errorf("failed to read join spill file: %w", err)
+			}
+			if !keys[key] {
+				continue
+			}
+			if err = emit(row); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (j *hashJoin) close() {
+	for _, partition := range j.spilled {
+		for _, file := range []*os.File{partition.keys, partition.rows} {
+			if file == nil {
+				continue
+			}
+			_ = file.Close()
+			_ = os.Remove(file.Name())
+		}
+	}
+	j.spilled = nil
+}
+
+func (j *hashJoin) partition(key string) int {
+	if j.partitions == 1 {
+		return 0
+	}
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(key))
+	return int(hash.Sum32() % uint32(j.partitions))
+}
+
+func (p *joinPartition) loadKeys() (map[string]bool, error) {
+	if _, err := p.keys.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	ret := map[string]bool{}
+	decoder := json.NewDecoder(p.keys)
+	for {
+		var key string
+		if err := decoder.Decode(&key); err == io.EOF {
+			return ret, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to read join keys: %w", err)
+		}
+		ret[key] = true
+	}
+}
+
+// joinKey returns comparable key representation, numeric keys of different types are equal when values are equal
+func joinKey(value interface{}) (string, bool) {
+	if value == nil {
+		return "", false
+	}
+	rValue := reflect.ValueOf(value)
+	for rValue.Kind() == reflect.Ptr {
+		if rValue.IsNil() {
+			return "", false
+		}
+		rValue = rValue.Elem()
+	}
+	switch rValue.Kind() {
+	case reflect.Slice:
+		if rValue.Type().Elem().Kind() == reflect.Uint8 {
+			return string(rValue.Bytes()), true
+		}
+	case reflect.Float32, reflect.Float64:
+		if float := rValue.Float(); float == float64(int64(float)) {
+			return fmt.Sprintf("%d", int64(float)), true
+		}
+	}
+	return fmt.Sprintf("%v", rValue.Interface()), true
+}
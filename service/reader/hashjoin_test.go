@@ -0,0 +1,70 @@
+package reader
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type joinRecord struct {
+	ID       int
+	ParentID *int64 `json:"-"`
+	Audit    joinAudit
+}
+
+type joinAudit struct {
+	Created time.Time
+	Tags    []string
+}
+
+func TestHashJoin(t *testing.T) {
+	var testCases = []struct {
+		description string
+		limit       int
+	}{
+		{description: "in memory", limit: 100},
+		{description: "spill to disk", limit: 2},
+	}
+	for _, testCase := range testCases {
+		parentKeys := []interface{}{1, 2, int64(3), "4", []byte("5"), nil}
+		join, err := newHashJoin(parentKeys, testCase.limit, t.TempDir(), func() interface{} { return &joinRecord{} })
+		require.Nil(t, err, testCase.description)
+		assert.Equal(t, testCase.limit < len(parentKeys), len(join.spilled) > 0, testCase.description)
+
+		created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+		var matched []int
+		emit := func(row interface{}) error {
+			record := row.(*joinRecord)
+			require.NotNil(t, record.ParentID, testCase.description)
+			assert.Equal(t, int64(record.ID/10), *record.ParentID, testCase.description)
+			assert.Equal(t, joinAudit{Created: created, Tags: []string{"a"}}, record.Audit, testCase.description)
+			matched = append(matched, record.ID)
+			return nil
+		}
+		for i := 1; i <= 8; i++ {
+			parentID := int64(i)
+			record := &joinRecord{ID: i * 10, ParentID: &parentID, Audit: joinAudit{Created: created, Tags: []string{"a"}}}
+			require.Nil(t, join.probe(record.ParentID, record, emit), testCase.description)
+		}
+		require.Nil(t, join.probe(nil, &joinRecord{ID: 99}, emit), testCase.description)
+		require.Nil(t, join.finish(emit), testCase.description)
+		join.close()
+		sort.Ints(matched)
+		assert.Equal(t, []int{10, 20, 30, 40, 50}, matched, testCase.description)
+	}
+}
+
+func TestJoinKey(t *testing.T) {
+	value := 7
+	for _, candidate := range []interface{}{7, int64(7), 7.0, &value, "7", []byte("7")} {
+		key, ok := joinKey(candidate)
+		assert.True(t, ok)
+		assert.Equal(t, "7", key)
+	}
+	var nilPtr *int
+	_, ok := joinKey(nilPtr)
+	assert.False(t, ok)
+}
@@ -0,0 +1,150 @@
+package reader
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/viant/datly/view"
+	"github.com/viant/sqlx/io"
+	"github.com/viant/sqlx/io/read/cache"
+	"github.com/viant/xdatly/handler/response"
+)
+
+type httpField struct {
+	index  []int
+	column string
+}
+
+// queryHTTP reads view records from HTTP source, relation batch parent keys are passed as ${ParentKeys}
+func (s *Service) queryHTTP(ctx context.Context, session *Session, aView *view.View, selector *view.Statelet, batchData *view.BatchData, collector *view.Collector, visitor view.VisitorFn) ([]*response.SQLExecution, error) {
+	source := aView.HTTP
+	var parentKeys []interface{}
+	if collector.Relation() != nil {
+		parentKeys = batchData.ValuesBatch
+		if len(parentKeys) == 0 && batchData.HasComposite() {
+			return nil, fmt.Errorf("view %v: HTTP source does not support composite relation keys", aView.Name)
+		}
+	}
+	stats, onDone := NewExecutionInfo(&cache.ParmetrizedQuery{SQL: source.Method + " " + source.URL, Args: parentKeys}, nil, collector)
+	defer onDone()
+	if session.DryRun {
+		return []*response.SQLExecution{stats}, nil
+	}
+	newItem := collector.NewItem()
+	fetchVisitor := s.fetchVisitor(ctx, aView, collector, visitor)
+	var fields []*httpField
+	limit, offset := 0, 0
+	if collector.Relation() == nil && selector != nil {
+		limit, offset = selector.Limit, selector.Offset
+	}
+	_, err := source.Fetch(ctx, httpLookup(selector), parentKeys, limit, offset, func(record view.HTTPRecord) error {
+		row := newItem()
+		if fields == nil {
+			fields = projectHTTPFields(aView, selector, httpFields(reflect.TypeOf(row)))
+		}
+		if err := setHTTPRecord(source, record, reflect.ValueOf(row), fields); err != nil {
+			return fmt.Errorf("view %v: %w", aView.Name, err)
+		}
+		return fetchVisitor(row)
+	})
+	if err != nil {
+		stats.SetError(err)
+	}
+	return []*response.SQLExecution{stats}, err
+}
+
+func httpLookup(selector *view.Statelet) func(name string) (interface{}, bool) {
+	return func(name string) (interface{}, bool) {
+		if selector == nil {
+			return nil, false
+		}
+		switch name {
+		case "Limit":
+			return selector.Limit, true
+		case "Offset":
+			return selector.Offset, true
+		case "Page":
+			return selector.Page, true
+		}
+		if selector.Template == nil {
+			return nil, false
+		}
+		value, err := selector.Template.Value(name)
+		if err != nil {
+			return nil, false
+		}
+		return value, true
+	}
+}
+
+// httpFields indexes record struct fields with their column names
+func httpFields(rType reflect.Type) []*httpField {
+	if rType.Kind() == reflect.Ptr {
+		rType = rType.Elem()
+	}
+	var ret []*httpField
+	for i := 0; i < rType.NumField(); i++ {
+		field := rType.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		column := field.Name
+		if tag := io.ParseTag(field.Tag); tag != nil {
+			if tag.Transient || tag.Column == "-" {
+				continue
+			}
+			if tag.Column != "" {
+				column = tag.Column
+			}
+		}
+		ret = append(ret, &httpField{index: field.Index, column: column})
+	}
+	return ret
+}
+
+// projectHTTPFields returns fields of selector projected columns, as SQL source, record fields outside projection stay empty
+func projectHTTPFields(aView *view.View, selector *view.Statelet, fields []*httpField) []*httpField {
+	if selector == nil || len(selector.Columns) == 0 {
+		return fields
+	}
+	projected := map[string]bool{}
+	for _, name := range selector.Columns {
+		if column, ok := aView.ColumnByName(name); ok {
+			name = column.Name
+		}
+		projected[strings.ToLower(name)] = true
+	}
+	ret := make([]*httpField, 0, len(projected))
+	for _, field := range fields {
+		if projected[strings.ToLower(field.column)] {
+			ret = append(ret, field)
+		}
+	}
+	return ret
+}
+
+func setHTTPRecord(source *view.HTTPSource, record view.HTTPRecord, row reflect.Value, fields []*httpField) error {
+	if row.Kind() == reflect.Ptr {
+		row = row.Elem()
+	}
+	for _, field := range fields {
+		value, ok := source.Value(record, field.column)
+		if !ok || value == nil {
+			continue
+		}
+		data, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		target := row.FieldByIndex(field.index)
+		fieldValue := reflect.New(target.Type())
+		if err = json.Unmarshal(data, fieldValue.Interface()); err != nil {
+			return fmt.Errorf("failed to map column %v: %w", field.column, err)
+		}
+		target.Set(fieldValue.Elem())
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package reader
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/datly/view"
+	"github.com/viant/xdatly/handler/state"
+)
+
+func TestProjectHTTPFields(t *testing.T) {
+	type Employee struct {
+		ID     int     `sqlx:"ID"`
+		Name   string  `sqlx:"NAME"`
+		Salary float64 `sqlx:"SALARY"`
+	}
+	fields := httpFields(reflect.TypeOf(&Employee{}))
+	var testCases = []struct {
+		description string
+		selector    *view.Statelet
+		expect      []string
+	}{
+		{description: "no projection", selector: &view.Statelet{}, expect: []string{"ID", "NAME", "SALARY"}},
+		{description: "projection without forbidden column", selector: &view.Statelet{QuerySelector: state.QuerySelector{Columns: []string{"id", "NAME"}}}, expect: []string{"ID", "NAME"}},
+	}
+	for _, testCase := range testCases {
+		var actual []string
+		for _, field := range projectHTTPFields(&view.View{Name: "employee"}, testCase.selector, fields) {
+			actual = append(actual, field.column)
+		}
+		assert.Equal(t, testCase.expect, actual, testCase.description)
+	}
+}
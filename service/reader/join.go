@@ -0,0 +1,307 @@
+package reader
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/viant/datly/service/dbms"
+	"github.com/viant/datly/view"
+	"github.com/viant/sqlx/io"
+	"github.com/viant/sqlx/io/read"
+	"github.com/viant/sqlx/io/read/cache"
+	"github.com/viant/xdatly/handler/response"
+	"github.com/viant/xunsafe"
+)
+
+const (
+	joinKeysInsertBatch = 500
+	// joinKeysTTL defines age after which keys left by interrupted sessions are removed
+	joinKeysTTL = time.Hour
+)
+
+type (
+	// JoinKey represents parent key pushed into keys table by temp_table join strategy
+	JoinKey struct {
+		SessionID    string
+		IntKey       int64
+		StrKey       string
+		CreationTime time.Time
+	}
+)
+
+var (
+	joinKeysTable  = dbms.New()
+	joinKeysPurged sync.Map
+)
+
+// readJoined reads relation child view with hash or keys table join strategy, it returns false if
+// parent keys are batched into IN list
+func (s *Service) readJoined(ctx context.Context, session *Session, batchData *view.BatchData, aView *view.View, collector *view.Collector, visitor view.VisitorFn, selector *view.Statelet, info *response.SQLExecutions) (bool, error) {
+	strategy := s.joinStrategy(aView, collector, batchData)
+	var execution *response.SQLExecution
+	var err error
+	switch strategy {
+	case view.JoinHash:
+		execution, err = s.readHashJoin(ctx, session, batchData, aView, collector, visitor, selector)
+	case view.JoinTempTable:
+		execution, err = s.readTempTableJoin(ctx, session, batchData, aView, collector, visitor, selector)
+	default:
+		return false, nil
+	}
+	if execution != nil {
+		info.Append(execution)
+	}
+	if err != nil {
+		return true, err
+	}
+	return true, s.readJoinedSummary(ctx, session, batchData, aView, collector, selector, info)
+}
+
+// readJoinedSummary populates relation summary in parent key batches, as IN list strategy does
+func (s *Service) readJoinedSummary(ctx context.Context, session *Session, batchData *view.BatchData, aView *view.View, collector *view.Collector, selector *view.Statelet, info *response.SQLExecutions) error {
+	if aView.Template == nil || aView.Template.Summary == nil {
+		return nil
+	}
+	batchSize := len(batchData.Values)
+	if aView.Batch != nil && aView.Batch.Size > 0 {
+		batchSize = aView.Batch.Size
+	}
+	summaryBatch := *batchData
+	summaryBatch.KeysQuery = ""
+	for offset := 0; offset < len(batchData.Values); offset += batchSize {
+		summaryBatch.ValuesBatch, _ = sliceWithLimit(batchData.Values, offset, offset+batchSize)
+		parentMeta := view.AsViewParam(aView, selector, &summaryBatch)
+		execution, err := s.querySummary(ctx, session, aView, selector, &summaryBatch, collector, parentMeta)
+		if execution != nil {
+			info.Append(execution)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// joinStrategy selects relation join strategy by parent key count, strategies other than IN list
+// support only single column relations
+func (s *Service) joinStrategy(aView *view.View, collector *view.Collector, batchData *view.BatchData) view.JoinStrategy {
+	relation := collector.Relation()
+	if relation == nil || relation.Join == nil || batchData.HasComposite() || len(batchData.ColumnNames) != 1 {
+		return view.JoinIn
+	}
+	if aView.HTTP != nil || aView.Partitioned != nil || aView.Connector == nil {
+		return view.JoinIn
+	}
+	if aView.Template != nil && strings.Contains(aView.Template.Source, "$View.ParentJoinOn") {
+		return view.JoinIn
+	}
+	strategy := relation.Join.Select(len(batchData.Values))
+	if strategy == view.JoinHash && (relation.ChildKeyField() == nil || aView.DatabaseType() != aView.Schema.CompType()) {
+		return view.JoinIn
+	}
+	return strategy
+}
+
+// readHashJoin streams child view without parent keys criteria and matches rows with parent keys in memory
+func (s *Service) readHashJoin(ctx context.Context, session *Session, batchData *view.BatchData, aView *view.View, collector *view.Collector, visitor view.VisitorFn, selector *view.Statelet) (*response.SQLExecution, error) {
+	relation := collector.Relation()
+	data, _ := session.ParentData()
+	query, err := s.sqlBuilder.Build(ctx, WithBuilderView(aView), WithBuilderStatelet(selector), WithBuilderBatchData(batchData),
+		WithBuilderRelation(relation), WithBuilderExclude(true, true), WithBuilderParent(data.AsParam()))
+	if err != nil {
+		return nil, err
+	}
+	db, err := aView.ReaderDb(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get db: %w", err)
+	}
+	rType := aView.DatabaseType()
+	if rType.Kind() == reflect.Ptr {
+		rType = rType.Elem()
+	}
+	newRow := func() interface{} { return reflect.New(rType).Interface() }
+	join, err := newHashJoin(batchData.Values, relation.Join.SpillLimit(), relation.Join.SpillDir, newRow)
+	if err != nil {
+		return nil, err
+	}
+	defer join.close()
+
+	newItem := collector.NewItem()
+	fetchVisitor := s.fetchVisitor(ctx, aView, collector, visitor)
+	emit := func(row interface{}) error {
+		item := newItem()
+		reflect.ValueOf(item).Elem().Set(reflect.ValueOf(row).Elem())
+		return fetchVisitor(item)
+	}
+	keyField := relation.ChildKeyField()
+	annotation := fmt.Sprintf("/* join: %v, parent keys: %v, spill partitions: %v */ ", view.JoinHash, len(batchData.Values), len(join.spilled))
+	execution, err := s.queryRows(ctx, session, aView, collector, db, query, annotation, newRow, func(row interface{}) error {
+		return join.probe(keyField.Interface(xunsafe.AsPointer(row)), row, emit)
+	})
+	if err != nil || session.DryRun {
+		return execution, err
+	}
+	if err = join.finish(emit); err != nil {
+		execution.SetError(err)
+	}
+	return execution, err
+}
+
+// readTempTableJoin pushes parent keys into keys table on the child connector and reads child view with keys subquery,
+// writer connection is used for both to see pushed keys. Keys table is shared, so pushed keys are removed by session ID
+// once read completes or fails, keys left by interrupted sessions are purged after joinKeysTTL
+func (s *Service) readTempTableJoin(ctx context.Context, session *Session, batchData *view.BatchData, aView *view.View, collector *view.Collector, visitor view.VisitorFn, selector *view.Statelet) (*response.SQLExecution, error) {
+	keyColumn := "StrKey"
+	if column, ok := aView.ColumnByName(collector.Relation().Of.On[0].Column); ok && isIntType(column.ColumnType()) {
+		keyColumn = "IntKey"
+	}
+	sessionID := uuid.New().String()
+	keysBatch := *batchData
+	keysBatch.KeysQuery = "SELECT " + keyColumn + " FROM " + view.JoinKeysTable + " WHERE SessionID = " + placeholderFragment
+	keysBatch.ValuesBatch = []interface{}{sessionID}
+	data, _ := session.ParentData()
+	query, err := s.sqlBuilder.Build(ctx, WithBuilderView(aView), WithBuilderStatelet(selector), WithBuilderBatchData(&keysBatch),
+		WithBuilderRelation(collector.Relation()), WithBuilderExclude(false, true), WithBuilderParent(data.AsParam()))
+	if err != nil {
+		return nil, err
+	}
+	annotation := fmt.Sprintf("/* join: %v, parent keys: %v */ ", view.JoinTempTable, len(batchData.Values))
+	db, err := aView.Connector.DB()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get db: %w", err)
+	}
+	if !session.DryRun {
+		defer func() {
+			if err := removeJoinKeys(context.Background(), aView.Connector, db, "SessionID = ?", sessionID); err != nil {
+				fmt.Printf("[WARN] view %v: failed to remove join keys: %v\n", aView.Name, err)
+			}
+		}()
+		if err = pushJoinKeys(ctx, aView.Connector, db, sessionID, batchData.Values); err != nil {
+			return nil, fmt.Errorf("view %v: failed to push join keys: %w", aView.Name, err)
+		}
+	}
+	return s.queryRows(ctx, session, aView, collector, db, query, annotation, collector.NewItem(), s.fetchVisitor(ctx, aView, collector, visitor))
+}
+
+// queryRows runs query with supplied row factory, execution SQL is prefixed with join annotation
+func (s *Service) queryRows(ctx context.Context, session *Session, aView *view.View, collector *view.Collector, db *sql.DB, query *cache.ParmetrizedQuery, annotation string, newRow func() interface{}, handler func(row interface{}) error) (*response.SQLExecution, error) {
+	stats, onDone := NewExecutionInfo(query, nil, collector)
+	defer onDone()
+	stats.SQL = annotation + stats.SQL
+	if session.DryRun {
+		return stats, nil
+	}
+	release, err := aView.Connector.Acquire(ctx)
+	if err != nil {
+		stats.SetError(err)
+		return stats, err
+	}
+	defer release()
+	begin := time.Now()
+	reader, err := read.New(ctx, db, query.SQL, newRow, read.WithUnmappedFn(io.Resolve(collector.Resolve)))
+	if err != nil {
+		return s.HandleSQLError(ctx, err, aView, query, stats)
+	}
+	defer func() {
+		if stmt := reader.Stmt(); stmt != nil {
+			_ = stmt.Close()
+		}
+	}()
+	rows := 0
+	err = reader.QueryAll(ctx, func(row interface{}) error {
+		rows++
+		return handler(row)
+	}, query.Args...)
+	aView.Logger.ReadingData(time.Since(begin), stats.SQL, rows, query.Args, err)
+	recordQuery(ctx, aView.Name, query)
+	if err != nil {
+		logBudgetExceeded(ctx, aView, time.Since(begin), query.SQL, query.Args, err)
+		return s.HandleSQLError(ctx, err, aView, query, stats)
+	}
+	s.explainIfSlow(ctx, aView, collector, query, nil, time.Since(begin))
+	return stats, nil
+}
+
+func pushJoinKeys(ctx context.Context, connector *view.Connector, db *sql.DB, sessionID string, keys []interface{}) error {
+	if _, err := joinKeysTable.EnsureTable(ctx, connector, &dbms.TableConfig{
+		RecordType:     reflect.TypeOf(&JoinKey{}),
+		TableName:      view.JoinKeysTable,
+		CreateIfNeeded: true,
+	}); err != nil {
+		return err
+	}
+	now := time.Now().UTC()
+	if _, purged := joinKeysPurged.LoadOrStore(connector.Name, true); !purged {
+		if err := removeJoinKeys(ctx, connector, db, "CreationTime < ?", now.Add(-joinKeysTTL)); err != nil {
+			fmt.Printf("[WARN] connector %v: failed to purge expired join keys: %v\n", connector.Name, err)
+		}
+	}
+	for offset := 0; offset < len(keys); offset += joinKeysInsertBatch {
+		end := offset + joinKeysInsertBatch
+		if end > len(keys) {
+			end = len(keys)
+		}
+		sb := strings.Builder{}
+		sb.WriteString("INSERT INTO " + view.JoinKeysTable + "(SessionID, IntKey, StrKey, CreationTime) VALUES")
+		var args []interface{}
+		for _, key := range keys[offset:end] {
+			value, ok := joinKey(key)
+			if !ok {
+				continue
+			}
+			if len(args) > 0 {
+				sb.WriteString(",")
+			}
+			sb.WriteString("(?, ?, ?, ?)")
+			intKey, _ := parseIntKey(value)
+			args = append(args, sessionID, intKey, value, now)
+		}
+		if len(args) == 0 {
+			continue
+		}
+		SQL, err := dbms.Bind(ctx, connector, sb.String())
+		if err != nil {
+			return err
+		}
+		if _, err = db.ExecContext(ctx, SQL, args...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func removeJoinKeys(ctx context.Context, connector *view.Connector, db *sql.DB, criteria string, arg interface{}) error {
+	SQL, err := dbms.Bind(ctx, connector, "DELETE FROM "+view.JoinKeysTable+" WHERE "+criteria)
+	if err != nil {
+		return err
+	}
+	_, err = db.ExecContext(ctx, SQL, arg)
+	return err
+}
+
+func parseIntKey(value string) (int64, bool) {
+	ret, err := strconv.ParseInt(value, 10, 64)
+	return ret, err == nil
+}
+
+func isIntType(rType reflect.Type) bool {
+	if rType == nil {
+		return false
+	}
+	for rType.Kind() == reflect.Ptr {
+		rType = rType.Elem()
+	}
+	switch rType.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,54 @@
+package reader
+
+import (
+	"context"
+	"sync"
+
+	"github.com/viant/sqlx/io/read/cache"
+)
+
+type (
+	// Recorder collects SQL executed by reader, it is used by rule contract tests to snapshot generated SQL
+	Recorder struct {
+		mux     sync.Mutex
+		queries []*RecordedQuery
+	}
+
+	// RecordedQuery represents executed view query
+	RecordedQuery struct {
+		View string
+		SQL  string
+		Args []interface{}
+	}
+
+	recorderKey string
+)
+
+const recorderContextKey = recorderKey("recorder")
+
+// WithRecorder returns context with SQL recorder
+func WithRecorder(ctx context.Context, recorder *Recorder) context.Context {
+	return context.WithValue(ctx, recorderContextKey, recorder)
+}
+
+// Queries returns recorded queries in execution order
+func (r *Recorder) Queries() []*RecordedQuery {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	return append([]*RecordedQuery{}, r.queries...)
+}
+
+// Append appends executed view query
+func (r *Recorder) Append(query *RecordedQuery) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	r.queries = append(r.queries, query)
+}
+
+func recordQuery(ctx context.Context, viewName string, query *cache.ParmetrizedQuery) {
+	recorder, _ := ctx.Value(recorderContextKey).(*Recorder)
+	if recorder == nil || query == nil {
+		return
+	}
+	recorder.Append(&RecordedQuery{View: viewName, SQL: query.SQL, Args: query.Args})
+}
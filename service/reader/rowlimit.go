@@ -0,0 +1,18 @@
+package reader
+
+import "context"
+
+type rowLimitKey string
+
+const rowLimitContextKey = rowLimitKey("rowLimit")
+
+// WithRowLimit returns context reading the main view with row limit replacing view default limit, it is used when
+// rows are aggregated after read, i.e. report rollups, time buckets and top-N
+func WithRowLimit(ctx context.Context, limit int) context.Context {
+	return context.WithValue(ctx, rowLimitContextKey, limit)
+}
+
+func rowLimit(ctx context.Context) int {
+	value, _ := ctx.Value(rowLimitContextKey).(int)
+	return value
+}
@@ -136,6 +136,13 @@ func (s *Service) readAll(ctx context.Context, session *Session, collector *view
 	if selector.Ignore {
 		return
 	}
+	if limit := rowLimit(ctx); limit > 0 && parent == session.Parent { //main view only
+		selector.Limit = limit
+	}
+	if err := authorizeProjection(ctx, aView, selector); err != nil {
+		errorCollector.Append(err)
+		return
+	}
 
 	collectorChildren, err := collector.Relations(selector)
 	if err != nil {
@@ -261,6 +268,8 @@ func (s *Service) exhaustRead(ctx context.Context, view *view.View, selector *vi
 	if !session.DryRun {
 		onFinish = view.Counter.Begin(start)
 	}
+	ctx, cancel := view.WithTimeout(ctx)
+	defer cancel()
 	err := s.readObjects(ctx, session, batchData, view, collector, selector, &execution)
 	s.afterRead(ctx, session, collector, &start, execution, err, onFinish)
 	return err
@@ -272,7 +281,12 @@ func (s *Service) readObjects(ctx context.Context, session *Session, batchData *
 	} else {
 		batchData.ValuesBatch, batchData.Size = sliceWithLimit(batchData.Values, batchData.Size, batchData.Size+view.Batch.Size)
 	}
-	visitor := collector.Visitor(ctx)
+	visitor := maskVisitor(ctx, view, collector.Visitor(ctx))
+	if generatorOf(ctx) == nil {
+		if joined, err := s.readJoined(ctx, session, batchData, view, collector, visitor, selector, info); joined {
+			return err
+		}
+	}
 	for {
 		err := s.queryInBatches(ctx, session, view, collector, visitor, info, batchData, selector)
 		if err != nil {
@@ -338,7 +352,7 @@ func (s *Service) querySummary(ctx context.Context, session *Session, aView *vie
 		execInfo.SetError(cacheErr)
 		return execInfo, cacheErr
 	}
-	db, err := aView.Db()
+	db, err := aView.ReaderDb(ctx)
 	if err != nil {
 		execInfo.SetError(err)
 		return nil, err
@@ -352,6 +366,12 @@ func (s *Service) querySummary(ctx context.Context, session *Session, aView *vie
 	args := indexed.Args
 	now := time.Now()
 	defer onDone()
+	release, err := aView.Connector.Acquire(ctx)
+	if err != nil {
+		execInfo.SetError(err)
+		return nil, err
+	}
+	defer release()
 	reader, err := read.New(ctx, db, SQL, func() interface{} {
 		add := appender.Add()
 		return add
@@ -372,6 +392,7 @@ func (s *Service) querySummary(ctx context.Context, session *Session, aView *vie
 		return collector.AddMeta(row)
 	}, args...)
 	if err != nil {
+		logBudgetExceeded(ctx, aView, time.Since(now), SQL, args, err)
 		execInfo.SetError(err)
 		return nil, err
 	}
@@ -786,8 +807,18 @@ func (s *Service) BuildCriteria(ctx context.Context, value interface{}, options
 }
 
 func (s *Service) queryInBatches(ctx context.Context, session *Session, aView *view.View, collector *view.Collector, visitor view.VisitorFn, info *response.SQLExecutions, batchData *view.BatchData, selector *view.Statelet) error {
+	if generator := generatorOf(ctx); generator != nil {
+		executions, err := s.queryGenerated(ctx, session, aView, selector, batchData, collector, visitor, generator)
+		info.Append(executions...)
+		return err
+	}
+	if aView.HTTP != nil {
+		executions, err := s.queryHTTP(ctx, session, aView, selector, batchData, collector, visitor)
+		info.Append(executions...)
+		return err
+	}
 	wg := &sync.WaitGroup{}
-	db, err := aView.Db()
+	db, err := aView.ReaderDb(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to get db: %w", err)
 	}
@@ -870,6 +901,30 @@ func (s *Service) getParentContext(ctx context.Context, row interface{}, collect
 	return fetchContext, nil
 }
 
+// fetchVisitor returns visitor unwrapping database type and calling OnFetch before visiting read row
+func (s *Service) fetchVisitor(ctx context.Context, aView *view.View, collector *view.Collector, visitor view.VisitorFn) view.VisitorFn {
+	var parentProvider func(value interface{}) (interface{}, error)
+	return func(row interface{}) error {
+		row, err := aView.UnwrapDatabaseType(ctx, row)
+		if err != nil {
+			return err
+		}
+		if fetcher, ok := row.(OnFetcher); ok {
+			if aView.PublishParent && parentProvider == nil {
+				parentProvider = collector.ParentRow(collector.Relation())
+			}
+			fetchCtx, err := s.getParentContext(ctx, row, collector, parentProvider)
+			if err != nil {
+				return err
+			}
+			if err = fetcher.OnFetch(fetchCtx); err != nil {
+				return err
+			}
+		}
+		return visitor(row)
+	}
+}
+
 func (s *Service) queryWithHandler(ctx context.Context, session *Session, aView *view.View, collector *view.Collector, columnInMatcher *cache.ParmetrizedQuery, parametrizedSQL *cache.ParmetrizedQuery, db *sql.DB, handler func(row interface{}) error, readData *int) ([]*response.SQLExecution, error) {
 	begin := time.Now()
 	var cacheStats *cache.Stats
@@ -897,13 +952,19 @@ func (s *Service) queryWithHandler(ctx context.Context, session *Session, aView
 		return []*response.SQLExecution{stats}, nil
 	}
 
+	release, err := aView.Connector.Acquire(ctx)
+	if err != nil {
+		stats.SetError(err)
+		return []*response.SQLExecution{stats}, err
+	}
+	defer release()
 	retires := uint32(0)
 BEGIN:
 	reader, err := read.New(ctx, db, parametrizedSQL.SQL, collector.NewItem(), options...)
 
-	isInvalidConnection := err != nil && strings.Contains(err.Error(), "invalid connection")
+	isInvalidConnection := err != nil && (strings.Contains(err.Error(), "invalid connection") || refreshedSecret(ctx, aView, err))
 	if isInvalidConnection && atomic.AddUint32(&retires, 1) < 3 {
-		db, err = aView.Connector.DB()
+		db, err = aView.Connector.ReaderDB(ctx)
 		if err != nil {
 			return nil, fmt.Errorf("failed to connect to db: %w", err)
 		}
@@ -924,9 +985,9 @@ BEGIN:
 	}()
 	err = reader.QueryAll(ctx, handler, parametrizedSQL.Args...)
 
-	isInvalidConnection = err != nil && strings.Contains(err.Error(), "invalid connection")
+	isInvalidConnection = err != nil && (strings.Contains(err.Error(), "invalid connection") || refreshedSecret(ctx, aView, err))
 	if isInvalidConnection && atomic.AddUint32(&retires, 1) < 3 {
-		db, err = aView.Connector.DB()
+		db, err = aView.Connector.ReaderDB(ctx)
 		if err != nil {
 			return nil, fmt.Errorf("failed to connect to db: %w", err)
 		}
@@ -935,8 +996,13 @@ BEGIN:
 	end := time.Now()
 
 	aView.Logger.ReadingData(end.Sub(begin), parametrizedSQL.SQL, *readData, parametrizedSQL.Args, err)
+	recordQuery(ctx, aView.Name, parametrizedSQL)
+	if err == nil {
+		s.explainIfSlow(ctx, aView, collector, parametrizedSQL, cacheStats, end.Sub(begin))
+	}
 	logCacheRead(ctx, aView, cacheStats, end.Sub(begin), *readData, parametrizedSQL.Args)
 	if err != nil {
+		logBudgetExceeded(ctx, aView, end.Sub(begin), parametrizedSQL.SQL, parametrizedSQL.Args, err)
 		stats.SetError(err)
 		anExec, err := s.HandleSQLError(ctx, err, aView, parametrizedSQL, stats)
 		return []*response.SQLExecution{anExec}, err
@@ -1049,6 +1115,14 @@ func (s *Service) HandleSQLError(ctx context.Context, err error, aView *view.Vie
 	return stats, fmt.Errorf("database error occured while fetching Data for view %v %w", aView.Name, err)
 }
 
+// logBudgetExceeded logs query cancelled by route or view execution deadline
+func logBudgetExceeded(ctx context.Context, aView *view.View, elapsed time.Duration, SQL string, args []interface{}, err error) {
+	if !view.IsBudgetExceeded(err) {
+		return
+	}
+	aView.Logger.LogBudgetExceeded(ctx, aView.Name, SQL, elapsed, args...)
+}
+
 func logCacheRead(ctx context.Context, aView *view.View, stats *cache.Stats, elapsed time.Duration, rows int, args []interface{}) {
 	if stats == nil {
 		return
@@ -1185,3 +1259,11 @@ func New() *Service {
 	}
 	return ret
 }
+
+// refreshedSecret reloads view connector secret when database rejected credentials, thus query can be retried
+func refreshedSecret(ctx context.Context, aView *view.View, err error) bool {
+	if aView.Connector == nil {
+		return false
+	}
+	return aView.Connector.RefreshedSecret(ctx, err)
+}
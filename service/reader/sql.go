@@ -724,6 +724,15 @@ func (b *Builder) updateColumnsIn(ctx context.Context, aView *view.View, params
 		return nil
 	}
 
+	if batchData.KeysQuery != "" && columns == 1 {
+		sb.WriteString(batchData.ColumnNames[0])
+		sb.WriteString(inFragment)
+		sb.WriteString(batchData.KeysQuery)
+		sb.WriteString(encloseFragment)
+		params.ColumnsIn = sb.String()
+		return nil
+	}
+
 	switch columns {
 	case 1:
 		sb.WriteString(batchData.ColumnNames[0])
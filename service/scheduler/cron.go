@@ -0,0 +1,197 @@
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type (
+	// Cron represents parsed 5 field cron expression: minute hour day-of-month month day-of-week
+	Cron struct {
+		minute     uint64
+		hour       uint64
+		dayOfMonth uint64
+		month      uint64
+		dayOfWeek  uint64
+		anyDom     bool
+		anyDow     bool
+	}
+
+	cronField struct {
+		name  string
+		min   int
+		max   int
+		names map[string]int
+	}
+)
+
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var cronFields = []*cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12, names: map[string]int{"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6, "jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12}},
+	{name: "day of week", min: 0, max: 7, names: map[string]int{"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6}},
+}
+
+// maxCronLookup limits next fire time search, expressions like "0 0 30 2 *" never match
+const maxCronLookup = 5 * 366 * 24 * time.Hour
+
+// ParseCron parses cron expression, supported are standard 5 fields with lists, ranges, steps, month and
+// week day names, and @yearly, @monthly, @weekly, @daily, @hourly macros
+func ParseCron(expr string) (*Cron, error) {
+	spec := strings.TrimSpace(expr)
+	if macro, ok := cronMacros[strings.ToLower(spec)]; ok {
+		spec = macro
+	}
+	parts := strings.Fields(spec)
+	if len(parts) != len(cronFields) {
+		return nil, fmt.Errorf("invalid cron expression %q: expected %v fields, but had %v", expr, len(cronFields), len(parts))
+	}
+	var bits [5]uint64
+	for i, part := range parts {
+		value, err := cronFields[i].parse(part)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cron expression %q: %w", expr, err)
+		}
+		bits[i] = value
+	}
+	dayOfWeek := bits[4]
+	if dayOfWeek&(1<<7) != 0 { //7 is sunday alias
+		dayOfWeek |= 1
+		dayOfWeek &^= 1 << 7
+	}
+	return &Cron{
+		minute:     bits[0],
+		hour:       bits[1],
+		dayOfMonth: bits[2],
+		month:      bits[3],
+		dayOfWeek:  dayOfWeek,
+		anyDom:     parts[2] == "*" || parts[2] == "?",
+		anyDow:     parts[4] == "*" || parts[4] == "?",
+	}, nil
+}
+
+// Next returns the first matching time after supplied time, in the supplied time location,
+// or zero time if expression never matches
+func (c *Cron) Next(after time.Time) time.Time {
+	t := after.Truncate(time.Minute).Add(time.Minute)
+	limit := after.Add(maxCronLookup)
+	for t.Before(limit) {
+		if !has(c.month, int(t.Month())) {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !c.matchesDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !has(c.hour, t.Hour()) {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+		if !has(c.minute, t.Minute()) {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// matchesDay follows cron semantics: when both day of month and day of week are restricted, either can match
+func (c *Cron) matchesDay(t time.Time) bool {
+	dom := has(c.dayOfMonth, t.Day())
+	dow := has(c.dayOfWeek, int(t.Weekday()))
+	switch {
+	case c.anyDom && c.anyDow:
+		return true
+	case c.anyDom:
+		return dow
+	case c.anyDow:
+		return dom
+	}
+	return dom || dow
+}
+
+func (f *cronField) parse(expr string) (uint64, error) {
+	var result uint64
+	for _, item := range strings.Split(expr, ",") {
+		bits, err := f.parseItem(item)
+		if err != nil {
+			return 0, err
+		}
+		result |= bits
+	}
+	return result, nil
+}
+
+func (f *cronField) parseItem(item string) (uint64, error) {
+	step := 1
+	if index := strings.Index(item, "/"); index != -1 {
+		var err error
+		if step, err = strconv.Atoi(item[index+1:]); err != nil || step <= 0 {
+			return 0, fmt.Errorf("invalid %v step: %v", f.name, item)
+		}
+		item = item[:index]
+	}
+	from, to := f.min, f.max
+	switch {
+	case item == "*" || item == "?":
+	case strings.Contains(item, "-"):
+		bounds := strings.SplitN(item, "-", 2)
+		var err error
+		if from, err = f.value(bounds[0]); err != nil {
+			return 0, err
+		}
+		if to, err = f.value(bounds[1]); err != nil {
+			return 0, err
+		}
+	default:
+		value, err := f.value(item)
+		if err != nil {
+			return 0, err
+		}
+		from = value
+		if step == 1 {
+			to = value
+		}
+	}
+	if from > to {
+		return 0, fmt.Errorf("invalid %v range: %v", f.name, item)
+	}
+	var bits uint64
+	for i := from; i <= to; i += step {
+		bits |= 1 << uint(i)
+	}
+	return bits, nil
+}
+
+func (f *cronField) value(text string) (int, error) {
+	if value, ok := f.names[strings.ToLower(text)]; ok {
+		return value, nil
+	}
+	value, err := strconv.Atoi(text)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %v value: %v", f.name, text)
+	}
+	if value < f.min || value > f.max {
+		return 0, fmt.Errorf("%v value %v out of range %v-%v", f.name, value, f.min, f.max)
+	}
+	return value, nil
+}
+
+func has(bits uint64, value int) bool {
+	return bits&(1<<uint(value)) != 0
+}
@@ -0,0 +1,43 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCron_Next(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	var testCases = []struct {
+		description string
+		expr        string
+		after       time.Time
+		expect      time.Time
+	}{
+		{description: "every 15 minutes", expr: "*/15 * * * *", after: time.Date(2024, 3, 1, 10, 7, 30, 0, time.UTC), expect: time.Date(2024, 3, 1, 10, 15, 0, 0, time.UTC)},
+		{description: "exact minute is not repeated", expr: "*/15 * * * *", after: time.Date(2024, 3, 1, 10, 15, 0, 0, time.UTC), expect: time.Date(2024, 3, 1, 10, 30, 0, 0, time.UTC)},
+		{description: "daily macro", expr: "@daily", after: time.Date(2024, 3, 1, 10, 7, 0, 0, time.UTC), expect: time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC)},
+		{description: "week days range", expr: "30 9 * * mon-fri", after: time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC), expect: time.Date(2024, 3, 4, 9, 30, 0, 0, time.UTC)},
+		{description: "sunday alias", expr: "0 0 * * 7", after: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), expect: time.Date(2024, 3, 3, 0, 0, 0, 0, time.UTC)},
+		{description: "leap day", expr: "0 12 29 feb *", after: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), expect: time.Date(2028, 2, 29, 12, 0, 0, 0, time.UTC)},
+		{description: "day of month or week", expr: "0 0 15 * 1", after: time.Date(2024, 3, 12, 0, 0, 0, 0, time.UTC), expect: time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)},
+		{description: "time zone", expr: "0 6 * * *", after: time.Date(2024, 3, 1, 7, 0, 0, 0, newYork), expect: time.Date(2024, 3, 2, 6, 0, 0, 0, newYork)},
+		{description: "never", expr: "0 0 30 2 *", after: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), expect: time.Time{}},
+	}
+	for _, testCase := range testCases {
+		cron, err := ParseCron(testCase.expr)
+		require.NoError(t, err, testCase.description)
+		actual := cron.Next(testCase.after)
+		assert.True(t, testCase.expect.Equal(actual), "%v: expected %v, but had %v", testCase.description, testCase.expect, actual)
+	}
+}
+
+func TestParseCron_Invalid(t *testing.T) {
+	for _, expr := range []string{"", "* * * *", "60 * * * *", "* * * foo *", "*/0 * * * *", "5-1 * * * *"} {
+		_, err := ParseCron(expr)
+		assert.Error(t, err, expr)
+	}
+}
@@ -0,0 +1,90 @@
+package scheduler
+
+import (
+	"context"
+	"crypto/sha1"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/viant/datly/service/dbms"
+	"github.com/viant/datly/view"
+)
+
+// LocksTable defines schedule leader election table
+const LocksTable = "DATLY_SCHEDULE_LOCKS"
+
+type (
+	// Lock represents schedule lock row, an instance claiming fire time runs the schedule
+	Lock struct {
+		ID         string `sqlx:"primaryKey=true,name=ID"`
+		Name       string
+		Owner      string
+		FireTime   int
+		UpdateTime time.Time
+	}
+
+	locker struct {
+		connector *view.Connector
+		dbms      *dbms.Service
+	}
+)
+
+func newLocker(connector *view.Connector, service *dbms.Service) *locker {
+	return &locker{connector: connector, dbms: service}
+}
+
+func (l *locker) init(ctx context.Context) error {
+	_, err := l.dbms.EnsureTable(ctx, l.connector, &dbms.TableConfig{
+		RecordType:     reflect.TypeOf(&Lock{}),
+		TableName:      LocksTable,
+		CreateIfNeeded: true,
+	})
+	return err
+}
+
+// claim returns true if this owner claimed schedule fire time, only one instance can claim the same fire time
+func (l *locker) claim(ctx context.Context, name, owner string, fireTime time.Time) (bool, error) {
+	db, err := l.connector.DB()
+	if err != nil {
+		return false, err
+	}
+	ID := lockID(name)
+	fire := fireTime.Unix()
+	now := time.Now().UTC()
+	SQL, err := dbms.Bind(ctx, l.connector, "UPDATE "+LocksTable+" SET Owner = ?, FireTime = ?, UpdateTime = ? WHERE ID = ? AND FireTime < ?")
+	if err != nil {
+		return false, err
+	}
+	result, err := db.ExecContext(ctx, SQL, owner, fire, now, ID, fire)
+	if err != nil {
+		return false, fmt.Errorf("failed to claim schedule %v lock: %w", name, err)
+	}
+	if claimed, _ := result.RowsAffected(); claimed > 0 {
+		return true, nil
+	}
+	return l.insert(ctx, db, ID, name, owner, fire, now)
+}
+
+// insert creates lock row for the first schedule run, duplicate key means the fire time was claimed by other instance
+func (l *locker) insert(ctx context.Context, db *sql.DB, ID, name, owner string, fire int64, now time.Time) (bool, error) {
+	SQL, err := dbms.Bind(ctx, l.connector, "INSERT INTO "+LocksTable+"(ID, Name, Owner, FireTime, UpdateTime) VALUES(?, ?, ?, ?, ?)")
+	if err != nil {
+		return false, err
+	}
+	_, err = db.ExecContext(ctx, SQL, ID, name, owner, fire, now)
+	if err == nil {
+		return true, nil
+	}
+	if dbms.IsDuplicateKey(l.connector.DriverName(), err) {
+		return false, nil
+	}
+	return false, fmt.Errorf("failed to create schedule %v lock: %w", name, err)
+}
+
+func lockID(name string) string {
+	hash := sha1.Sum([]byte(name))
+	return hex.EncodeToString(hash[:])
+}
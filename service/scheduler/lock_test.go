@@ -0,0 +1,40 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/viant/datly/view"
+)
+
+func TestLocker_Claim(t *testing.T) {
+	connector := view.NewConnector("schedule_locks", "sqlite3", t.TempDir()+"/locks.db")
+	db, err := connector.DB()
+	require.NoError(t, err)
+	defer db.Close()
+	_, err = db.Exec("CREATE TABLE " + LocksTable + " (ID TEXT PRIMARY KEY, Name TEXT, Owner TEXT, FireTime INTEGER, UpdateTime DATETIME)")
+	require.NoError(t, err)
+	aLocker := newLocker(connector, nil)
+	ctx := context.Background()
+	fireTime := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	claimed, err := aLocker.claim(ctx, "GET:/v1/api/report", "a", fireTime)
+	require.NoError(t, err)
+	assert.True(t, claimed, "first instance should create lock row")
+	claimed, err = aLocker.claim(ctx, "GET:/v1/api/report", "b", fireTime)
+	require.NoError(t, err)
+	assert.False(t, claimed, "claimed fire time should be skipped")
+	claimed, err = aLocker.claim(ctx, "GET:/v1/api/report", "b", fireTime.Add(time.Hour))
+	require.NoError(t, err)
+	assert.True(t, claimed, "next fire time should be claimed")
+
+	_, err = db.Exec("DROP TABLE " + LocksTable)
+	require.NoError(t, err)
+	claimed, err = aLocker.claim(ctx, "GET:/v1/api/other", "a", fireTime)
+	assert.Error(t, err, "failed insert should not be reported as lost claim")
+	assert.False(t, claimed)
+}
@@ -0,0 +1,48 @@
+package scheduler
+
+import (
+	"github.com/viant/gmetric/counter"
+	"github.com/viant/gmetric/stat"
+)
+
+const (
+	successMetric = "Success"
+	errorMetric   = "Error"
+	skippedMetric = "Skipped"
+)
+
+type metricProvider struct{}
+
+var metricKeys = []string{
+	successMetric,
+	errorMetric,
+	skippedMetric,
+	stat.ErrorKey,
+}
+
+func newMetricProvider() counter.Provider {
+	return &metricProvider{}
+}
+
+func (p *metricProvider) Keys() []string {
+	return metricKeys
+}
+
+func (p *metricProvider) Map(value interface{}) int {
+	switch actual := value.(type) {
+	case error:
+		return 1
+	case string:
+		switch actual {
+		case successMetric:
+			return 0
+		case errorMetric:
+			return 1
+		case skippedMetric:
+			return 2
+		case stat.ErrorKey:
+			return 3
+		}
+	}
+	return -1
+}
@@ -0,0 +1,20 @@
+package scheduler
+
+import "github.com/viant/gmetric"
+
+// Option represents scheduler option
+type Option func(s *Service)
+
+// WithMetrics sets metrics service used to expose schedule runs
+func WithMetrics(metrics *gmetric.Service) Option {
+	return func(s *Service) {
+		s.metrics = metrics
+	}
+}
+
+// WithOwner sets instance identity recorded in lock table
+func WithOwner(owner string) Option {
+	return func(s *Service) {
+		s.owner = owner
+	}
+}
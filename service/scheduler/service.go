@@ -0,0 +1,298 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/viant/datly/internal/gmetricx"
+	"github.com/viant/datly/logger"
+	"github.com/viant/datly/repository/contract"
+	"github.com/viant/datly/repository/path"
+	"github.com/viant/datly/service/dbms"
+	"github.com/viant/datly/service/jobs"
+	"github.com/viant/datly/view"
+	"github.com/viant/gmetric"
+	"github.com/viant/xdatly/handler/async"
+)
+
+// JobType identifies scheduled runs in the jobs table
+const JobType = "schedule"
+
+type (
+	// Service runs cron scheduled components, each fire time is executed by one instance only
+	Service struct {
+		dispatcher contract.Dispatcher
+		metrics    *gmetric.Service
+		owner      string
+		dbms       *dbms.Service
+		entries    map[string]*entry
+		ctx        context.Context
+		entryMux   sync.Mutex
+		mux        sync.Mutex
+		backends   map[string]*backend
+	}
+
+	entry struct {
+		path      *path.Path
+		name      string
+		cron      *Cron
+		location  *time.Location
+		connector *view.Connector
+		counter   logger.Counter
+		cancel    context.CancelFunc
+	}
+
+	backend struct {
+		locker *locker
+		jobs   *jobs.Service
+	}
+)
+
+// Add registers scheduled path, connector hosts lock and jobs tables; a changed schedule of already
+// registered path replaces the previous one, once started the schedule runs immediately
+func (s *Service) Add(aPath *path.Path, connector *view.Connector) error {
+	schedule := aPath.Schedule
+	if schedule == nil || schedule.Cron == "" {
+		return fmt.Errorf("path %v %v has no schedule", aPath.Method, aPath.URI)
+	}
+	if connector == nil {
+		return fmt.Errorf("schedule %v %v connector was empty", aPath.Method, aPath.URI)
+	}
+	cron, err := ParseCron(schedule.Cron)
+	if err != nil {
+		return err
+	}
+	location, err := schedule.Location()
+	if err != nil {
+		return err
+	}
+	name := entryName(aPath)
+	s.entryMux.Lock()
+	defer s.entryMux.Unlock()
+	prev, ok := s.entries[name]
+	if ok && prev.path == aPath && prev.connector == connector {
+		return nil
+	}
+	if ok {
+		prev.stop()
+	}
+	item := &entry{
+		path:      aPath,
+		name:      name,
+		cron:      cron,
+		location:  location,
+		connector: connector,
+		counter:   s.counter(name),
+	}
+	s.entries[name] = item
+	if s.ctx != nil {
+		s.start(item)
+	}
+	return nil
+}
+
+// Retain stops and removes schedules of paths other than supplied ones
+func (s *Service) Retain(paths []*path.Path) {
+	names := make(map[string]bool, len(paths))
+	for _, aPath := range paths {
+		names[entryName(aPath)] = true
+	}
+	s.entryMux.Lock()
+	defer s.entryMux.Unlock()
+	for name, item := range s.entries {
+		if !names[name] {
+			item.stop()
+			delete(s.entries, name)
+		}
+	}
+}
+
+// Start runs schedules until context is cancelled
+func (s *Service) Start(ctx context.Context) {
+	s.entryMux.Lock()
+	defer s.entryMux.Unlock()
+	s.ctx = ctx
+	for _, item := range s.entries {
+		s.start(item)
+	}
+}
+
+func (s *Service) start(e *entry) {
+	ctx, cancel := context.WithCancel(s.ctx)
+	e.cancel = cancel
+	go s.loop(ctx, e)
+}
+
+func (e *entry) stop() {
+	if e.cancel != nil {
+		e.cancel()
+	}
+}
+
+func entryName(aPath *path.Path) string {
+	return aPath.Method + ":" + aPath.URI
+}
+
+func (s *Service) loop(ctx context.Context, e *entry) {
+	for {
+		now := time.Now().In(e.location)
+		next := e.cron.Next(now)
+		if next.IsZero() {
+			fmt.Printf("[WARN] schedule %v: cron %v has no next run\n", e.name, e.path.Schedule.Cron)
+			return
+		}
+		timer := time.NewTimer(next.Sub(now) + jitter(e.path.Schedule.Jitter()))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+		if err := s.run(ctx, e, next); err != nil {
+			fmt.Printf("[WARN] schedule %v run at %v failed: %v\n", e.name, next.Format(time.RFC3339), err)
+		}
+	}
+}
+
+// run executes component when this instance claimed fire time, the run is recorded in the jobs table
+func (s *Service) run(ctx context.Context, e *entry, fireTime time.Time) error {
+	aBackend, err := s.backend(ctx, e.connector)
+	if err != nil {
+		e.counter.IncrementValue(errorMetric)
+		return err
+	}
+	claimed, err := aBackend.locker.claim(ctx, e.name, s.owner, fireTime)
+	if err != nil {
+		e.counter.IncrementValue(errorMetric)
+		return err
+	}
+	if !claimed {
+		e.counter.IncrementValue(skippedMetric)
+		return nil
+	}
+	start := time.Now()
+	onDone := e.counter.Begin(start)
+	job := e.newJob(fireTime, start)
+	if err = aBackend.jobs.CreateJob(ctx, job); err != nil {
+		fmt.Printf("[WARN] schedule %v: failed to record job: %v\n", e.name, err)
+		job = nil
+	}
+	runErr := s.dispatch(ctx, e)
+	end := time.Now()
+	if runErr != nil {
+		onDone(end, runErr)
+	} else {
+		onDone(end)
+		e.counter.IncrementValue(successMetric)
+	}
+	if job == nil {
+		return runErr
+	}
+	job.EndTime = &end
+	job.RunTimeInMcs = int(end.Sub(start).Microseconds())
+	job.Status = string(async.StatusDone)
+	if runErr != nil {
+		job.Status = string(async.StatusError)
+		message := runErr.Error()
+		job.Error = &message
+	}
+	if err = aBackend.jobs.UpdateJob(ctx, job); err != nil {
+		fmt.Printf("[WARN] schedule %v: failed to update job %v: %v\n", e.name, job.ID, err)
+	}
+	return runErr
+}
+
+func (s *Service) dispatch(ctx context.Context, e *entry) error {
+	if timeout := e.path.Timeout(); timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	schedule := e.path.Schedule
+	URL := "http://localhost" + e.path.URI
+	if schedule.Query != "" {
+		URL += "?" + strings.TrimPrefix(schedule.Query, "?")
+	}
+	request, err := http.NewRequestWithContext(ctx, e.path.Method, URL, strings.NewReader(schedule.Body))
+	if err != nil {
+		return err
+	}
+	if schedule.Body != "" {
+		request.Header.Set("Content-Type", "application/json")
+	}
+	_, err = s.dispatcher.Dispatch(ctx, &e.path.Path, contract.WithRequest(request))
+	return err
+}
+
+func (s *Service) backend(ctx context.Context, connector *view.Connector) (*backend, error) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	if ret, ok := s.backends[connector.Name]; ok {
+		return ret, nil
+	}
+	ret := &backend{locker: newLocker(connector, s.dbms), jobs: jobs.New(connector)}
+	if err := ret.locker.init(ctx); err != nil {
+		return nil, fmt.Errorf("failed to initialise schedule locks: %w", err)
+	}
+	if err := ret.jobs.Init(ctx); err != nil {
+		return nil, fmt.Errorf("failed to initialise schedule jobs: %w", err)
+	}
+	s.backends[connector.Name] = ret
+	return ret, nil
+}
+
+func (s *Service) counter(name string) logger.Counter {
+	metricName := "schedule." + strings.ReplaceAll(strings.Trim(name, "/"), "/", ".")
+	return logger.NewCounter(gmetricx.NewCounter(s.metrics, metricName, func() *gmetric.Operation {
+		return s.metrics.MultiOperationCounter("schedule", metricName, name+" schedule", time.Millisecond, time.Minute, 2, newMetricProvider())
+	}))
+}
+
+func (e *entry) newJob(fireTime, start time.Time) *async.Job {
+	schedule := e.path.Schedule
+	return &async.Job{
+		ID:            uuid.New().String(),
+		MatchKey:      e.name + "@" + fireTime.UTC().Format(time.RFC3339),
+		Status:        string(async.StatusRunning),
+		Request:       async.Request{Method: e.path.Method, URI: e.path.URI, State: schedule.Body},
+		Labels:        schedule.Cron,
+		JobType:       JobType,
+		CreationTime:  start,
+		StartTime:     &start,
+		WaitTimeInMcs: int(start.Sub(fireTime).Microseconds()),
+	}
+}
+
+func jitter(max time.Duration) time.Duration {
+	if max <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(max)))
+}
+
+func defaultOwner() string {
+	host, _ := os.Hostname()
+	return fmt.Sprintf("%v:%v", host, os.Getpid())
+}
+
+// New creates scheduler service
+func New(dispatcher contract.Dispatcher, opts ...Option) *Service {
+	ret := &Service{
+		dispatcher: dispatcher,
+		owner:      defaultOwner(),
+		dbms:       dbms.New(),
+		entries:    map[string]*entry{},
+		backends:   map[string]*backend{},
+	}
+	for _, opt := range opts {
+		opt(ret)
+	}
+	return ret
+}
@@ -0,0 +1,36 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/viant/datly/repository/contract"
+	"github.com/viant/datly/repository/path"
+	"github.com/viant/datly/view"
+	"github.com/viant/gmetric"
+)
+
+func TestService_Retain(t *testing.T) {
+	connector := view.NewConnector("schedule", "sqlite3", ":memory:")
+	hourly := &path.Path{Path: contract.Path{Method: "GET", URI: "/v1/api/hourly"}, Schedule: &path.Schedule{Cron: "@hourly"}}
+	daily := &path.Path{Path: contract.Path{Method: "GET", URI: "/v1/api/daily"}, Schedule: &path.Schedule{Cron: "@daily"}}
+	aScheduler := New(nil, WithMetrics(gmetric.New()))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	aScheduler.Start(ctx)
+	require.NoError(t, aScheduler.Add(hourly, connector))
+	require.NoError(t, aScheduler.Add(daily, connector))
+	started := aScheduler.entries["GET:/v1/api/hourly"]
+	require.NoError(t, aScheduler.Add(hourly, connector))
+	assert.Same(t, started, aScheduler.entries["GET:/v1/api/hourly"], "unchanged path should keep running schedule")
+
+	modified := &path.Path{Path: hourly.Path, Schedule: &path.Schedule{Cron: "*/30 * * * *"}}
+	require.NoError(t, aScheduler.Add(modified, connector))
+	assert.NotSame(t, started, aScheduler.entries["GET:/v1/api/hourly"], "modified path should replace schedule")
+
+	aScheduler.Retain([]*path.Path{modified})
+	assert.Len(t, aScheduler.entries, 1)
+	assert.Contains(t, aScheduler.entries, "GET:/v1/api/hourly")
+}
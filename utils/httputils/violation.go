@@ -8,6 +8,9 @@ import (
 	"github.com/viant/xdatly/handler/validator"
 )
 
+// ParameterCheck is violation check, and stable message key, of parameter errors
+const ParameterCheck = "parameter"
+
 type Violations []*validator.Violation
 
 func (v Violations) MergeGoViolation(violations []*govalidator.Violation) validator.Violations {
@@ -50,7 +53,7 @@ func (v Violations) MergeErrors(errors []*response.Error) validator.Violations {
 		aViolation := &validator.Violation{
 			Location: anError.View + "/" + anError.Parameter,
 			Value:    anError.Object,
-			Check:    fmt.Sprintf("%T", anError.Error()),
+			Check:    ParameterCheck,
 			Message:  anError.Message,
 		}
 		ret = append(ret, aViolation)
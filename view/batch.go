@@ -9,6 +9,7 @@ type BatchData struct {
 	ValuesBatch          []interface{}   // batched scalar values
 	CompositeValues      [][]interface{} // all composite parent tuples
 	CompositeValuesBatch [][]interface{} // batched composite tuples
+	KeysQuery            string          // parent keys subquery used instead of IN list, ValuesBatch holds its arguments
 }
 
 func (b *BatchData) ColIn() []interface{} {
@@ -0,0 +1,72 @@
+package view
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrConnectorBusy is returned when a query could not obtain connector bulkhead slot within QueueTimeoutMs
+var ErrConnectorBusy = errors.New("connector query queue timeout")
+
+type bulkheadRegistry struct {
+	mux   sync.Mutex
+	index map[string]chan struct{}
+}
+
+var aBulkheads = &bulkheadRegistry{index: map[string]chan struct{}{}}
+
+// Acquire reserves connector query slot, the returned function releases it; when MaxConcurrentQueries is not set
+// queries are not limited
+func (c *Connector) Acquire(ctx context.Context) (func(), error) {
+	if c == nil || c.MaxConcurrentQueries <= 0 {
+		return func() {}, nil
+	}
+	slots := aBulkheads.slots(c.Name, c.MaxConcurrentQueries)
+	select {
+	case slots <- struct{}{}:
+		return func() { <-slots }, nil
+	default:
+	}
+	var timeout <-chan time.Time
+	if c.QueueTimeoutMs > 0 {
+		timer := time.NewTimer(time.Duration(c.QueueTimeoutMs) * time.Millisecond)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+	select {
+	case slots <- struct{}{}:
+		return func() { <-slots }, nil
+	case <-timeout:
+		return nil, fmt.Errorf("%w: %v, max concurrent queries: %v", ErrConnectorBusy, c.Name, c.MaxConcurrentQueries)
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (r *bulkheadRegistry) slots(name string, limit int) chan struct{} {
+	key := fmt.Sprintf("%v|%v", name, limit)
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	ret, ok := r.index[key]
+	if !ok {
+		ret = make(chan struct{}, limit)
+		r.index[key] = ret
+	}
+	return ret
+}
+
+// WithTimeout returns context with view execution deadline, when TimeoutMs is not set the context is returned as is
+func (v *View) WithTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if v == nil || v.TimeoutMs <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, time.Duration(v.TimeoutMs)*time.Millisecond)
+}
+
+// IsBudgetExceeded returns true if error was caused by exceeded execution deadline
+func IsBudgetExceeded(err error) bool {
+	return errors.Is(err, context.DeadlineExceeded)
+}
@@ -0,0 +1,56 @@
+package view
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConnector_Acquire(t *testing.T) {
+	connector := &Connector{Connection: Connection{DBConfig: DBConfig{Name: "bulkhead", MaxConcurrentQueries: 2, QueueTimeoutMs: 20}}}
+	ctx := context.Background()
+
+	release1, err := connector.Acquire(ctx)
+	require.NoError(t, err)
+	release2, err := connector.Acquire(ctx)
+	require.NoError(t, err)
+
+	_, err = connector.Acquire(ctx)
+	assert.True(t, errors.Is(err, ErrConnectorBusy), "queued query should time out")
+
+	cancelCtx, cancel := context.WithTimeout(ctx, time.Millisecond)
+	defer cancel()
+	connector.QueueTimeoutMs = 0
+	_, err = connector.Acquire(cancelCtx)
+	assert.True(t, IsBudgetExceeded(err), "queued query should honour request deadline")
+
+	release1()
+	release3, err := connector.Acquire(ctx)
+	require.NoError(t, err, "released slot should be reused")
+	release2()
+	release3()
+
+	unlimited := &Connector{Connection: Connection{DBConfig: DBConfig{Name: "unlimited"}}}
+	for i := 0; i < 5; i++ {
+		_, err = unlimited.Acquire(ctx)
+		require.NoError(t, err)
+	}
+}
+
+func TestView_WithTimeout(t *testing.T) {
+	ctx := context.Background()
+	actual, cancel := (&View{}).WithTimeout(ctx)
+	defer cancel()
+	_, ok := actual.Deadline()
+	assert.False(t, ok)
+
+	actual, cancel = (&View{TimeoutMs: 1000}).WithTimeout(ctx)
+	defer cancel()
+	deadline, ok := actual.Deadline()
+	assert.True(t, ok)
+	assert.WithinDuration(t, time.Now().Add(time.Second), deadline, 100*time.Millisecond)
+}
@@ -58,6 +58,7 @@ type (
 		MaxCases       *int       `json:",omitempty" yaml:",omitempty"`
 		FieldNames     []string   `json:",omitempty" yaml:",omitempty"`
 		Connector      *Connector `json:",omitempty"`
+		Watermark      *Watermark `json:",omitempty" yaml:",omitempty"`
 		Cases          []*CacheParameters
 	}
 
@@ -392,6 +393,7 @@ func (w *Warmup) clone() *Warmup {
 		cloned.MaxCases = &maxCases
 	}
 	cloned.Connector = w.Connector.clone()
+	cloned.Watermark = w.Watermark.clone()
 	for _, item := range w.Cases {
 		cloned.Cases = append(cloned.Cases, item.clone())
 	}
@@ -613,6 +615,9 @@ func (c *Cache) initWarmup(ctx context.Context, resource *Resource) error {
 	if err := c.validateWarmupBudget("maxCases", c.Warmup.MaxCases); err != nil {
 		return err
 	}
+	if err := c.validateWatermark(c.Warmup.Watermark); err != nil {
+		return err
+	}
 	for _, dataset := range c.Warmup.Cases {
 		if err := c.validateWarmupFieldNames(dataset.FieldNames); err != nil {
 			return err
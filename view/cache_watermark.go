@@ -0,0 +1,187 @@
+package view
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	as "github.com/aerospike/aerospike-client-go"
+	"github.com/aerospike/aerospike-client-go/types"
+	"github.com/viant/afs"
+	"github.com/viant/afs/file"
+	"github.com/viant/afs/url"
+)
+
+const (
+	watermarkKey    = "datly:warmup:watermark"
+	watermarkBin    = "State"
+	watermarkSuffix = ".watermark.json"
+)
+
+type (
+	// Watermark enables incremental warmup, cache keys are re-warmed only when the watermark changed since the last run.
+	// Column max value is probed per cache key with the key criteria, so only keys with changed rows are re-warmed;
+	// deleted rows do not move the max value and are not detected, use Partition or a soft delete column updating the marker instead.
+	Watermark struct {
+		Column    string `json:",omitempty" yaml:",omitempty"` // change marker column i.e. UPDATED_AT, max value is tracked per cache key
+		Partition bool   `json:",omitempty" yaml:",omitempty"` // tracks view.Partitioned partitions, partition change re-warms all keys
+	}
+
+	// WatermarkState represents per cache key watermarks of the last warmup run
+	WatermarkState struct {
+		Keys       map[string]string
+		UpdateTime time.Time
+	}
+
+	// WatermarkStore persists warmup watermark state in the cache provider
+	WatermarkStore interface {
+		Load(ctx context.Context) (*WatermarkState, error)
+		Save(ctx context.Context, state *WatermarkState) error
+	}
+
+	afsWatermarks struct {
+		fs  afs.Service
+		URL string
+	}
+
+	aerospikeWatermarks struct {
+		client    func() (*as.Client, error)
+		namespace string
+		set       string
+	}
+)
+
+// Enabled returns true if watermark tracks any change marker
+func (w *Watermark) Enabled() bool {
+	return w != nil && (w.Column != "" || w.Partition)
+}
+
+func (w *Watermark) clone() *Watermark {
+	if w == nil {
+		return nil
+	}
+	cloned := *w
+	return &cloned
+}
+
+func (c *Cache) validateWatermark(watermark *Watermark) error {
+	if watermark == nil {
+		return nil
+	}
+	if watermark.Column != "" {
+		if _, ok := c.owner.ColumnByName(watermark.Column); !ok {
+			return fmt.Errorf("not found warmup watermark column %v at View %v", watermark.Column, c.owner.Name)
+		}
+	}
+	if watermark.Partition && c.owner.Partitioned == nil {
+		return fmt.Errorf("warmup watermark partition requires partitioned View %v", c.owner.Name)
+	}
+	return nil
+}
+
+// NewWatermarkState creates an empty watermark state
+func NewWatermarkState() *WatermarkState {
+	return &WatermarkState{Keys: map[string]string{}}
+}
+
+// WatermarkStore returns incremental warmup state store, located next to cached data
+func (c *Cache) WatermarkStore() (WatermarkStore, error) {
+	if c.owner == nil {
+		return nil, fmt.Errorf("cache %v was not initialized", c.Name)
+	}
+	location, err := c.expandLocation(c.owner)
+	if err != nil {
+		return nil, err
+	}
+	if url.Scheme(c.Provider, "") != aerospikeType {
+		return &afsWatermarks{fs: afs.New(), URL: strings.TrimRight(location, "/") + watermarkSuffix}, nil
+	}
+	host, port, namespace, err := c.split(c.Provider)
+	if err != nil {
+		return nil, err
+	}
+	return &aerospikeWatermarks{client: aClientPool.Client(host, port), namespace: namespace, set: location}, nil
+}
+
+func (w *afsWatermarks) Load(ctx context.Context) (*WatermarkState, error) {
+	if ok, _ := w.fs.Exists(ctx, w.URL); !ok {
+		return NewWatermarkState(), nil
+	}
+	data, err := w.fs.DownloadWithURL(ctx, w.URL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load warmup watermark %v: %w", w.URL, err)
+	}
+	return decodeWatermarkState(data)
+}
+
+func (w *afsWatermarks) Save(ctx context.Context, state *WatermarkState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	if err = w.fs.Upload(ctx, w.URL, file.DefaultFileOsMode, bytes.NewReader(data)); err != nil {
+		return fmt.Errorf("failed to save warmup watermark %v: %w", w.URL, err)
+	}
+	return nil
+}
+
+func (w *aerospikeWatermarks) Load(ctx context.Context) (*WatermarkState, error) {
+	client, key, err := w.key()
+	if err != nil {
+		return nil, err
+	}
+	record, err := client.Get(nil, key, watermarkBin)
+	if err != nil {
+		if asErr, ok := err.(types.AerospikeError); ok && asErr.ResultCode() == types.KEY_NOT_FOUND_ERROR {
+			return NewWatermarkState(), nil
+		}
+		return nil, fmt.Errorf("failed to load warmup watermark %v.%v: %w", w.namespace, w.set, err)
+	}
+	data, _ := record.Bins[watermarkBin].(string)
+	return decodeWatermarkState([]byte(data))
+}
+
+func (w *aerospikeWatermarks) Save(ctx context.Context, state *WatermarkState) error {
+	client, key, err := w.key()
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	policy := as.NewWritePolicy(0, as.TTLDontExpire)
+	if err = client.Put(policy, key, as.BinMap{watermarkBin: string(data)}); err != nil {
+		return fmt.Errorf("failed to save warmup watermark %v.%v: %w", w.namespace, w.set, err)
+	}
+	return nil
+}
+
+func (w *aerospikeWatermarks) key() (*as.Client, *as.Key, error) {
+	client, err := w.client()
+	if err != nil {
+		return nil, nil, err
+	}
+	key, err := as.NewKey(w.namespace, w.set, watermarkKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	return client, key, nil
+}
+
+func decodeWatermarkState(data []byte) (*WatermarkState, error) {
+	ret := NewWatermarkState()
+	if len(bytes.TrimSpace(data)) == 0 {
+		return ret, nil
+	}
+	if err := json.Unmarshal(data, ret); err != nil {
+		return nil, fmt.Errorf("invalid warmup watermark state: %w", err)
+	}
+	if ret.Keys == nil {
+		ret.Keys = map[string]string{}
+	}
+	return ret, nil
+}
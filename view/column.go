@@ -31,6 +31,7 @@ type (
 		Codec          *state.Codec `json:",omitempty"`
 		DatabaseColumn string       `json:",omitempty"`
 		IndexedBy      string       `json:",omitempty"`
+		Auth           *ColumnAuth  `json:",omitempty"`
 
 		rType         reflect.Type
 		sqlExpression string
@@ -86,6 +87,9 @@ func (c *Column) Init(resource state.Resource, caseFormat text.CaseFormat, allow
 	if err := c.initGroupable(); err != nil {
 		return err
 	}
+	if err := c.initAuth(); err != nil {
+		return err
+	}
 	err := c.EnsureType(resource.LookupType())
 	if err != nil {
 		return err
@@ -107,6 +111,25 @@ func (c *Column) Init(resource state.Resource, caseFormat text.CaseFormat, allow
 	return nil
 }
 
+func (c *Column) initAuth() error {
+	if c.Auth == nil && c.Tag != "" {
+		if value, ok := reflect.StructTag(strings.TrimSpace(c.Tag)).Lookup(AuthTag); ok {
+			auth, err := ParseColumnAuth(value)
+			if err != nil {
+				return fmt.Errorf("invalid auth tag for column %s: %w", c.Name, err)
+			}
+			c.Auth = auth
+		}
+	}
+	if c.Auth == nil {
+		return nil
+	}
+	if err := c.Auth.Init(); err != nil {
+		return fmt.Errorf("invalid auth for column %s: %w", c.Name, err)
+	}
+	return nil
+}
+
 func (c *Column) initGroupable() error {
 	if c._groupableSet || c.Tag == "" {
 		return nil
@@ -231,6 +254,9 @@ func (c *Column) ApplyConfig(config *ColumnConfig) {
 		c.Groupable = *config.Groupable
 		c._groupableSet = true
 	}
+	if config.Auth != nil {
+		c.Auth = config.Auth
+	}
 	c._initialized = false
 }
 
@@ -273,6 +299,7 @@ type (
 		Format              *string      `json:",omitempty"`
 		Tag                 *string      `json:",omitempty"`
 		Default             *string      `json:",omitempty"`
+		Auth                *ColumnAuth  `json:",omitempty"`
 	}
 
 	ColumnConfigs []*ColumnConfig
@@ -0,0 +1,280 @@
+package view
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/viant/xdatly/handler/exec"
+)
+
+const (
+	// AuthTag column authorization struct tag, i.e. auth:"roles=admin|hr,scopes=pii,mask=partial"
+	AuthTag = "auth"
+
+	MaskNull    = "null"
+	MaskRedact  = "redact"
+	MaskHash    = "hash"
+	MaskPartial = "partial"
+
+	redacted              = "****"
+	defaultPartialVisible = 4
+)
+
+type (
+	// ColumnAuth represents column level authorization, caller needs any of roles or scopes to see column,
+	// unauthorized column is dropped from projection unless mask is specified
+	ColumnAuth struct {
+		Roles   []string `json:",omitempty" yaml:",omitempty"`
+		Scopes  []string `json:",omitempty" yaml:",omitempty"`
+		Mask    string   `json:",omitempty" yaml:",omitempty"`
+		Visible int      `json:",omitempty" yaml:",omitempty"` //partial mask visible trailing chars
+	}
+
+	// Grants represents caller roles and scopes
+	Grants struct {
+		Roles  []string
+		Scopes []string
+	}
+
+	grantsKey string
+)
+
+const grantsContextKey = grantsKey("grants")
+
+var (
+	maskSecret     []byte
+	maskSecretLock sync.RWMutex
+)
+
+// SetMaskSecret sets hash mask HMAC secret, shared across instances so hashed values can be correlated
+func SetMaskSecret(secret []byte) {
+	maskSecretLock.Lock()
+	defer maskSecretLock.Unlock()
+	maskSecret = secret
+}
+
+// hashMaskSecret returns hash mask HMAC secret, random per process key is used when secret is not configured
+func hashMaskSecret() []byte {
+	maskSecretLock.RLock()
+	secret := maskSecret
+	maskSecretLock.RUnlock()
+	if len(secret) > 0 {
+		return secret
+	}
+	maskSecretLock.Lock()
+	defer maskSecretLock.Unlock()
+	if len(maskSecret) == 0 {
+		maskSecret = make([]byte, 32)
+		_, _ = rand.Read(maskSecret)
+	}
+	return maskSecret
+}
+
+// Init validates column auth
+func (a *ColumnAuth) Init() error {
+	switch a.Mask {
+	case "", MaskNull, MaskRedact, MaskHash, MaskPartial:
+	default:
+		return fmt.Errorf("unsupported column mask: %v, supported: %v, %v, %v, %v", a.Mask, MaskNull, MaskRedact, MaskHash, MaskPartial)
+	}
+	if a.Visible < 0 {
+		return fmt.Errorf("invalid column mask visible chars: %v", a.Visible)
+	}
+	if a.Mask == MaskPartial && a.Visible == 0 {
+		a.Visible = defaultPartialVisible
+	}
+	return nil
+}
+
+// Allowed returns true if grants satisfy any of required roles or scopes
+func (a *ColumnAuth) Allowed(grants *Grants) bool {
+	if a == nil || (len(a.Roles) == 0 && len(a.Scopes) == 0) {
+		return true
+	}
+	if grants == nil {
+		return false
+	}
+	return containsAny(grants.Roles, a.Roles) || containsAny(grants.Scopes, a.Scopes)
+}
+
+// Masked returns true if unauthorized column value is masked rather than dropped
+func (a *ColumnAuth) Masked() bool {
+	return a != nil && a.Mask != ""
+}
+
+// MaskValue masks field value in place
+func (a *ColumnAuth) MaskValue(value reflect.Value) {
+	if !value.CanSet() {
+		return
+	}
+	text, isText := maskText(value)
+	if !isText || a.Mask == MaskNull {
+		value.Set(reflect.Zero(value.Type()))
+		return
+	}
+	if text == "" {
+		return
+	}
+	switch a.Mask {
+	case MaskRedact:
+		text = redacted
+	case MaskHash:
+		mac := hmac.New(sha256.New, hashMaskSecret())
+		mac.Write([]byte(text))
+		text = hex.EncodeToString(mac.Sum(nil))
+	case MaskPartial:
+		runes := []rune(text)
+		visible := a.Visible
+		if visible >= len(runes) {
+			visible = 0
+		}
+		text = redacted + string(runes[len(runes)-visible:])
+	}
+	if value.Kind() == reflect.Ptr {
+		value.Set(reflect.ValueOf(&text))
+		return
+	}
+	value.SetString(text)
+}
+
+func maskText(value reflect.Value) (string, bool) {
+	switch value.Kind() {
+	case reflect.String:
+		return value.String(), true
+	case reflect.Ptr:
+		if value.Type().Elem().Kind() != reflect.String {
+			return "", false
+		}
+		if value.IsNil() {
+			return "", true
+		}
+		return value.Elem().String(), true
+	}
+	return "", false
+}
+
+// Tag returns auth tag value
+func (a *ColumnAuth) Tag() string {
+	var items []string
+	if len(a.Roles) > 0 {
+		items = append(items, "roles="+strings.Join(a.Roles, "|"))
+	}
+	if len(a.Scopes) > 0 {
+		items = append(items, "scopes="+strings.Join(a.Scopes, "|"))
+	}
+	if a.Mask != "" {
+		items = append(items, "mask="+a.Mask)
+	}
+	if a.Visible > 0 && a.Mask == MaskPartial {
+		items = append(items, "visible="+strconv.Itoa(a.Visible))
+	}
+	return strings.Join(items, ",")
+}
+
+// ParseColumnAuth parses auth tag value
+func ParseColumnAuth(tag string) (*ColumnAuth, error) {
+	ret := &ColumnAuth{}
+	for _, item := range strings.Split(tag, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		key, value, _ := strings.Cut(item, "=")
+		switch strings.ToLower(strings.TrimSpace(key)) {
+		case "roles", "role":
+			ret.Roles = splitGrants(value)
+		case "scopes", "scope":
+			ret.Scopes = splitGrants(value)
+		case "mask":
+			ret.Mask = strings.ToLower(strings.TrimSpace(value))
+		case "visible":
+			visible, err := strconv.Atoi(strings.TrimSpace(value))
+			if err != nil {
+				return nil, fmt.Errorf("invalid auth tag visible: %v", value)
+			}
+			ret.Visible = visible
+		default:
+			return nil, fmt.Errorf("unsupported auth tag option: %v", key)
+		}
+	}
+	return ret, ret.Init()
+}
+
+// FieldAllowed returns true if struct field auth tag is satisfied by context grants
+func FieldAllowed(ctx context.Context, field reflect.StructField) bool {
+	tag, ok := field.Tag.Lookup(AuthTag)
+	if !ok {
+		return true
+	}
+	auth, err := ParseColumnAuth(tag)
+	if err != nil {
+		return false
+	}
+	return auth.Allowed(GrantsOf(ctx))
+}
+
+// WithGrants returns context with caller grants, it takes precedence over JWT scope claim
+func WithGrants(ctx context.Context, grants *Grants) context.Context {
+	return context.WithValue(ctx, grantsContextKey, grants)
+}
+
+// GrantsOf returns caller grants, by default JWT scope claim entries are used as both roles and scopes
+func GrantsOf(ctx context.Context) *Grants {
+	if grants, ok := ctx.Value(grantsContextKey).(*Grants); ok {
+		return grants
+	}
+	if execContext := exec.GetContext(ctx); execContext != nil && execContext.Auth != nil {
+		return NewGrants(execContext.Auth.Scope)
+	}
+	return &Grants{}
+}
+
+// NewGrants creates grants from space or comma separated scope claim
+func NewGrants(scope string) *Grants {
+	items := strings.FieldsFunc(scope, func(r rune) bool { return r == ' ' || r == ',' })
+	return &Grants{Roles: items, Scopes: items}
+}
+
+// Authorize splits unauthorized columns into forbidden ones, dropped from projection, and masked ones
+func (c Columns) Authorize(grants *Grants) (forbidden []*Column, masked []*Column) {
+	for _, column := range c {
+		if column.Auth == nil || column.Auth.Allowed(grants) {
+			continue
+		}
+		if column.Auth.Masked() {
+			masked = append(masked, column)
+			continue
+		}
+		forbidden = append(forbidden, column)
+	}
+	return forbidden, masked
+}
+
+func splitGrants(value string) []string {
+	var ret []string
+	for _, item := range strings.Split(value, "|") {
+		if item = strings.TrimSpace(item); item != "" {
+			ret = append(ret, item)
+		}
+	}
+	return ret
+}
+
+func containsAny(granted []string, required []string) bool {
+	for _, candidate := range required {
+		for _, item := range granted {
+			if strings.EqualFold(item, candidate) {
+				return true
+			}
+		}
+	}
+	return false
+}
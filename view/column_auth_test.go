@@ -0,0 +1,89 @@
+package view
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseColumnAuth(t *testing.T) {
+	auth, err := ParseColumnAuth("roles=admin|hr,scopes=pii,mask=partial")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"admin", "hr"}, auth.Roles)
+	assert.Equal(t, []string{"pii"}, auth.Scopes)
+	assert.Equal(t, MaskPartial, auth.Mask)
+	assert.Equal(t, 4, auth.Visible)
+	assert.Equal(t, "roles=admin|hr,scopes=pii,mask=partial,visible=4", auth.Tag())
+
+	_, err = ParseColumnAuth("roles=admin,mask=blur")
+	assert.Error(t, err)
+	_, err = ParseColumnAuth("owner=admin")
+	assert.Error(t, err)
+}
+
+func TestColumnAuth_Allowed(t *testing.T) {
+	auth := &ColumnAuth{Roles: []string{"admin"}, Scopes: []string{"pii"}}
+	assert.True(t, auth.Allowed(&Grants{Roles: []string{"Admin"}}))
+	assert.True(t, auth.Allowed(NewGrants("read pii")))
+	assert.False(t, auth.Allowed(NewGrants("read")))
+	assert.False(t, auth.Allowed(nil))
+	assert.True(t, (&ColumnAuth{Mask: MaskRedact}).Allowed(nil))
+}
+
+func TestColumnAuth_MaskValue(t *testing.T) {
+	type record struct {
+		SSN   string
+		Email *string
+		Phone string
+		Token string
+		ID    int
+	}
+	email := "john@acme.com"
+	row := &record{SSN: "123-45-6789", Email: &email, Phone: "5551234", Token: "abc", ID: 7}
+	value := reflect.ValueOf(row).Elem()
+
+	SetMaskSecret([]byte("secret"))
+	defer SetMaskSecret(nil)
+	(&ColumnAuth{Mask: MaskPartial, Visible: 4}).MaskValue(value.FieldByName("SSN"))
+	(&ColumnAuth{Mask: MaskRedact}).MaskValue(value.FieldByName("Email"))
+	(&ColumnAuth{Mask: MaskNull}).MaskValue(value.FieldByName("Phone"))
+	(&ColumnAuth{Mask: MaskHash}).MaskValue(value.FieldByName("Token"))
+	(&ColumnAuth{Mask: MaskRedact}).MaskValue(value.FieldByName("ID"))
+
+	assert.Equal(t, "****6789", row.SSN)
+	assert.Equal(t, "****", *row.Email)
+	assert.Equal(t, "john@acme.com", email)
+	assert.Equal(t, "", row.Phone)
+	assert.Equal(t, "9946dad4e00e913fc8be8e5d3f7e110a4a9e832f83fb09c345285d78638d8a0e", row.Token)
+	assert.Equal(t, 0, row.ID)
+}
+
+func TestColumns_Authorize(t *testing.T) {
+	salary := &Column{Name: "salary", Auth: &ColumnAuth{Roles: []string{"hr"}}}
+	ssn := &Column{Name: "ssn", Auth: &ColumnAuth{Roles: []string{"hr"}, Mask: MaskPartial, Visible: 4}}
+	name := &Column{Name: "name"}
+	columns := Columns{salary, ssn, name}
+
+	forbidden, masked := columns.Authorize(NewGrants("read"))
+	assert.Equal(t, []*Column{salary}, forbidden)
+	assert.Equal(t, []*Column{ssn}, masked)
+
+	forbidden, masked = columns.Authorize(GrantsOf(WithGrants(context.Background(), &Grants{Roles: []string{"hr"}})))
+	assert.Empty(t, forbidden)
+	assert.Empty(t, masked)
+}
+
+func TestFieldAllowed(t *testing.T) {
+	type record struct {
+		Name   string
+		Salary float64 `auth:"roles=hr"`
+	}
+	rType := reflect.TypeOf(record{})
+	ctx := context.Background()
+	assert.True(t, FieldAllowed(ctx, rType.Field(0)))
+	assert.False(t, FieldAllowed(ctx, rType.Field(1)))
+	assert.True(t, FieldAllowed(WithGrants(ctx, NewGrants("hr")), rType.Field(1)))
+}
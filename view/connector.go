@@ -18,34 +18,47 @@ import (
 type (
 	Connection struct {
 		DBConfig
-		_dialect     *info.Dialect
-		_dsn         string
-		_db          func() (*sql.DB, error)
-		_initialized bool
-		_mux         sync.Mutex
-		_sharedMux   *sync.Mutex
+		_dialect      *info.Dialect
+		_dsn          string
+		_db           func() (*sql.DB, error)
+		_initialized  bool
+		_mux          sync.Mutex
+		_sharedMux    *sync.Mutex
+		_poolKey      string
+		_secretLoaded int64
+		_refreshing   int32
 	}
 
 	Connector struct {
 		Connection
-		Connections  []*Connection
-		index        int32
-		_initialized bool
+		Connections []*Connection
+		// HealthCheckIntervalMs enables periodic connection health checks; failing connections are ejected with backoff
+		HealthCheckIntervalMs int `json:",omitempty" yaml:",omitempty"`
+		// MaxEjectionMs caps failing connection ejection backoff, default 5 min
+		MaxEjectionMs int `json:",omitempty" yaml:",omitempty"`
+		// ReadYourWritesMs pins session reads to the primary for the window following a write
+		ReadYourWritesMs int `json:",omitempty" yaml:",omitempty"`
+		index            int32
+		_initialized     bool
 	}
 
 	ConnectorOption func(c *Connector)
 
 	DBConfig struct {
 		shared.Reference
-		Name              string        `json:",omitempty"`
-		Driver            string        `json:",omitempty"`
-		DSN               string        `json:",omitempty"`
-		MaxIdleConns      int           `json:",omitempty" yaml:",omitempty"`
-		ConnMaxIdleTimeMs int           `json:",omitempty" yaml:",omitempty"`
-		MaxOpenConns      int           `json:",omitempty" yaml:",omitempty"`
-		ConnMaxLifetimeMs int           `json:",omitempty" yaml:",omitempty"`
-		TimeoutTime       int           `json:",omitempty" yaml:",omitempty"`
-		Secret            *scy.Resource `json:",omitempty"`
+		Name                 string        `json:",omitempty"`
+		Driver               string        `json:",omitempty"`
+		DSN                  string        `json:",omitempty"`
+		MaxIdleConns         int           `json:",omitempty" yaml:",omitempty"`
+		ConnMaxIdleTimeMs    int           `json:",omitempty" yaml:",omitempty"`
+		MaxOpenConns         int           `json:",omitempty" yaml:",omitempty"`
+		ConnMaxLifetimeMs    int           `json:",omitempty" yaml:",omitempty"`
+		TimeoutTime          int           `json:",omitempty" yaml:",omitempty"`
+		Secret               *scy.Resource `json:",omitempty"`
+		Role                 string        `json:",omitempty" yaml:",omitempty"` // primary (default) or replica
+		SecretRefreshMs      int           `json:",omitempty" yaml:",omitempty"` // secret reload interval, rotated credentials re-create pool
+		MaxConcurrentQueries int           `json:",omitempty" yaml:",omitempty"` // concurrent query limit (bulkhead), 0 unlimited
+		QueueTimeoutMs       int           `json:",omitempty" yaml:",omitempty"` // max time a query waits for a bulkhead slot
 	}
 )
 
@@ -68,6 +81,7 @@ func (c *Connector) Init(ctx context.Context, connectors Connectors) error {
 			return err
 		}
 	}
+	c.registerHealth()
 	c._initialized = true
 	return nil
 }
@@ -94,6 +108,14 @@ func (c *Connector) Validate() error {
 	return nil
 }
 
+// DriverName returns connector driver, the first connection driver for multi connection connector
+func (c *Connector) DriverName() string {
+	if c.Driver == "" && len(c.Connections) > 0 {
+		return c.Connections[0].Driver
+	}
+	return c.Driver
+}
+
 func (c *Connector) Dialect(ctx context.Context) (*info.Dialect, error) {
 	if len(c.Connections) == 0 {
 		return c.Connection.Dialect(ctx)
@@ -108,8 +130,21 @@ func (c *Connector) GetConnection(ctx context.Context, connectors Connectors) (*
 	if len(c.Connections) == 1 {
 		return c.Connections[0], nil
 	}
-	index := int(atomic.AddInt32(&c.index, 1)) % len(c.Connections)
-	return c.Connections[index], nil
+	c.checkHealthIfDue()
+	if conn := c.nextConnection(c.healthyConnections(ConnectionRolePrimary)); conn != nil {
+		return conn, nil
+	}
+	//no healthy primary: fail open over primaries, or all connections if none is primary
+	var primaries []*Connection
+	for _, conn := range c.Connections {
+		if conn.role() == ConnectionRolePrimary {
+			primaries = append(primaries, conn)
+		}
+	}
+	if len(primaries) == 0 {
+		primaries = c.Connections
+	}
+	return c.nextConnection(primaries), nil
 }
 
 // Init initializes connector.
@@ -153,22 +188,22 @@ func (c *Connection) Init(ctx context.Context, connectors Connectors) error {
 // It is important to not close the DB since the connection is shared.
 func (c *Connection) DB() (*sql.DB, error) {
 	if c._db != nil {
+		c.refreshSecretIfDue()
 		return c._db()
 	}
 
-	var err error
-	dsn := c.getDSN()
-	var secret *scy.Secret
-	if c.Secret != nil {
-		secrets := scy.New()
-		if secret, err = secrets.Load(context.Background(), c.Secret); err != nil {
-			return nil, fmt.Errorf("invalid db:%v secret, %w", c.DSN, err)
-		}
-		dsn = secret.Expand(dsn)
+	dsn, err := c.expandDSN(context.Background())
+	if err != nil {
+		return nil, err
 	}
 
 	c.lock()
+	c._poolKey = poolKey(c.Driver, dsn, &c.DBConfig)
 	c._db = aDbPool.DB(c.Driver, dsn, &c.DBConfig)
+	if c.Secret != nil {
+		atomic.StoreInt64(&c._secretLoaded, time.Now().UnixNano())
+		aDbPool.onAuthFailure(c._poolKey, c.expandDSN)
+	}
 	aDB, err := c._db()
 	c.unlock()
 
@@ -240,8 +275,11 @@ func (c *Connector) clone() *Connector {
 	}
 
 	cloned := &Connector{
-		Connection:  *c.Connection.clone(),
-		Connections: make([]*Connection, 0, len(c.Connections)),
+		Connection:            *c.Connection.clone(),
+		Connections:           make([]*Connection, 0, len(c.Connections)),
+		HealthCheckIntervalMs: c.HealthCheckIntervalMs,
+		MaxEjectionMs:         c.MaxEjectionMs,
+		ReadYourWritesMs:      c.ReadYourWritesMs,
 	}
 	for _, connection := range c.Connections {
 		cloned.Connections = append(cloned.Connections, connection.clone())
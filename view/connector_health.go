@@ -0,0 +1,428 @@
+package view
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+const (
+	// ConnectionRolePrimary identifies connection serving writes (and reads when no replica is healthy)
+	ConnectionRolePrimary = "primary"
+	// ConnectionRoleReplica identifies read only connection
+	ConnectionRoleReplica = "replica"
+
+	defaultMaxEjectionMs    = 5 * 60 * 1000
+	maxHealthCheckTimeoutMs = 5000
+	maxPins                 = 1024
+
+	// health check error categories exposed on the status route, driver error is only logged as it may contain
+	// hosts or DSN fragments
+	healthErrorTimeout        = "timeout"
+	healthErrorCanceled       = "canceled"
+	healthErrorRefused        = "connectionRefused"
+	healthErrorUnreachable    = "unreachable"
+	healthErrorAuthentication = "authentication"
+	healthErrorConnection     = "connectionError"
+)
+
+type (
+	// ConnectionStatus represents connection health snapshot exposed on the status route
+	ConnectionStatus struct {
+		Connector    string
+		Name         string
+		Role         string
+		Healthy      bool
+		Failures     int        `json:",omitempty"`
+		EjectedUntil *time.Time `json:",omitempty"`
+		LastCheck    *time.Time `json:",omitempty"`
+		LastError    string     `json:",omitempty"` //last health check error category, i.e. timeout or connectionRefused
+	}
+
+	connectionHealth struct {
+		mux          sync.Mutex
+		connector    string
+		name         string
+		role         string
+		failures     int
+		ejectedUntil time.Time
+		lastCheck    time.Time
+		lastError    string
+	}
+
+	connectorHealth struct {
+		checking  int32
+		lastCheck int64
+	}
+
+	healthRegistry struct {
+		mux         sync.Mutex
+		connections map[string]*connectionHealth
+		connectors  map[string]*connectorHealth
+		pins        map[string]time.Time // connector and session to primary pinning expiry
+	}
+
+	sessionKey string
+)
+
+var aHealthRegistry = newHealthRegistry()
+
+const sessionContextKey = sessionKey("datlyConnectorSession")
+
+// WithConnectorSession returns context carrying session key, used to pin reads to the primary after a write
+func WithConnectorSession(ctx context.Context, session string) context.Context {
+	if session == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, sessionContextKey, session)
+}
+
+func connectorSession(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	session, _ := ctx.Value(sessionContextKey).(string)
+	return session
+}
+
+// ConnectionStatuses returns health of connections belonging to connectors with read replicas or health checks
+func ConnectionStatuses() []*ConnectionStatus {
+	aHealthRegistry.mux.Lock()
+	items := make([]*connectionHealth, 0, len(aHealthRegistry.connections))
+	for _, item := range aHealthRegistry.connections {
+		items = append(items, item)
+	}
+	aHealthRegistry.mux.Unlock()
+	now := time.Now()
+	result := make([]*ConnectionStatus, 0, len(items))
+	for _, item := range items {
+		result = append(result, item.status(now))
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Connector == result[j].Connector {
+			return result[i].Name < result[j].Name
+		}
+		return result[i].Connector < result[j].Connector
+	})
+	return result
+}
+
+// ReaderDB returns connection pool for reader views: a healthy replica, or the primary when the session
+// has recently written or no replica is healthy
+func (c *Connector) ReaderDB(ctx context.Context) (*sql.DB, error) {
+	conn, err := c.ReadConnection(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return conn.DB()
+}
+
+// WriterDB returns primary connection pool for executor and transactional work
+func (c *Connector) WriterDB(ctx context.Context) (*sql.DB, error) {
+	conn, err := c.WriteConnection(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return conn.DB()
+}
+
+// ReadConnection returns connection for reads
+func (c *Connector) ReadConnection(ctx context.Context) (*Connection, error) {
+	if len(c.Connections) == 0 {
+		return &c.Connection, nil
+	}
+	c.checkHealthIfDue()
+	if !c.isPinned(ctx) {
+		if conn := c.nextConnection(c.healthyConnections(ConnectionRoleReplica)); conn != nil {
+			return conn, nil
+		}
+	}
+	return c.GetConnection(ctx, emptyConnectors)
+}
+
+// WriteConnection returns primary connection, pinning session reads to the primary for ReadYourWritesMs
+func (c *Connector) WriteConnection(ctx context.Context) (*Connection, error) {
+	if c.ReadYourWritesMs > 0 && len(c.Connections) > 0 {
+		if session := connectorSession(ctx); session != "" {
+			aHealthRegistry.pin(c.pinKey(session), time.Now().Add(time.Duration(c.ReadYourWritesMs)*time.Millisecond))
+		}
+	}
+	return c.GetConnection(ctx, emptyConnectors)
+}
+
+// healthyConnections returns non ejected connections with supplied role, connections without role are primary
+func (c *Connector) healthyConnections(role string) []*Connection {
+	now := time.Now()
+	var result []*Connection
+	for _, conn := range c.Connections {
+		if conn.role() != role {
+			continue
+		}
+		if health := aHealthRegistry.lookup(c.Name, conn); health != nil && !health.available(now) {
+			continue
+		}
+		result = append(result, conn)
+	}
+	return result
+}
+
+func (c *Connector) nextConnection(candidates []*Connection) *Connection {
+	switch len(candidates) {
+	case 0:
+		return nil
+	case 1:
+		return candidates[0]
+	}
+	index := int(atomic.AddInt32(&c.index, 1)) % len(candidates)
+	if index < 0 {
+		index = -index
+	}
+	return candidates[index]
+}
+
+func (c *Connector) isPinned(ctx context.Context) bool {
+	if c.ReadYourWritesMs <= 0 {
+		return false
+	}
+	session := connectorSession(ctx)
+	return session != "" && aHealthRegistry.isPinned(c.pinKey(session), time.Now())
+}
+
+func (c *Connector) pinKey(session string) string {
+	return c.Name + "|" + session
+}
+
+func (c *Connector) hasReplicas() bool {
+	for _, conn := range c.Connections {
+		if conn.role() == ConnectionRoleReplica {
+			return true
+		}
+	}
+	return false
+}
+
+// tracksHealth returns true if connector connections are health tracked
+func (c *Connector) tracksHealth() bool {
+	return len(c.Connections) > 1 && (c.HealthCheckIntervalMs > 0 || c.hasReplicas())
+}
+
+func (c *Connector) registerHealth() {
+	if !c.tracksHealth() {
+		return
+	}
+	for _, conn := range c.Connections {
+		aHealthRegistry.register(c.Name, conn)
+	}
+}
+
+// checkHealthIfDue pings connections in the background once per health check interval
+func (c *Connector) checkHealthIfDue() {
+	if c.HealthCheckIntervalMs <= 0 || len(c.Connections) < 2 {
+		return
+	}
+	state := aHealthRegistry.connectorState(c.Name)
+	interval := time.Duration(c.HealthCheckIntervalMs) * time.Millisecond
+	now := time.Now()
+	if now.Sub(time.Unix(0, atomic.LoadInt64(&state.lastCheck))) < interval {
+		return
+	}
+	if !atomic.CompareAndSwapInt32(&state.checking, 0, 1) {
+		return
+	}
+	atomic.StoreInt64(&state.lastCheck, now.UnixNano())
+	go func() {
+		defer atomic.StoreInt32(&state.checking, 0)
+		c.checkHealth(context.Background())
+	}()
+}
+
+func (c *Connector) checkHealth(ctx context.Context) {
+	timeoutMs := c.HealthCheckIntervalMs
+	if timeoutMs <= 0 || timeoutMs > maxHealthCheckTimeoutMs {
+		timeoutMs = maxHealthCheckTimeoutMs
+	}
+	maxEjection := time.Duration(c.MaxEjectionMs) * time.Millisecond
+	if maxEjection <= 0 {
+		maxEjection = defaultMaxEjectionMs * time.Millisecond
+	}
+	interval := time.Duration(c.HealthCheckIntervalMs) * time.Millisecond
+	for _, conn := range c.Connections {
+		health := aHealthRegistry.register(c.Name, conn)
+		if !health.probeDue(time.Now()) {
+			continue
+		}
+		err := pingConnection(ctx, conn, time.Duration(timeoutMs)*time.Millisecond)
+		health.record(err, time.Now(), interval, maxEjection)
+	}
+}
+
+func pingConnection(ctx context.Context, conn *Connection, timeout time.Duration) error {
+	aDB, err := conn.DB()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	return aDB.PingContext(ctx)
+}
+
+func (c *Connection) role() string {
+	if c.Role == ConnectionRoleReplica {
+		return ConnectionRoleReplica
+	}
+	return ConnectionRolePrimary
+}
+
+func (c *Connection) healthKey(connector string) string {
+	return connector + "|" + c.Name + "|" + c.Driver + "|" + c.getDSN()
+}
+
+func (h *connectionHealth) available(now time.Time) bool {
+	h.mux.Lock()
+	defer h.mux.Unlock()
+	return h.failures == 0 || now.After(h.ejectedUntil)
+}
+
+// probeDue returns true for healthy connections, and ejected ones whose backoff elapsed
+func (h *connectionHealth) probeDue(now time.Time) bool {
+	h.mux.Lock()
+	defer h.mux.Unlock()
+	return h.failures == 0 || !now.Before(h.ejectedUntil)
+}
+
+// record updates health with probe result, failing connection is ejected with exponential backoff
+func (h *connectionHealth) record(err error, now time.Time, interval, maxEjection time.Duration) {
+	h.mux.Lock()
+	defer h.mux.Unlock()
+	h.lastCheck = now
+	if err == nil {
+		h.failures = 0
+		h.lastError = ""
+		h.ejectedUntil = time.Time{}
+		return
+	}
+	h.failures++
+	h.lastError = healthErrorCategory(err)
+	fmt.Printf("[WARN] connector %v connection %v health check failed: %v\n", h.connector, h.name, err)
+	backoff := interval
+	for i := 1; i < h.failures && backoff < maxEjection; i++ {
+		backoff *= 2
+	}
+	if backoff > maxEjection {
+		backoff = maxEjection
+	}
+	h.ejectedUntil = now.Add(backoff)
+}
+
+// healthErrorCategory returns sanitized health check error category
+func healthErrorCategory(err error) string {
+	var netErr net.Error
+	var dnsErr *net.DNSError
+	message := strings.ToLower(err.Error())
+	switch {
+	case errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()):
+		return healthErrorTimeout
+	case errors.Is(err, context.Canceled):
+		return healthErrorCanceled
+	case errors.Is(err, syscall.ECONNREFUSED) || strings.Contains(message, "connection refused"):
+		return healthErrorRefused
+	case errors.As(err, &dnsErr) || errors.Is(err, syscall.EHOSTUNREACH) || errors.Is(err, syscall.ENETUNREACH) || strings.Contains(message, "no such host"):
+		return healthErrorUnreachable
+	case strings.Contains(message, "access denied") || strings.Contains(message, "authentication") || strings.Contains(message, "password"):
+		return healthErrorAuthentication
+	}
+	return healthErrorConnection
+}
+
+func (h *connectionHealth) status(now time.Time) *ConnectionStatus {
+	h.mux.Lock()
+	defer h.mux.Unlock()
+	ret := &ConnectionStatus{
+		Connector: h.connector,
+		Name:      h.name,
+		Role:      h.role,
+		Healthy:   h.failures == 0 || now.After(h.ejectedUntil),
+		Failures:  h.failures,
+		LastError: h.lastError,
+	}
+	if !h.lastCheck.IsZero() {
+		lastCheck := h.lastCheck
+		ret.LastCheck = &lastCheck
+	}
+	if h.failures > 0 && now.Before(h.ejectedUntil) {
+		ejectedUntil := h.ejectedUntil
+		ret.EjectedUntil = &ejectedUntil
+	}
+	return ret
+}
+
+func (r *healthRegistry) register(connector string, conn *Connection) *connectionHealth {
+	key := conn.healthKey(connector)
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	item, ok := r.connections[key]
+	if !ok {
+		item = &connectionHealth{connector: connector, name: conn.Name, role: conn.role()}
+		r.connections[key] = item
+	}
+	return item
+}
+
+func (r *healthRegistry) lookup(connector string, conn *Connection) *connectionHealth {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	return r.connections[conn.healthKey(connector)]
+}
+
+func (r *healthRegistry) connectorState(connector string) *connectorHealth {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	item, ok := r.connectors[connector]
+	if !ok {
+		item = &connectorHealth{}
+		r.connectors[connector] = item
+	}
+	return item
+}
+
+func (r *healthRegistry) pin(key string, until time.Time) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	if len(r.pins) > maxPins { //drop expired pins
+		now := time.Now()
+		for candidate, expiry := range r.pins {
+			if now.After(expiry) {
+				delete(r.pins, candidate)
+			}
+		}
+	}
+	r.pins[key] = until
+}
+
+func (r *healthRegistry) isPinned(key string, now time.Time) bool {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	until, ok := r.pins[key]
+	return ok && now.Before(until)
+}
+
+func newHealthRegistry() *healthRegistry {
+	return &healthRegistry{
+		connections: map[string]*connectionHealth{},
+		connectors:  map[string]*connectorHealth{},
+		pins:        map[string]time.Time{},
+	}
+}
+
+// ResetConnectionHealth resets connection health and read-your-writes pinning state
+func ResetConnectionHealth() {
+	aHealthRegistry = newHealthRegistry()
+}
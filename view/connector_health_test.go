@@ -0,0 +1,92 @@
+package view
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConnector_ReadConnection(t *testing.T) {
+	ResetConnectionHealth()
+	defer ResetConnectionHealth()
+	primary := &Connection{DBConfig: DBConfig{Name: "primary", Driver: "mysql", DSN: "primary"}}
+	replica1 := &Connection{DBConfig: DBConfig{Name: "replica1", Driver: "mysql", DSN: "replica1", Role: ConnectionRoleReplica}}
+	replica2 := &Connection{DBConfig: DBConfig{Name: "replica2", Driver: "mysql", DSN: "replica2", Role: ConnectionRoleReplica}}
+	connector := &Connector{
+		Connection:       Connection{DBConfig: DBConfig{Name: "main"}},
+		Connections:      []*Connection{primary, replica1, replica2},
+		ReadYourWritesMs: 60000,
+	}
+	connector.registerHealth()
+	ctx := context.Background()
+
+	seen := map[string]bool{}
+	for i := 0; i < 4; i++ {
+		conn, err := connector.ReadConnection(ctx)
+		require.NoError(t, err)
+		seen[conn.Name] = true
+	}
+	assert.Equal(t, map[string]bool{"replica1": true, "replica2": true}, seen, "reads should be balanced over replicas")
+
+	now := time.Now()
+	aHealthRegistry.lookup("main", replica1).record(errors.New("connection refused"), now, time.Minute, 5*time.Minute)
+	for i := 0; i < 3; i++ {
+		conn, err := connector.ReadConnection(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "replica2", conn.Name, "ejected replica should not receive reads")
+	}
+	aHealthRegistry.lookup("main", replica2).record(errors.New("connection refused"), now, time.Minute, 5*time.Minute)
+	conn, err := connector.ReadConnection(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "primary", conn.Name, "reads should fail over to primary")
+
+	aHealthRegistry.lookup("main", replica1).record(nil, now, time.Minute, 5*time.Minute)
+	sessionCtx := WithConnectorSession(ctx, "user1")
+	conn, err = connector.WriteConnection(sessionCtx)
+	require.NoError(t, err)
+	assert.Equal(t, "primary", conn.Name)
+	conn, err = connector.ReadConnection(sessionCtx)
+	require.NoError(t, err)
+	assert.Equal(t, "primary", conn.Name, "reads after write should be pinned to primary")
+	conn, err = connector.ReadConnection(WithConnectorSession(ctx, "user2"))
+	require.NoError(t, err)
+	assert.Equal(t, "replica1", conn.Name)
+
+	statuses := ConnectionStatuses()
+	require.Len(t, statuses, 3)
+	assert.True(t, statuses[0].Healthy)
+	assert.True(t, statuses[1].Healthy)
+	assert.False(t, statuses[2].Healthy)
+	assert.Equal(t, "replica2", statuses[2].Name)
+	assert.Equal(t, healthErrorRefused, statuses[2].LastError)
+}
+
+func TestHealthErrorCategory(t *testing.T) {
+	assert.Equal(t, healthErrorTimeout, healthErrorCategory(fmt.Errorf("ping: %w", context.DeadlineExceeded)))
+	assert.Equal(t, healthErrorRefused, healthErrorCategory(errors.New("dial tcp 10.0.0.1:3306: connect: connection refused")))
+	assert.Equal(t, healthErrorUnreachable, healthErrorCategory(&net.DNSError{Err: "no such host", Name: "db.internal"}))
+	assert.Equal(t, healthErrorAuthentication, healthErrorCategory(errors.New("Error 1045: Access denied for user 'app'@'10.0.0.2' (using password: YES)")))
+	assert.Equal(t, healthErrorConnection, healthErrorCategory(errors.New("invalid connection to db.internal/sales")))
+}
+
+func TestConnectionHealth_Record(t *testing.T) {
+	health := &connectionHealth{}
+	now := time.Now()
+	for i := 0; i < 3; i++ {
+		health.record(errors.New("timeout"), now, time.Minute, 5*time.Minute)
+	}
+	assert.Equal(t, now.Add(4*time.Minute), health.ejectedUntil)
+	assert.False(t, health.available(now.Add(time.Minute)))
+	assert.True(t, health.probeDue(now.Add(4*time.Minute)))
+	health.record(errors.New("timeout"), now, time.Minute, 5*time.Minute)
+	assert.Equal(t, now.Add(5*time.Minute), health.ejectedUntil, "backoff should be capped")
+	health.record(nil, now, time.Minute, 5*time.Minute)
+	assert.True(t, health.available(now))
+	assert.Equal(t, 0, health.failures)
+}
@@ -0,0 +1,494 @@
+package handler
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"path"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/viant/sqlx/io/load"
+	"github.com/viant/xdatly/handler"
+	"github.com/viant/xdatly/handler/sqlx"
+	"github.com/xuri/excelize/v2"
+)
+
+const (
+	IngestDataHandler = "IngestData"
+
+	//IngestAllOrNothing loads rows only when the whole file is valid
+	IngestAllOrNothing = "all"
+	//IngestBestEffort loads valid rows, each batch is committed on its own, rows of a batch failing to load are reported
+	IngestBestEffort = "best"
+
+	defaultIngestBatchSize = 500
+)
+
+type (
+	IngestDataProvider struct{}
+
+	// IngestData loads multipart XLSX/CSV upload into a table,
+	// arguments: file form parameter, table name, mode (all|best), batch size, column mapping (Header:Field,...)
+	IngestData struct {
+		*handler.Options
+	}
+
+	// IngestReport represents ingestion result
+	IngestReport struct {
+		Mode   string
+		Total  int
+		Loaded int
+		Failed int
+		Errors []*IngestError `json:",omitempty"`
+	}
+
+	// IngestError represents row error, row is spreadsheet line number (header is line 1)
+	IngestError struct {
+		Row     int
+		Column  string `json:",omitempty"`
+		Field   string `json:",omitempty"`
+		Message string
+	}
+
+	rowReader interface {
+		Read() ([]string, error)
+	}
+
+	xlsxRows struct {
+		file *excelize.File
+		rows *excelize.Rows
+	}
+
+	ingestColumn struct {
+		header string
+		index  []int
+	}
+
+	ingestMapper struct {
+		rType   reflect.Type
+		columns []*ingestColumn
+	}
+
+	ingestBatch struct {
+		rows  []int
+		slice reflect.Value
+	}
+
+	ingestOptions struct {
+		fileParameter string
+		table         string
+		mode          string
+		batchSize     int
+		mapping       map[string]string
+	}
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// Exec executes handler
+func (l *IngestData) Exec(ctx context.Context, session handler.Session) (interface{}, error) {
+	options, err := l.ingestOptions()
+	if err != nil {
+		return nil, err
+	}
+	recordType := l.Options.InputType
+	for recordType != nil && (recordType.Kind() == reflect.Ptr || recordType.Kind() == reflect.Slice) {
+		recordType = recordType.Elem()
+	}
+	if recordType == nil || recordType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("invalid Ingest input type: expected struct, but had %v", l.Options.InputType)
+	}
+	value, ok, err := session.Stater().Value(ctx, options.fileParameter)
+	if err != nil || !ok {
+		return nil, fmt.Errorf("invalid Ingest file %v: %w", options.fileParameter, err)
+	}
+	var file *multipart.FileHeader
+	switch actual := value.(type) {
+	case *multipart.FileHeader:
+		file = actual
+	case []*multipart.FileHeader:
+		if len(actual) > 0 {
+			file = actual[0]
+		}
+	}
+	if file == nil {
+		return nil, fmt.Errorf("invalid Ingest file %v: expected %T, but had %T", options.fileParameter, file, value)
+	}
+	report := &IngestReport{Mode: options.mode}
+	if options.mode == IngestAllOrNothing {
+		if err = l.ingest(ctx, session, file, recordType, options, report, false); err != nil || report.Failed > 0 {
+			return report, err
+		}
+		*report = IngestReport{Mode: options.mode}
+	}
+	return report, l.ingest(ctx, session, file, recordType, options, report, true)
+}
+
+func (l *IngestData) ingest(ctx context.Context, session handler.Session, file *multipart.FileHeader, recordType reflect.Type, options *ingestOptions, report *IngestReport, load bool) error {
+	reader, closer, err := openRows(file)
+	if err != nil {
+		return err
+	}
+	defer closer.Close()
+	header, err := reader.Read()
+	if err != nil {
+		return fmt.Errorf("failed to read %v header: %w", file.Filename, err)
+	}
+	mapper, err := newIngestMapper(recordType, header, options.mapping)
+	if err != nil {
+		return err
+	}
+	batch := &ingestBatch{slice: reflect.MakeSlice(reflect.SliceOf(reflect.PtrTo(recordType)), 0, options.batchSize)}
+	flush := func() error {
+		if !load || len(batch.rows) == 0 {
+			return nil
+		}
+		defer batch.reset()
+		service, err := session.Db()
+		if err != nil {
+			return err
+		}
+		if options.mode == IngestAllOrNothing {
+			//Load joins component transaction, batches are committed or rolled back together
+			if err = service.Load(ctx, options.table, batch.slice.Interface()); err != nil {
+				return fmt.Errorf("failed to load %v rows into %v: %w", len(batch.rows), options.table, err)
+			}
+			report.Loaded += len(batch.rows)
+			return nil
+		}
+		if err = loadBatch(ctx, service, options.table, batch.slice.Interface()); err != nil {
+			for _, row := range batch.rows {
+				report.Errors = append(report.Errors, &IngestError{Row: row, Message: err.Error()})
+			}
+			report.Failed += len(batch.rows)
+			return nil
+		}
+		report.Loaded += len(batch.rows)
+		return nil
+	}
+	for row := 2; ; row++ {
+		values, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read %v row %v: %w", file.Filename, row, err)
+		}
+		if isBlankRow(values) {
+			continue
+		}
+		report.Total++
+		record, rowErrors := mapper.record(row, values)
+		if len(rowErrors) == 0 {
+			rowErrors = validateRecord(ctx, session, row, record)
+		}
+		if len(rowErrors) > 0 {
+			report.Failed++
+			report.Errors = append(report.Errors, rowErrors...)
+			continue
+		}
+		batch.append(row, record)
+		if len(batch.rows) >= options.batchSize {
+			if err = flush(); err != nil {
+				return err
+			}
+		}
+	}
+	return flush()
+}
+
+// loadBatch loads best effort batch in its own transaction outside component transaction,
+// otherwise failed batch would abort transaction shared with the other batches (i.e. on Postgres)
+func loadBatch(ctx context.Context, service *sqlx.Service, table string, data interface{}) error {
+	db, err := service.Db(ctx)
+	if err != nil {
+		return err
+	}
+	loader, err := load.New(ctx, db, table)
+	if err != nil {
+		return err
+	}
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if _, err = loader.Exec(ctx, data, tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+func validateRecord(ctx context.Context, session handler.Session, row int, record interface{}) []*IngestError {
+	aValidator := session.Validator()
+	if aValidator == nil {
+		return nil
+	}
+	validation, err := aValidator.Validate(ctx, record)
+	if err != nil {
+		return []*IngestError{{Row: row, Message: err.Error()}}
+	}
+	if validation == nil || !validation.Failed {
+		return nil
+	}
+	var ret []*IngestError
+	for _, violation := range validation.Violations {
+		ret = append(ret, &IngestError{Row: row, Field: violation.Field, Message: violation.Message})
+	}
+	return ret
+}
+
+func (l *IngestData) ingestOptions() (*ingestOptions, error) {
+	if len(l.Arguments) < 2 {
+		return nil, fmt.Errorf("invalid Ingest arguments: %v, expected file parameter and table", l.Arguments)
+	}
+	ret := &ingestOptions{fileParameter: l.Arguments[0], table: l.Arguments[1], mode: IngestAllOrNothing, batchSize: defaultIngestBatchSize}
+	if len(l.Arguments) > 2 && l.Arguments[2] != "" {
+		ret.mode = strings.ToLower(l.Arguments[2])
+		if ret.mode != IngestAllOrNothing && ret.mode != IngestBestEffort {
+			return nil, fmt.Errorf("invalid Ingest mode: %v, supported: %v, %v", l.Arguments[2], IngestAllOrNothing, IngestBestEffort)
+		}
+	}
+	if len(l.Arguments) > 3 && l.Arguments[3] != "" {
+		batchSize, err := strconv.Atoi(l.Arguments[3])
+		if err != nil || batchSize <= 0 {
+			return nil, fmt.Errorf("invalid Ingest batch size: %v", l.Arguments[3])
+		}
+		ret.batchSize = batchSize
+	}
+	if len(l.Arguments) > 4 && l.Arguments[4] != "" {
+		ret.mapping = map[string]string{}
+		for _, pair := range strings.Split(l.Arguments[4], ",") {
+			index := strings.LastIndex(pair, ":")
+			if index == -1 {
+				return nil, fmt.Errorf("invalid Ingest column mapping: %v, expected Header:Field", pair)
+			}
+			ret.mapping[normalizeHeader(pair[:index])] = strings.TrimSpace(pair[index+1:])
+		}
+	}
+	return ret, nil
+}
+
+func (*IngestDataProvider) New(ctx context.Context, opts ...handler.Option) (handler.Handler, error) {
+	options := handler.NewOptions(opts)
+	return &IngestData{Options: options}, nil
+}
+
+func openRows(file *multipart.FileHeader) (rowReader, io.Closer, error) {
+	content, err := file.Open()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open %v: %w", file.Filename, err)
+	}
+	switch strings.ToLower(path.Ext(file.Filename)) {
+	case ".xlsx", ".xlsm":
+		rows, err := newXLSXRows(content)
+		if err != nil {
+			_ = content.Close()
+			return nil, nil, fmt.Errorf("failed to open %v: %w", file.Filename, err)
+		}
+		_ = content.Close()
+		return rows, rows, nil
+	case ".tsv":
+		return newCSVReader(content, '\t'), content, nil
+	default:
+		return newCSVReader(content, ','), content, nil
+	}
+}
+
+func newCSVReader(reader io.Reader, delimiter rune) *csv.Reader {
+	ret := csv.NewReader(reader)
+	ret.Comma = delimiter
+	ret.TrimLeadingSpace = true
+	ret.FieldsPerRecord = -1
+	return ret
+}
+
+func newXLSXRows(reader io.Reader) (*xlsxRows, error) {
+	file, err := excelize.OpenReader(reader)
+	if err != nil {
+		return nil, err
+	}
+	sheets := file.GetSheetList()
+	if len(sheets) == 0 {
+		_ = file.Close()
+		return nil, fmt.Errorf("spreadsheet has no sheets")
+	}
+	rows, err := file.Rows(sheets[0])
+	if err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	return &xlsxRows{file: file, rows: rows}, nil
+}
+
+// Read reads next sheet row
+func (x *xlsxRows) Read() ([]string, error) {
+	if !x.rows.Next() {
+		if err := x.rows.Error(); err != nil {
+			return nil, err
+		}
+		return nil, io.EOF
+	}
+	return x.rows.Columns()
+}
+
+// Close closes spreadsheet
+func (x *xlsxRows) Close() error {
+	_ = x.rows.Close()
+	return x.file.Close()
+}
+
+func newIngestMapper(rType reflect.Type, header []string, mapping map[string]string) (*ingestMapper, error) {
+	fields := map[string][]int{}
+	for i := 0; i < rType.NumField(); i++ {
+		field := rType.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		for _, name := range fieldNames(field) {
+			if _, ok := fields[normalizeHeader(name)]; !ok {
+				fields[normalizeHeader(name)] = field.Index
+			}
+		}
+	}
+	ret := &ingestMapper{rType: rType, columns: make([]*ingestColumn, len(header))}
+	mapped := 0
+	for i, name := range header {
+		key := normalizeHeader(name)
+		if fieldName, ok := mapping[key]; ok {
+			key = normalizeHeader(fieldName)
+		}
+		index, ok := fields[key]
+		if !ok {
+			if _, ok := mapping[normalizeHeader(name)]; ok {
+				return nil, fmt.Errorf("invalid Ingest column mapping: field %v not found in %v", mapping[normalizeHeader(name)], rType.Name())
+			}
+			continue
+		}
+		ret.columns[i] = &ingestColumn{header: strings.TrimSpace(name), index: index}
+		mapped++
+	}
+	if mapped == 0 {
+		return nil, fmt.Errorf("none of header columns %v matched %v fields", header, rType.Name())
+	}
+	return ret, nil
+}
+
+func (m *ingestMapper) record(row int, values []string) (interface{}, []*IngestError) {
+	record := reflect.New(m.rType)
+	var errors []*IngestError
+	for i, value := range values {
+		if i >= len(m.columns) || m.columns[i] == nil {
+			continue
+		}
+		column := m.columns[i]
+		field := record.Elem().FieldByIndex(column.index)
+		if err := setIngestValue(field, value); err != nil {
+			errors = append(errors, &IngestError{Row: row, Column: column.header, Field: m.rType.FieldByIndex(column.index).Name, Message: err.Error()})
+		}
+	}
+	return record.Interface(), errors
+}
+
+func fieldNames(field reflect.StructField) []string {
+	ret := []string{field.Name}
+	if tag := field.Tag.Get("sqlx"); tag != "" {
+		for _, part := range strings.Split(tag, ",") {
+			if strings.HasPrefix(part, "name=") {
+				ret = append(ret, part[5:])
+			} else if !strings.Contains(part, "=") && part != "-" {
+				ret = append(ret, part)
+			}
+		}
+	}
+	if tag := field.Tag.Get("json"); tag != "" {
+		if name := strings.Split(tag, ",")[0]; name != "" && name != "-" {
+			ret = append(ret, name)
+		}
+	}
+	return ret
+}
+
+func normalizeHeader(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	return strings.NewReplacer(" ", "", "_", "", "-", "").Replace(name)
+}
+
+func setIngestValue(field reflect.Value, text string) error {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil
+	}
+	if field.Kind() == reflect.Ptr {
+		value := reflect.New(field.Type().Elem())
+		if err := setIngestValue(value.Elem(), text); err != nil {
+			return err
+		}
+		field.Set(value)
+		return nil
+	}
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(text)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		value, err := strconv.ParseInt(text, 10, field.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("invalid integer: %v", text)
+		}
+		field.SetInt(value)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		value, err := strconv.ParseUint(text, 10, field.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("invalid unsigned integer: %v", text)
+		}
+		field.SetUint(value)
+	case reflect.Float32, reflect.Float64:
+		value, err := strconv.ParseFloat(text, field.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("invalid number: %v", text)
+		}
+		field.SetFloat(value)
+	case reflect.Bool:
+		value, err := strconv.ParseBool(text)
+		if err != nil {
+			return fmt.Errorf("invalid bool: %v", text)
+		}
+		field.SetBool(value)
+	default:
+		if field.Type() != timeType {
+			return fmt.Errorf("unsupported field type: %v", field.Type())
+		}
+		for _, layout := range []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02", "01-02-06", "1/2/2006", "1/2/06 15:04"} {
+			if value, err := time.Parse(layout, text); err == nil {
+				field.Set(reflect.ValueOf(value))
+				return nil
+			}
+		}
+		return fmt.Errorf("invalid time: %v", text)
+	}
+	return nil
+}
+
+func isBlankRow(values []string) bool {
+	for _, value := range values {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func (b *ingestBatch) append(row int, record interface{}) {
+	b.rows = append(b.rows, row)
+	b.slice = reflect.Append(b.slice, reflect.ValueOf(record))
+}
+
+func (b *ingestBatch) reset() {
+	b.rows = b.rows[:0]
+	b.slice = b.slice.Slice(0, 0)
+}
@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
+)
+
+type ingestRecord struct {
+	ID        int      `sqlx:"ID"`
+	Name      string   `sqlx:"name=NAME"`
+	Price     *float64 `json:"price"`
+	Active    bool
+	CreatedAt time.Time `sqlx:"CREATED_AT"`
+}
+
+func TestIngestMapper_Record(t *testing.T) {
+	mapper, err := newIngestMapper(reflect.TypeOf(ingestRecord{}), []string{"id", "Product Name", "Price", "active", "created_at", "ignored"}, map[string]string{"productname": "Name"})
+	require.Nil(t, err)
+
+	record, errors := mapper.record(2, []string{"1", "abc", "1.5", "true", "2024-03-01", "x"})
+	require.Empty(t, errors)
+	actual := record.(*ingestRecord)
+	assert.Equal(t, 1, actual.ID)
+	assert.Equal(t, "abc", actual.Name)
+	assert.Equal(t, 1.5, *actual.Price)
+	assert.True(t, actual.Active)
+	assert.Equal(t, 2024, actual.CreatedAt.Year())
+
+	record, errors = mapper.record(3, []string{"x", "abc", "", "maybe"})
+	require.Len(t, errors, 2)
+	assert.Equal(t, &IngestError{Row: 3, Column: "id", Field: "ID", Message: "invalid integer: x"}, errors[0])
+	assert.Equal(t, "Active", errors[1].Field)
+	assert.Nil(t, record.(*ingestRecord).Price)
+
+	_, err = newIngestMapper(reflect.TypeOf(ingestRecord{}), []string{"id"}, map[string]string{"id": "Missing"})
+	assert.NotNil(t, err)
+	_, err = newIngestMapper(reflect.TypeOf(ingestRecord{}), []string{"foo", "bar"}, nil)
+	assert.NotNil(t, err)
+}
+
+func TestOpenRows(t *testing.T) {
+	spreadsheet := excelize.NewFile()
+	require.Nil(t, spreadsheet.SetSheetRow("Sheet1", "A1", &[]interface{}{"ID", "NAME"}))
+	require.Nil(t, spreadsheet.SetSheetRow("Sheet1", "A2", &[]interface{}{1, "abc"}))
+	xlsx, err := spreadsheet.WriteToBuffer()
+	require.Nil(t, err)
+
+	var testCases = []struct {
+		description string
+		filename    string
+		data        []byte
+	}{
+		{description: "csv", filename: "products.csv", data: []byte("ID,NAME\n1,abc\n")},
+		{description: "tsv", filename: "products.tsv", data: []byte("ID\tNAME\n1\tabc\n")},
+		{description: "xlsx", filename: "products.xlsx", data: xlsx.Bytes()},
+	}
+	for _, testCase := range testCases {
+		file := multipartFile(t, testCase.filename, testCase.data)
+		reader, closer, err := openRows(file)
+		require.Nil(t, err, testCase.description)
+		header, err := reader.Read()
+		require.Nil(t, err, testCase.description)
+		assert.Equal(t, []string{"ID", "NAME"}, header, testCase.description)
+		row, err := reader.Read()
+		require.Nil(t, err, testCase.description)
+		assert.Equal(t, []string{"1", "abc"}, row, testCase.description)
+		_, err = reader.Read()
+		assert.Equal(t, io.EOF, err, testCase.description)
+		assert.Nil(t, closer.Close(), testCase.description)
+	}
+}
+
+func multipartFile(t *testing.T, filename string, data []byte) *multipart.FileHeader {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile("file", filename)
+	require.Nil(t, err)
+	_, err = part.Write(data)
+	require.Nil(t, err)
+	require.Nil(t, writer.Close())
+	form, err := multipart.NewReader(body, writer.Boundary()).ReadForm(1 << 20)
+	require.Nil(t, err)
+	return form.File["file"][0]
+}
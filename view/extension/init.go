@@ -78,6 +78,7 @@ func InitRegistry() {
 			xreflect.NewType("predicate.NamedFilters", xreflect.WithReflectType(reflect.TypeOf(predicate.NamedFilters{}))),
 			xreflect.NewType("LoadData", xreflect.WithReflectType(reflect.TypeOf(&handler.LoadDataProvider{}))),
 			xreflect.NewType("LoadDelimitedData", xreflect.WithReflectType(reflect.TypeOf(&handler.LoadDelimitedDataProvider{}))),
+			xreflect.NewType("IngestData", xreflect.WithReflectType(reflect.TypeOf(&handler.IngestDataProvider{}))),
 			xreflect.NewType("handler.ProxyProvider", xreflect.WithReflectType(reflect.TypeOf(&handler.ProxyProvider{}))),
 			xreflect.NewType("auth.Token", xreflect.WithReflectType(reflect.TypeOf(&auth.Token{}))),
 			xreflect.NewType("Token", xreflect.WithReflectType(reflect.TypeOf(&auth.Token{}))),
@@ -115,6 +116,7 @@ func InitRegistry() {
 			codec.WithFactory(dcodec.KeyCognitoAuth, &dcodec.CogitoAuth{}, time.Time{}),
 			codec.WithFactory(dcodec.KeyCustomAuth, dcodec.NewCustomAuth(nil), time.Time{}),
 		),
+		Formats: rcontent.Formats(),
 		Predicates: &PredicateRegistry{
 			registry: map[string]*Predicate{
 				PredicateEqual:             NewEqualPredicate(),
@@ -2,6 +2,7 @@ package extension
 
 import (
 	"fmt"
+	"github.com/viant/datly/repository/content"
 	"github.com/viant/xdatly/codec"
 	"github.com/viant/xdatly/docs"
 	"github.com/viant/xdatly/types/core"
@@ -17,6 +18,7 @@ type Registry struct {
 	Codecs     *codec.Registry
 	Predicates *PredicateRegistry
 	Docs       *docs.Registry
+	Formats    *content.FormatRegistry
 }
 
 func NewRegistry() *Registry {
@@ -39,6 +41,7 @@ func NewRegistry() *Registry {
 		Types:      xreflect.NewTypes(xreflect.WithRegistry(Config.Types)),
 		Codecs:     Config.Codecs,
 		Predicates: predictes,
+		Formats:    Config.Formats,
 	}
 }
 
@@ -61,6 +64,13 @@ func (r *Registry) RegisterCodecFactory(name string, factory codec.Factory, at t
 	r.Codecs.RegisterFactory(name, factory, at)
 }
 
+// RegisterFormat registers content format, i.e. yaml or msgpack
+func (r *Registry) RegisterFormat(format *content.Format) error {
+	r.Lock()
+	defer r.Unlock()
+	return r.Formats.Register(format)
+}
+
 func (r *Registry) MergeFrom(toOverride *Registry) {
 	r.Lock()
 	defer r.Unlock()
@@ -0,0 +1,335 @@
+package view
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// ParentKeysVariable expands to comma separated parent key values of the current relation batch
+	ParentKeysVariable = "ParentKeys"
+
+	defaultHTTPMaxPages = 100
+	defaultHTTPTimeout  = 30 * time.Second
+)
+
+type (
+	// HTTPSource represents HTTP/JSON API used as view data source instead of SQL connector.
+	// URL, Header and Body are templates, ${Name} expands view parameter value, ${ParentKeys} expands
+	// parent key values collected for relation batch. Values are path escaped in URL path, query escaped in URL query,
+	// JSON string escaped in body and used as is in header.
+	// Native gRPC sources are not supported, gRPC services are read with their Connect or JSON transcoding endpoint (POST with JSON body)
+	HTTPSource struct {
+		URL          string            `json:",omitempty" yaml:",omitempty"`
+		Method       string            `json:",omitempty" yaml:",omitempty"` // GET by default
+		Header       map[string]string `json:",omitempty" yaml:",omitempty"`
+		Body         string            `json:",omitempty" yaml:",omitempty"`
+		ItemsPath    string            `json:",omitempty" yaml:",omitempty"` // dot path to records array, whole response by default
+		Mapping      map[string]string `json:",omitempty" yaml:",omitempty"` // column name to record dot path, column name by default
+		NextPath     string            `json:",omitempty" yaml:",omitempty"` // dot path to next page URL or cursor
+		PageParam    string            `json:",omitempty" yaml:",omitempty"` // query parameter carrying cursor when next page value is not URL
+		MaxPages     int               `json:",omitempty" yaml:",omitempty"` // 100 by default
+		KeySeparator string            `json:",omitempty" yaml:",omitempty"` // parent keys separator, comma by default
+		TimeoutMs    int               `json:",omitempty" yaml:",omitempty"`
+		client       *http.Client
+	}
+
+	// HTTPRecord represents decoded API record
+	HTTPRecord map[string]interface{}
+)
+
+// Init initialises HTTP source
+func (h *HTTPSource) Init() error {
+	if h.URL == "" {
+		return fmt.Errorf("http source URL was empty")
+	}
+	if scheme := strings.ToLower(strings.SplitN(h.URL, "://", 2)[0]); scheme == "grpc" || scheme == "grpcs" {
+		return fmt.Errorf("unsupported http source URL scheme: %v, gRPC service has to be exposed with Connect or JSON transcoding endpoint", scheme)
+	}
+	if h.Method == "" {
+		h.Method = http.MethodGet
+	}
+	h.Method = strings.ToUpper(h.Method)
+	if h.MaxPages <= 0 {
+		h.MaxPages = defaultHTTPMaxPages
+	}
+	if h.KeySeparator == "" {
+		h.KeySeparator = ","
+	}
+	if h.client == nil {
+		timeout := defaultHTTPTimeout
+		if h.TimeoutMs > 0 {
+			timeout = time.Duration(h.TimeoutMs) * time.Millisecond
+		}
+		h.client = &http.Client{Timeout: timeout}
+	}
+	return nil
+}
+
+// Fetch calls API following next page links, each decoded record is passed to visitor.
+// When limit is positive, fetching stops once limit records are visited; when offset is positive and
+// templates do not pass ${Offset} or ${Page} to the API, the first offset records are skipped
+func (h *HTTPSource) Fetch(ctx context.Context, lookup func(name string) (interface{}, bool), parentKeys []interface{}, limit, offset int, visitor func(record HTTPRecord) error) (int, error) {
+	if h.client == nil {
+		if err := h.Init(); err != nil {
+			return 0, err
+		}
+	}
+	URL := h.expandURL(h.URL, lookup, parentKeys)
+	body := h.expander(lookup, parentKeys, jsonEscape)(h.Body)
+	headerExpand := h.expander(lookup, parentKeys, rawValue)
+	skip := 0
+	if offset > 0 && !h.paginatesAPI() {
+		skip = offset
+	}
+	records := 0
+	for page := 0; page < h.MaxPages && URL != "" && (limit <= 0 || records < limit); page++ {
+		response, err := h.call(ctx, URL, body, headerExpand)
+		if err != nil {
+			return records, err
+		}
+		items, err := h.items(response)
+		if err != nil {
+			return records, err
+		}
+		for _, item := range items {
+			record, ok := item.(map[string]interface{})
+			if !ok {
+				return records, fmt.Errorf("http source %v: expected object record, but had %T", h.URL, item)
+			}
+			if skip > 0 {
+				skip--
+				continue
+			}
+			if limit > 0 && records >= limit {
+				break
+			}
+			records++
+			if err = visitor(record); err != nil {
+				return records, err
+			}
+		}
+		if URL, err = h.nextURL(URL, response); err != nil {
+			return records, err
+		}
+	}
+	return records, nil
+}
+
+// Value returns column value, Mapping path is used when defined, otherwise column is matched case-insensitively
+func (h *HTTPSource) Value(record HTTPRecord, column string) (interface{}, bool) {
+	if path, ok := h.Mapping[column]; ok {
+		return pathValue(map[string]interface{}(record), path)
+	}
+	if value, ok := record[column]; ok {
+		return value, true
+	}
+	for key, value := range record {
+		if strings.EqualFold(key, column) {
+			return value, true
+		}
+	}
+	return nil, false
+}
+
+func (h *HTTPSource) call(ctx context.Context, URL string, body string, expand func(string) string) (interface{}, error) {
+	var reader io.Reader
+	if body != "" {
+		reader = strings.NewReader(body)
+	}
+	request, err := http.NewRequestWithContext(ctx, h.Method, URL, reader)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Accept", "application/json")
+	if body != "" {
+		request.Header.Set("Content-Type", "application/json")
+	}
+	for key, value := range h.Header {
+		request.Header.Set(key, expand(value))
+	}
+	response, err := h.client.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("http source %v %v: %w", h.Method, URL, err)
+	}
+	defer response.Body.Close()
+	data, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return nil, fmt.Errorf("http source %v %v: unexpected status code: %v, %s", h.Method, URL, response.StatusCode, data)
+	}
+	var ret interface{}
+	if err = json.Unmarshal(data, &ret); err != nil {
+		return nil, fmt.Errorf("http source %v %v: invalid JSON response: %w", h.Method, URL, err)
+	}
+	return ret, nil
+}
+
+func (h *HTTPSource) items(response interface{}) ([]interface{}, error) {
+	value := response
+	if h.ItemsPath != "" {
+		var ok bool
+		if value, ok = pathValue(response, h.ItemsPath); !ok || value == nil {
+			return nil, nil
+		}
+	}
+	switch actual := value.(type) {
+	case []interface{}:
+		return actual, nil
+	case map[string]interface{}:
+		return []interface{}{actual}, nil
+	}
+	return nil, fmt.Errorf("http source %v: expected records at %q, but had %T", h.URL, h.ItemsPath, value)
+}
+
+func (h *HTTPSource) nextURL(URL string, response interface{}) (string, error) {
+	if h.NextPath == "" {
+		return "", nil
+	}
+	value, ok := pathValue(response, h.NextPath)
+	if !ok || value == nil {
+		return "", nil
+	}
+	next := formatValue(value)
+	if next == "" {
+		return "", nil
+	}
+	if h.PageParam == "" {
+		base, err := url.Parse(URL)
+		if err != nil {
+			return "", err
+		}
+		ref, err := url.Parse(next)
+		if err != nil {
+			return "", fmt.Errorf("http source %v: invalid next page URL %v: %w", h.URL, next, err)
+		}
+		return base.ResolveReference(ref).String(), nil
+	}
+	parsed, err := url.Parse(URL)
+	if err != nil {
+		return "", err
+	}
+	query := parsed.Query()
+	query.Set(h.PageParam, next)
+	parsed.RawQuery = query.Encode()
+	return parsed.String(), nil
+}
+
+// paginatesAPI returns true if selector offset is passed to the API
+func (h *HTTPSource) paginatesAPI() bool {
+	for _, name := range []string{"Offset", "Page"} {
+		if strings.Contains(h.URL, "${"+name+"}") || strings.Contains(h.Body, "${"+name+"}") {
+			return true
+		}
+	}
+	return false
+}
+
+// expandURL expands URL template, values are path escaped before query and query escaped after
+func (h *HTTPSource) expandURL(URL string, lookup func(name string) (interface{}, bool), parentKeys []interface{}) string {
+	query := ""
+	if index := strings.Index(URL, "?"); index != -1 {
+		URL, query = URL[:index], URL[index:]
+	}
+	return h.expander(lookup, parentKeys, url.PathEscape)(URL) + h.expander(lookup, parentKeys, url.QueryEscape)(query)
+}
+
+func (h *HTTPSource) expander(lookup func(name string) (interface{}, bool), parentKeys []interface{}, escape func(string) string) func(string) string {
+	return func(text string) string {
+		if !strings.Contains(text, "${") {
+			return text
+		}
+		var sb strings.Builder
+		for {
+			start := strings.Index(text, "${")
+			if start == -1 {
+				break
+			}
+			end := strings.Index(text[start:], "}")
+			if end == -1 {
+				break
+			}
+			sb.WriteString(text[:start])
+			name := text[start+2 : start+end]
+			sb.WriteString(h.variable(name, lookup, parentKeys, escape))
+			text = text[start+end+1:]
+		}
+		sb.WriteString(text)
+		return sb.String()
+	}
+}
+
+func (h *HTTPSource) variable(name string, lookup func(name string) (interface{}, bool), parentKeys []interface{}, escape func(string) string) string {
+	if name == ParentKeysVariable {
+		keys := make([]string, 0, len(parentKeys))
+		for _, key := range parentKeys {
+			keys = append(keys, escape(formatValue(key)))
+		}
+		return strings.Join(keys, h.KeySeparator)
+	}
+	if lookup == nil {
+		return ""
+	}
+	value, ok := lookup(name)
+	if !ok {
+		return ""
+	}
+	return escape(formatValue(value))
+}
+
+// jsonEscape escapes value to be embedded in JSON body, enclosing quotes are defined by body template
+func jsonEscape(value string) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return value
+	}
+	return string(data[1 : len(data)-1])
+}
+
+func rawValue(value string) string {
+	return value
+}
+
+func pathValue(value interface{}, path string) (interface{}, bool) {
+	for _, segment := range strings.Split(path, ".") {
+		record, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if value, ok = record[segment]; !ok {
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+func formatValue(value interface{}) string {
+	switch actual := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return actual
+	case *string:
+		if actual == nil {
+			return ""
+		}
+		return *actual
+	case float64:
+		return strconv.FormatFloat(actual, 'f', -1, 64)
+	case *int:
+		if actual == nil {
+			return ""
+		}
+		return strconv.Itoa(*actual)
+	}
+	return fmt.Sprintf("%v", value)
+}
@@ -0,0 +1,140 @@
+package view
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPSource_Fetch(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		requests = append(requests, request.URL.RequestURI())
+		response := map[string]interface{}{}
+		switch request.URL.Query().Get("cursor") {
+		case "":
+			response["data"] = map[string]interface{}{"items": []interface{}{
+				map[string]interface{}{"id": 1, "vendor": map[string]interface{}{"name": "V1"}},
+				map[string]interface{}{"id": 2, "vendor": map[string]interface{}{"name": "V2"}},
+			}}
+			response["next"] = "p2"
+		case "p2":
+			response["data"] = map[string]interface{}{"items": []interface{}{
+				map[string]interface{}{"id": 3, "vendor": map[string]interface{}{"name": "V3"}},
+			}}
+		}
+		_ = json.NewEncoder(writer).Encode(response)
+	}))
+	defer server.Close()
+
+	source := &HTTPSource{
+		URL:       server.URL + "/products?ids=${ParentKeys}&status=${Status}",
+		ItemsPath: "data.items",
+		Mapping:   map[string]string{"VENDOR_NAME": "vendor.name"},
+		NextPath:  "next",
+		PageParam: "cursor",
+	}
+	require.Nil(t, source.Init())
+	lookup := func(name string) (interface{}, bool) {
+		if name == "Status" {
+			return "active", true
+		}
+		return nil, false
+	}
+	var ids, vendors []interface{}
+	count, err := source.Fetch(context.Background(), lookup, []interface{}{1, 2, "a b"}, 0, 0, func(record HTTPRecord) error {
+		id, _ := source.Value(record, "ID")
+		vendor, _ := source.Value(record, "VENDOR_NAME")
+		ids = append(ids, id)
+		vendors = append(vendors, vendor)
+		return nil
+	})
+	require.Nil(t, err)
+	assert.Equal(t, 3, count)
+	assert.Equal(t, []interface{}{1.0, 2.0, 3.0}, ids)
+	assert.Equal(t, []interface{}{"V1", "V2", "V3"}, vendors)
+	require.Len(t, requests, 2)
+	assert.Equal(t, "/products?ids=1,2,a+b&status=active", requests[0])
+	assert.Equal(t, "/products?cursor=p2&ids=1%2C2%2Ca+b&status=active", requests[1])
+}
+
+func TestHTTPSource_Fetch_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+	source := &HTTPSource{URL: server.URL}
+	_, err := source.Fetch(context.Background(), nil, nil, 0, 0, func(record HTTPRecord) error { return nil })
+	assert.NotNil(t, err)
+}
+
+func TestHTTPSource_Fetch_Escape(t *testing.T) {
+	var path, query, body, header string
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		path, query, header = request.URL.EscapedPath(), request.URL.RawQuery, request.Header.Get("X-Name")
+		data, _ := io.ReadAll(request.Body)
+		body = string(data)
+		_, _ = writer.Write([]byte("[]"))
+	}))
+	defer server.Close()
+	source := &HTTPSource{
+		URL:    server.URL + "/products/${Name}?name=${Name}",
+		Method: http.MethodPost,
+		Header: map[string]string{"X-Name": "${Name}"},
+		Body:   `{"name":"${Name}"}`,
+	}
+	lookup := func(name string) (interface{}, bool) { return `a/b "c"&d`, true }
+	_, err := source.Fetch(context.Background(), lookup, nil, 0, 0, func(record HTTPRecord) error { return nil })
+	require.Nil(t, err)
+	assert.Equal(t, "/products/a%2Fb%20%22c%22&d", path)
+	assert.Equal(t, "name=a%2Fb+%22c%22%26d", query)
+	assert.Equal(t, `a/b "c"&d`, header)
+	var payload map[string]string
+	require.Nil(t, json.Unmarshal([]byte(body), &payload))
+	assert.Equal(t, `a/b "c"&d`, payload["name"])
+}
+
+func TestHTTPSource_Fetch_Limit(t *testing.T) {
+	pages := 0
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		pages++
+		_ = json.NewEncoder(writer).Encode(map[string]interface{}{
+			"items": []interface{}{map[string]interface{}{"id": pages*2 - 1}, map[string]interface{}{"id": pages * 2}},
+			"next":  pages + 1,
+		})
+	}))
+	defer server.Close()
+	source := &HTTPSource{URL: server.URL, ItemsPath: "items", NextPath: "next", PageParam: "page"}
+	var ids []interface{}
+	count, err := source.Fetch(context.Background(), nil, nil, 3, 2, func(record HTTPRecord) error {
+		ids = append(ids, record["id"])
+		return nil
+	})
+	require.Nil(t, err)
+	assert.Equal(t, 3, count)
+	assert.Equal(t, []interface{}{3.0, 4.0, 5.0}, ids)
+	assert.Equal(t, 3, pages)
+}
+
+func TestHTTPSource_Init(t *testing.T) {
+	var testCases = []struct {
+		description string
+		URL         string
+		expectErr   bool
+	}{
+		{description: "http", URL: "http://localhost/products"},
+		{description: "connect endpoint", URL: "https://localhost/product.v1.ProductService/List"},
+		{description: "empty", URL: "", expectErr: true},
+		{description: "native gRPC", URL: "grpc://localhost:9090/product.v1.ProductService/List", expectErr: true},
+	}
+	for _, testCase := range testCases {
+		err := (&HTTPSource{URL: testCase.URL}).Init()
+		assert.Equal(t, testCase.expectErr, err != nil, testCase.description)
+	}
+}
@@ -0,0 +1,105 @@
+package view
+
+import (
+	"fmt"
+
+	"github.com/viant/xunsafe"
+)
+
+// JoinStrategy defines how relation child view is matched with parent keys
+type JoinStrategy string
+
+const (
+	JoinIn        JoinStrategy = "in"         // parent keys are batched into IN list (default)
+	JoinHash      JoinStrategy = "hash"       // child view is streamed and matched with parent keys in memory
+	JoinTempTable JoinStrategy = "temp_table" // parent keys are pushed into keys table on the child connector
+	JoinAuto      JoinStrategy = "auto"       // strategy is selected by parent key count
+
+	// JoinKeysTable defines table used by temp_table join strategy
+	JoinKeysTable = "DATLY_JOIN_KEYS"
+
+	defaultHashJoinThreshold = 10000
+	defaultSpillThreshold    = 100000
+)
+
+// Join represents relation join strategy
+type Join struct {
+	Strategy           JoinStrategy `json:",omitempty" yaml:",omitempty"`
+	HashThreshold      int          `json:",omitempty" yaml:",omitempty"` // auto: parent key count above which hash join is used, 10000 by default
+	TempTableThreshold int          `json:",omitempty" yaml:",omitempty"` // auto: parent key count above which keys table is used, disabled by default
+	SpillThreshold     int          `json:",omitempty" yaml:",omitempty"` // hash: parent keys kept in memory, above that keys and child rows spill to disk, 100000 by default
+	SpillDir           string       `json:",omitempty" yaml:",omitempty"` // hash: spill directory, os temp dir by default
+}
+
+// Validate checks if join is valid
+func (j *Join) Validate() error {
+	switch j.Strategy {
+	case "", JoinIn, JoinHash, JoinTempTable, JoinAuto:
+	default:
+		return fmt.Errorf("unsupported join strategy %v", j.Strategy)
+	}
+	if j.HashThreshold < 0 || j.TempTableThreshold < 0 || j.SpillThreshold < 0 {
+		return fmt.Errorf("join thresholds can not be negative")
+	}
+	return nil
+}
+
+// ValidateDriver checks if join strategy is supported by child view connector driver
+func (j *Join) ValidateDriver(driver string) error {
+	if j.Strategy == JoinTempTable || (j.Strategy == JoinAuto && j.TempTableThreshold > 0) {
+		if !SupportsTempTableJoin(driver) {
+			return fmt.Errorf("join strategy %v is not supported with %v driver, supported: mysql, bigquery", JoinTempTable, driver)
+		}
+	}
+	return nil
+}
+
+// SupportsTempTableJoin returns true if driver supports keys table join strategy
+func SupportsTempTableJoin(driver string) bool {
+	switch driver {
+	case "mysql", "bigquery":
+		return true
+	}
+	return false
+}
+
+// Select returns strategy for parent key count
+func (j *Join) Select(parentKeys int) JoinStrategy {
+	if j == nil {
+		return JoinIn
+	}
+	switch j.Strategy {
+	case "":
+		return JoinIn
+	case JoinAuto:
+	default:
+		return j.Strategy
+	}
+	if j.TempTableThreshold > 0 && parentKeys > j.TempTableThreshold {
+		return JoinTempTable
+	}
+	threshold := j.HashThreshold
+	if threshold == 0 {
+		threshold = defaultHashJoinThreshold
+	}
+	if parentKeys > threshold {
+		return JoinHash
+	}
+	return JoinIn
+}
+
+// SpillLimit returns number of parent keys kept in memory by hash join
+func (j *Join) SpillLimit() int {
+	if j == nil || j.SpillThreshold == 0 {
+		return defaultSpillThreshold
+	}
+	return j.SpillThreshold
+}
+
+// ChildKeyField returns child view field holding relation key or nil if relation key is not mapped to field
+func (r *Relation) ChildKeyField() *xunsafe.Field {
+	if r == nil || r.Of == nil || len(r.Of.On) != 1 {
+		return nil
+	}
+	return r.Of.On[0].xField
+}
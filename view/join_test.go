@@ -0,0 +1,40 @@
+package view
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJoin_Select(t *testing.T) {
+	var testCases = []struct {
+		description string
+		join        *Join
+		parentKeys  int
+		expect      JoinStrategy
+	}{
+		{description: "not configured", parentKeys: 1000000, expect: JoinIn},
+		{description: "explicit", join: &Join{Strategy: JoinHash}, parentKeys: 1, expect: JoinHash},
+		{description: "auto below threshold", join: &Join{Strategy: JoinAuto}, parentKeys: 100, expect: JoinIn},
+		{description: "auto default hash threshold", join: &Join{Strategy: JoinAuto}, parentKeys: 20000, expect: JoinHash},
+		{description: "auto custom hash threshold", join: &Join{Strategy: JoinAuto, HashThreshold: 50}, parentKeys: 100, expect: JoinHash},
+		{description: "auto temp table", join: &Join{Strategy: JoinAuto, HashThreshold: 50, TempTableThreshold: 500}, parentKeys: 1000, expect: JoinTempTable},
+	}
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.expect, testCase.join.Select(testCase.parentKeys), testCase.description)
+	}
+}
+
+func TestJoin_Validate(t *testing.T) {
+	assert.Nil(t, (&Join{Strategy: JoinAuto}).Validate())
+	assert.NotNil(t, (&Join{Strategy: "merge"}).Validate())
+	assert.NotNil(t, (&Join{HashThreshold: -1}).Validate())
+}
+
+func TestJoin_ValidateDriver(t *testing.T) {
+	assert.Nil(t, (&Join{Strategy: JoinTempTable}).ValidateDriver("mysql"))
+	assert.Nil(t, (&Join{Strategy: JoinHash}).ValidateDriver("postgres"))
+	assert.Nil(t, (&Join{Strategy: JoinAuto}).ValidateDriver("postgres"))
+	assert.NotNil(t, (&Join{Strategy: JoinTempTable}).ValidateDriver("postgres"))
+	assert.NotNil(t, (&Join{Strategy: JoinAuto, TempTableThreshold: 100}).ValidateDriver("postgres"))
+}
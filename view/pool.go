@@ -29,6 +29,8 @@ type (
 		ctx         context.Context
 		cancelFunc  context.CancelFunc
 		initialized bool
+		dsn         string
+		reload      func(ctx context.Context) (string, error) // reloads secret expanded dsn on auth failure
 	}
 
 	aerospikeClientRegistry struct {
@@ -151,6 +153,7 @@ func (d *db) initDatabase(driver string, dsn string, config *DBConfig) error {
 	}
 
 	d.initialized = true
+	d.dsn = dsn
 	started := time.Now()
 	d.actual, d.err = sql.Open(driver, dsn)
 	if elapsed := time.Since(started); elapsed > 100*time.Millisecond {
@@ -225,7 +228,13 @@ func (d *db) keepConnectionAlive(driver string, dsn string, config *DBConfig) {
 					//}
 				}
 
+				if err != nil && IsAuthError(driver, err) && d.reloadDSN(driver, config) {
+					continue
+				}
 				if err != nil || aDb == nil {
+					d.mutex.Lock()
+					dsn := d.dsn
+					d.mutex.Unlock()
 					newDb, err := sql.Open(driver, dsn)
 					d.mutex.Lock()
 					d.actual = newDb
@@ -254,11 +263,17 @@ func (d *db) ctxWithTimeout(duration time.Duration) (context.Context, context.Ca
 }
 
 func (p *dbRegistry) DB(driver, dsn string, config *DBConfig) func() (*sql.DB, error) {
-	builder := &strings.Builder{}
-
 	if config == nil {
 		config = &DBConfig{}
 	}
+	actualKey := poolKey(driver, dsn, config)
+	dbConn := p.getItem(actualKey, driver, dsn, config)
+
+	return dbConn.connect
+}
+
+func poolKey(driver, dsn string, config *DBConfig) string {
+	builder := &strings.Builder{}
 
 	builder.WriteString(strconv.Itoa(config.ConnMaxLifetimeMs))
 	builder.WriteByte('#')
@@ -271,11 +286,7 @@ func (p *dbRegistry) DB(driver, dsn string, config *DBConfig) func() (*sql.DB, e
 	builder.WriteString(driver)
 	builder.WriteString("://")
 	builder.WriteString(dsn)
-
-	actualKey := builder.String()
-	dbConn := p.getItem(actualKey, driver, dsn, config)
-
-	return dbConn.connect
+	return builder.String()
 }
 
 func (p *dbRegistry) getItem(key string, driver string, dsn string, config *DBConfig) *db {
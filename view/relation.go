@@ -26,6 +26,7 @@ type (
 		On            Links
 		Holder        string `json:",omitempty"` //Represents column created due to the merging. In our example it would be Employee#Account
 		IncludeColumn bool   `json:",omitempty"` //tells if Column _field should be kept in the struct type. In our example, if set false in produced Employee would be also AccountId _field
+		Join          *Join  `json:",omitempty"` //child view join strategy, parent keys are batched into IN list by default
 		holderField   *xunsafe.Field
 	}
 
@@ -220,6 +221,16 @@ func (r *Relation) Validate() error {
 	if strings.Title(r.Holder)[0] != r.Holder[0] {
 		return fmt.Errorf("holder has to start with uppercase")
 	}
+	if r.Join != nil {
+		if err := r.Join.Validate(); err != nil {
+			return fmt.Errorf("relation %v: %w", r.Name, err)
+		}
+		if connector := r.Of.View.Connector; connector != nil && r.Of.View.HTTP == nil {
+			if err := r.Join.ValidateDriver(connector.Driver); err != nil {
+				return fmt.Errorf("relation %v: %w", r.Name, err)
+			}
+		}
+	}
 
 	return nil
 }
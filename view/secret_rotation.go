@@ -0,0 +1,227 @@
+package view
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
+	"github.com/viant/scy"
+)
+
+// DrainTimeout defines how long a pool replaced by secret rotation keeps serving in-flight work before it is closed
+var DrainTimeout = 30 * time.Second
+
+// IsAuthError returns true if driver error code indicates rejected database credentials
+func IsAuthError(driver string, err error) bool {
+	if err == nil {
+		return false
+	}
+	switch strings.ToLower(driver) {
+	case "mysql":
+		var mysqlErr *mysql.MySQLError
+		//1045: ER_ACCESS_DENIED_ERROR, 1698: ER_ACCESS_DENIED_NO_PASSWORD_ERROR
+		return errors.As(err, &mysqlErr) && (mysqlErr.Number == 1045 || mysqlErr.Number == 1698)
+	case "postgres", "pgx":
+		//28000: invalid_authorization_specification, 28P01: invalid_password
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) {
+			return pqErr.Code == "28000" || pqErr.Code == "28P01"
+		}
+		var stateErr interface{ SQLState() string }
+		if errors.As(err, &stateErr) {
+			state := stateErr.SQLState()
+			return state == "28000" || state == "28P01"
+		}
+	case "oracle", "godror":
+		//ORA-01017: invalid username/password
+		var codeErr interface{ Code() int }
+		if errors.As(err, &codeErr) {
+			return codeErr.Code() == 1017
+		}
+		return strings.HasPrefix(err.Error(), "ORA-01017")
+	case "sqlserver", "mssql":
+		//18456: login failed
+		var numberErr interface{ SQLErrorNumber() int32 }
+		return errors.As(err, &numberErr) && numberErr.SQLErrorNumber() == 18456
+	}
+	return false
+}
+
+// IsAuthError returns true if connector database rejected credentials
+func (c *Connector) IsAuthError(err error) bool {
+	return IsAuthError(c.DriverName(), err)
+}
+
+// RefreshedSecret reloads connector secrets when database rejected credentials, it returns true if the operation can be retried
+func (c *Connector) RefreshedSecret(ctx context.Context, err error) bool {
+	if !c.IsAuthError(err) || !c.HasSecret() {
+		return false
+	}
+	return c.RefreshSecret(ctx) == nil
+}
+
+// HasSecret returns true if any connector connection uses secret
+func (c *Connector) HasSecret() bool {
+	if c.Secret != nil {
+		return true
+	}
+	for _, conn := range c.Connections {
+		if conn.Secret != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// RefreshSecret reloads connector secrets, pools with rotated credentials are re-created
+func (c *Connector) RefreshSecret(ctx context.Context) error {
+	if len(c.Connections) == 0 {
+		return c.Connection.RefreshSecret(ctx)
+	}
+	for _, conn := range c.Connections {
+		if err := conn.RefreshSecret(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RefreshSecret reloads connection secret; when expanded DSN changed, the shared pool entry is swapped to
+// the new credentials and the old pool is drained
+func (c *Connection) RefreshSecret(ctx context.Context) error {
+	if c.Secret == nil || c._db == nil {
+		return nil
+	}
+	dsn, err := c.expandDSN(ctx)
+	if err != nil {
+		return err
+	}
+	atomic.StoreInt64(&c._secretLoaded, time.Now().UnixNano())
+	newKey := poolKey(c.Driver, dsn, &c.DBConfig)
+	c.lock()
+	defer c.unlock()
+	if newKey == c._poolKey {
+		return nil
+	}
+	c._db = aDbPool.rotate(c._poolKey, newKey, c.Driver, dsn, &c.DBConfig)
+	c._poolKey = newKey
+	return nil
+}
+
+// refreshSecretIfDue reloads secret in the background once per SecretRefreshMs
+func (c *Connection) refreshSecretIfDue() {
+	if c.Secret == nil || c.SecretRefreshMs <= 0 {
+		return
+	}
+	loaded := time.Unix(0, atomic.LoadInt64(&c._secretLoaded))
+	if time.Since(loaded) < time.Duration(c.SecretRefreshMs)*time.Millisecond {
+		return
+	}
+	if !atomic.CompareAndSwapInt32(&c._refreshing, 0, 1) {
+		return
+	}
+	go func() {
+		defer atomic.StoreInt32(&c._refreshing, 0)
+		if err := c.RefreshSecret(context.Background()); err != nil {
+			fmt.Printf("[WARN] failed to refresh db:%v secret: %v\n", c.Name, err)
+		}
+	}()
+}
+
+// ResolvedDSN returns connection DSN expanded with secret, DSN field is cleared once connection is initialised
+func (c *Connection) ResolvedDSN(ctx context.Context) (string, error) {
+	return c.expandDSN(ctx)
+}
+
+// expandDSN returns DSN with expanded secret
+func (c *Connection) expandDSN(ctx context.Context) (string, error) {
+	dsn := c.getDSN()
+	if c.Secret == nil {
+		return dsn, nil
+	}
+	secret, err := scy.New().Load(ctx, c.Secret)
+	if err != nil {
+		return "", fmt.Errorf("invalid db:%v secret, %w", c.Name, err)
+	}
+	return secret.Expand(dsn), nil
+}
+
+// reloadDSN swaps pool to reloaded credentials, it returns true if credentials changed
+func (d *db) reloadDSN(driver string, config *DBConfig) bool {
+	d.mutex.Lock()
+	reload, current := d.reload, d.dsn
+	d.mutex.Unlock()
+	if reload == nil {
+		return false
+	}
+	dsn, err := reload(context.Background())
+	if err != nil || dsn == current {
+		return false
+	}
+	aDbPool.alias(d, poolKey(driver, dsn, config))
+	d.swap(driver, dsn, config)
+	return true
+}
+
+// swap opens pool with supplied dsn, the previous pool is closed after DrainTimeout
+func (d *db) swap(driver, dsn string, config *DBConfig) {
+	newDb, err := sql.Open(driver, dsn)
+	if err != nil {
+		fmt.Printf("[WARN] failed to open rotated %v connection: %v\n", driver, err)
+		return
+	}
+	d.configureDB(config, newDb)
+	d.mutex.Lock()
+	previous := d.actual
+	d.actual = newDb
+	d.dsn = dsn
+	d.err = nil
+	d.mutex.Unlock()
+	if previous != nil {
+		time.AfterFunc(DrainTimeout, func() { _ = previous.Close() })
+	}
+}
+
+// rotate moves pool entry to the key of rotated credentials, connections sharing the entry pick up new credentials
+func (p *dbRegistry) rotate(oldKey, newKey, driver, dsn string, config *DBConfig) func() (*sql.DB, error) {
+	p.mutex.Lock()
+	if item, ok := p.index[newKey]; ok { //already rotated by other connection
+		p.mutex.Unlock()
+		return item.connect
+	}
+	item, ok := p.index[oldKey]
+	p.mutex.Unlock()
+	if !ok {
+		return p.DB(driver, dsn, config)
+	}
+	p.alias(item, newKey)
+	item.swap(driver, dsn, config)
+	return item.connect
+}
+
+func (p *dbRegistry) alias(item *db, key string) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.index[key] = item
+}
+
+// onAuthFailure sets pool entry credentials reload function
+func (p *dbRegistry) onAuthFailure(key string, reload func(ctx context.Context) (string, error)) {
+	p.mutex.Lock()
+	item, ok := p.index[key]
+	p.mutex.Unlock()
+	if !ok {
+		return
+	}
+	item.mutex.Lock()
+	if item.reload == nil {
+		item.reload = reload
+	}
+	item.mutex.Unlock()
+}
@@ -0,0 +1,40 @@
+package view
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+type sqlStateError string
+
+func (e sqlStateError) Error() string    { return "sqlstate " + string(e) }
+func (e sqlStateError) SQLState() string { return string(e) }
+
+func TestIsAuthError(t *testing.T) {
+	var testCases = []struct {
+		description string
+		driver      string
+		err         error
+		expect      bool
+	}{
+		{description: "nil", driver: "mysql", err: nil, expect: false},
+		{description: "mysql", driver: "mysql", err: &mysql.MySQLError{Number: 1045, Message: "Access denied for user 'app'@'10.0.0.1'"}, expect: true},
+		{description: "mysql wrapped", driver: "mysql", err: fmt.Errorf("failed to exec: %w", &mysql.MySQLError{Number: 1698}), expect: true},
+		{description: "mysql table access", driver: "mysql", err: &mysql.MySQLError{Number: 1142, Message: "SELECT command denied to user"}, expect: false},
+		{description: "mysql message only", driver: "mysql", err: errors.New("Error 1045 (28000): Access denied for user"), expect: false},
+		{description: "postgres", driver: "postgres", err: &pq.Error{Code: "28P01", Message: "password authentication failed for user \"app\""}, expect: true},
+		{description: "postgres other", driver: "postgres", err: &pq.Error{Code: "42501", Message: "permission denied for table account"}, expect: false},
+		{description: "pgx", driver: "pgx", err: sqlStateError("28000"), expect: true},
+		{description: "oracle", driver: "oracle", err: errors.New("ORA-01017: invalid username/password; logon denied"), expect: true},
+		{description: "other driver", driver: "bigquery", err: errors.New("access denied"), expect: false},
+		{description: "connection", driver: "mysql", err: errors.New("dial tcp: connection refused"), expect: false},
+	}
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.expect, IsAuthError(testCase.driver, testCase.err), testCase.description)
+	}
+}
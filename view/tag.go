@@ -60,6 +60,9 @@ func generateFieldTag(column *Column, viewCaseFormat text.CaseFormat, doc state.
 	if column.Codec != nil {
 		result.SetTag(aTag)
 	}
+	if column.Auth != nil {
+		result.Set(AuthTag, column.Auth.Tag())
+	}
 	sort.Slice(result, func(i, j int) bool {
 		// Prioritize "sqlx" as the first element
 		if result[i].Name == io.TagSqlx && result[j].Name != io.TagSqlx {
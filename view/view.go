@@ -89,6 +89,9 @@ type (
 		SelfReference *SelfReference           `json:",omitempty"`
 
 		TableBatches     map[string]bool `json:",omitempty"`
+		TimeoutMs        int             `json:",omitempty"` // view execution deadline
+		HTTP             *HTTPSource     `json:",omitempty"` // HTTP/JSON API data source, used instead of SQL
+		ExplainMs        int             `json:",omitempty"` // captures query plan when read takes longer
 		_transforms      marshal.Transforms
 		_resource        *Resource
 		_embedder        *state.FSEmbedder
@@ -683,6 +686,11 @@ func (v *View) initView(ctx context.Context) error {
 	if err = v.ensureConnector(ctx); err != nil {
 		return err
 	}
+	if v.HTTP != nil {
+		if err = v.HTTP.Init(); err != nil {
+			return fmt.Errorf("view %v: %w", v.Name, err)
+		}
+	}
 
 	if v.Mode == ModeQuery || v.Mode == ModeUnspecified {
 		if err = v.ensureColumns(ctx, v._resource); err != nil {
@@ -725,6 +733,9 @@ func (v *View) initView(ctx context.Context) error {
 	if err = v.initTemplate(ctx, v._resource); err != nil {
 		return err
 	}
+	if v.HTTP != nil && v.Template != nil && v.Template.Summary != nil {
+		return fmt.Errorf("view %v: summary is not supported with HTTP source", v.Name)
+	}
 
 	if v.Cache != nil {
 		if err = v.Cache.init(ctx, v._resource, v); err != nil {
@@ -835,10 +846,15 @@ func (v *View) ensureConnector(ctx context.Context) error {
 		return nil
 	}
 
+	if v.HTTP != nil && v.Connector == nil { //HTTP view does not require connector
+		return nil
+	}
 	var err error
-	if v.Connector, err = v._resource.FindConnector(v); err != nil {
+	connector, err := v._resource.FindConnector(v)
+	if err != nil {
 		return err
 	}
+	v.Connector = connector
 
 	if err = v.Connector.Init(ctx, v._resource._connectors); err != nil {
 		return err
@@ -957,6 +973,9 @@ func (v *View) ensureColumns(ctx context.Context, resource *Resource) error {
 	if v.Mode == "Write" || v.Mode == ModeExec || v.Mode == ModeHandler {
 		return nil
 	}
+	if v.HTTP != nil {
+		return fmt.Errorf("view %v: HTTP source requires columns or schema type with sqlx tags", v.Name)
+	}
 
 	err := v.detectColumns(ctx, resource)
 	if err != nil {
@@ -969,6 +988,32 @@ func (v *View) ensureColumns(ctx context.Context, resource *Resource) error {
 }
 
 func (v *View) detectColumns(ctx context.Context, resource *Resource) error {
+	columns, err := v.discoverColumns(ctx, resource)
+	if err != nil {
+		return err
+	}
+	v.Columns = columns
+	return nil
+}
+
+// DiscoverColumns discovers view columns from the database using view copy, declared view and its template are left unchanged
+func (v *View) DiscoverColumns(ctx context.Context) (Columns, error) {
+	if v._resource == nil {
+		return nil, fmt.Errorf("view %v was not initialized", v.Name)
+	}
+	if v.Connector == nil {
+		return nil, fmt.Errorf("view %v connector was empty", v.Name)
+	}
+	aView := *v
+	if v.Template != nil {
+		aTemplate := *v.Template
+		aTemplate.Parameters = append(state.Parameters{}, v.Template.Parameters...)
+		aView.Template = &aTemplate
+	}
+	return aView.discoverColumns(ctx, v._resource)
+}
+
+func (v *View) discoverColumns(ctx context.Context, resource *Resource) (Columns, error) {
 	defer func() {
 		if r := recover(); r != nil {
 			panic(fmt.Errorf("detectColumns panic for view=%s ref=%s table=%s source=%s templateURL=%s: %v", v.Name, v.Ref, v.Table, v.Source(), func() string {
@@ -983,7 +1028,7 @@ func (v *View) detectColumns(ctx context.Context, resource *Resource) error {
 	var aState state.Parameters
 	if v.Template != nil {
 		if err := v.Template.Init(ctx, resource, v); err != nil {
-			return err
+			return nil, err
 		}
 		SQL = v.Template.Source
 		aState = v.Template.Parameters
@@ -997,22 +1042,21 @@ func (v *View) detectColumns(ctx context.Context, resource *Resource) error {
 	}
 	query, err := v.BuildParametrizedSQL(aState, resource.TypeRegistry(), SQL, bindingArguments, options...)
 	if err != nil {
-		return fmt.Errorf("failed to build parameterized query: %v due to %w", SQL, err)
+		return nil, fmt.Errorf("failed to build parameterized query: %v due to %w", SQL, err)
 	}
 	if query == nil {
-		return fmt.Errorf("failed to build parameterized query: %v produced nil query", SQL)
+		return nil, fmt.Errorf("failed to build parameterized query: %v produced nil query", SQL)
 	}
 	v.Logger.ColumnsDetection(query.Query, v.Source())
 	db, err := v.Connector.DB()
 	if err != nil {
-		return err
+		return nil, err
 	}
 	sqlColumns, err := column.Discover(ctx, db, v.Table, query.Query, query.Args...)
 	if err != nil {
-		return fmt.Errorf("failed to detect column with: %v due to %w", query.Query, err)
+		return nil, fmt.Errorf("failed to detect column with: %v due to %w", query.Query, err)
 	}
-	v.Columns = NewColumns(sqlColumns, v.ColumnsConfig)
-	return nil
+	return NewColumns(sqlColumns, v.ColumnsConfig), nil
 }
 
 func convertIoColumnsToColumns(ioColumns []io.Column, nullable map[string]bool) []*Column {
@@ -1093,6 +1137,16 @@ func (v *View) Db() (*sql.DB, error) {
 	return v.Connector.DB()
 }
 
+// WriterDb returns primary database connection for writes, it pins session reads to the primary
+func (v *View) WriterDb(ctx context.Context) (*sql.DB, error) {
+	return v.Connector.WriterDB(ctx)
+}
+
+// ReaderDb returns database connection for reads, a healthy read replica when configured
+func (v *View) ReaderDb(ctx context.Context) (*sql.DB, error) {
+	return v.Connector.ReaderDB(ctx)
+}
+
 func (v *View) exclude(columns []io.Column) []io.Column {
 	if len(v.Exclude) == 0 {
 		return columns
@@ -1198,12 +1252,21 @@ func (v *View) inherit(view *View) error {
 	if v.TableBatches == nil {
 		v.TableBatches = view.TableBatches
 	}
+	if v.TimeoutMs == 0 {
+		v.TimeoutMs = view.TimeoutMs
+	}
 	if v.Counter == nil {
 		v.Counter = view.Counter
 	}
 	if v.Partitioned == nil {
 		v.Partitioned = view.Partitioned
 	}
+	if v.HTTP == nil {
+		v.HTTP = view.HTTP
+	}
+	if v.ExplainMs == 0 {
+		v.ExplainMs = view.ExplainMs
+	}
 	return nil
 }
 
@@ -29,6 +29,7 @@ type (
 
 	warmupEntry struct {
 		matcher *cache.ParmetrizedQuery
+		source  *cache.ParmetrizedQuery // watermark source query projecting watermark column with cache key criteria
 		view    *view.View
 		column  string
 		label   string
@@ -48,11 +49,15 @@ type (
 		Elapsed       string
 		TimeTaken     time.Duration
 		GroupsWritten int
+		Skipped       bool   `json:",omitempty"` // watermark unchanged since the last run
+		Watermark     string `json:",omitempty"`
 		Error         string `json:",omitempty"`
 	}
 
 	Result struct {
 		GroupsWritten int
+		Refreshed     int
+		Skipped       int
 		Entries       []*EntryResult
 	}
 )
@@ -117,9 +122,11 @@ func (c *matchersCollector) createMetaWarmupEntry(ctx context.Context, aView *vi
 		}
 		return
 	}
+	source := c.watermarkSource(ctx, input)
 	aChan <- func() (*warmupEntry, error) {
 		return &warmupEntry{
 			matcher: cacheIndex,
+			source:  source,
 			view:    aView,
 			column:  input.MetaColumn,
 			label:   input.Label,
@@ -143,10 +150,12 @@ func (c *matchersCollector) createIndexWarmupEntry(ctx context.Context, aView *v
 		return
 	}
 	build.StoredFields = view.SQLXProjectionFields(cacheInput.StoredFields)
+	source := c.watermarkSource(ctx, cacheInput)
 
 	aChan <- func() (*warmupEntry, error) {
 		return &warmupEntry{
 			matcher: build,
+			source:  source,
 			view:    aView,
 			column:  cacheInput.Column,
 			label:   cacheInput.Label,
@@ -165,8 +174,8 @@ func populateCollector(ctx context.Context, aView *view.View, builder *reader.Bu
 	}).populate(ctx, collector, notifier)
 }
 
-func warmup(ctx context.Context, entries []*warmupEntry, notifier chan func() (*EntryResult, error)) {
-	warmupWithLimit(ctx, entries, notifier, maxWarmupConcurrency, readWithErr)
+func warmup(ctx context.Context, entries []*warmupEntry, notifier chan func() (*EntryResult, error), marks *watermarks) {
+	warmupWithLimit(ctx, entries, notifier, maxWarmupConcurrency, marks.read(readWithErr, queryWatermark))
 }
 
 type warmupReadFn func(context.Context, *warmupEntry) (*EntryResult, error)
@@ -389,8 +398,9 @@ func PopulateCacheWithDetailsContext(ctx context.Context, views []*view.View) (*
 	}
 	fmt.Printf("[INFO] cache warmup entries built entries=%d elapsed=%s\n", len(warmupEntries), time.Since(started))
 
+	marks := newWatermarks(ctx, viewsWithCache)
 	notifierErr := make(chan func() (*EntryResult, error))
-	warmup(ctx, warmupEntries, notifierErr)
+	warmup(ctx, warmupEntries, notifierErr, marks)
 
 	for actual := range notifierErr {
 		if actual == nil {
@@ -400,11 +410,17 @@ func PopulateCacheWithDetailsContext(ctx context.Context, views []*view.View) (*
 		if entryResult != nil {
 			result.Entries = append(result.Entries, entryResult)
 			result.GroupsWritten += entryResult.GroupsWritten
+			if entryResult.Skipped {
+				result.Skipped++
+			} else if err == nil {
+				result.Refreshed++
+			}
 		}
 		if err != nil {
 			errors = append(errors, err)
 		}
 	}
+	marks.save(ctx)
 
 	close(notifier)
 	err := errUtils.CombineErrors("errors while populating cache: ", errors)
@@ -412,7 +428,7 @@ func PopulateCacheWithDetailsContext(ctx context.Context, views []*view.View) (*
 		fmt.Printf("[INFO] cache warmup populate error groups_written=%d entries=%d failures=%d elapsed=%s first_error=%v\n", result.GroupsWritten, len(warmupEntries), len(errors), time.Since(started), firstError(errors))
 		return result, err
 	}
-	fmt.Printf("[INFO] cache warmup populate done groups_written=%d entries=%d elapsed=%s\n", result.GroupsWritten, len(warmupEntries), time.Since(started))
+	fmt.Printf("[INFO] cache warmup populate done groups_written=%d entries=%d refreshed=%d skipped=%d elapsed=%s\n", result.GroupsWritten, len(warmupEntries), result.Refreshed, result.Skipped, time.Since(started))
 	return result, nil
 }
 
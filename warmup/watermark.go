@@ -0,0 +1,232 @@
+package warmup
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/viant/datly/view"
+	"github.com/viant/sqlx/io/read/cache"
+)
+
+type (
+	// watermarks tracks incremental warmup state of views with configured watermark
+	watermarks struct {
+		index map[*view.View]*viewWatermarks
+	}
+
+	viewWatermarks struct {
+		view         *view.View
+		store        view.WatermarkStore
+		previous     *view.WatermarkState
+		current      *view.WatermarkState
+		mux          sync.Mutex
+		partition    string
+		partitionErr error
+		once         sync.Once
+		probes       map[string]string // source query watermarks of the current run, shared by index and meta entries
+	}
+
+	// watermarkQueryFn returns change marker value for the supplied watermark query
+	watermarkQueryFn func(ctx context.Context, entry *warmupEntry, SQL string, args []interface{}) (string, error)
+)
+
+func newWatermarks(ctx context.Context, views []*view.View) *watermarks {
+	ret := &watermarks{index: map[*view.View]*viewWatermarks{}}
+	for _, aView := range views {
+		if !watermarkEnabled(aView) {
+			continue
+		}
+		store, err := aView.Cache.WatermarkStore()
+		if err != nil {
+			fmt.Printf("[WARN] cache warmup watermark disabled view=%s error=%v\n", aView.Name, err)
+			continue
+		}
+		previous, err := store.Load(ctx)
+		if err != nil {
+			fmt.Printf("[WARN] cache warmup watermark reset view=%s error=%v\n", aView.Name, err)
+			previous = view.NewWatermarkState()
+		}
+		ret.index[aView] = &viewWatermarks{view: aView, store: store, previous: previous, current: view.NewWatermarkState(), probes: map[string]string{}}
+	}
+	return ret
+}
+
+// read returns warmup read function that skips cache entries with unchanged watermark
+func (w *watermarks) read(read warmupReadFn, query watermarkQueryFn) warmupReadFn {
+	if w == nil || len(w.index) == 0 {
+		return read
+	}
+	return func(ctx context.Context, entry *warmupEntry) (*EntryResult, error) {
+		marks, ok := w.index[entry.view]
+		if !ok {
+			return read(ctx, entry)
+		}
+		key := watermarkKey(entry)
+		value, err := marks.value(ctx, entry, query)
+		if err != nil {
+			fmt.Printf("[WARN] cache warmup watermark error view=%s column=%s params=%s cache_write=refresh error=%v\n", entry.view.Name, entry.column, entry.label, err)
+			return read(ctx, entry)
+		}
+		if marks.unchanged(key, value) {
+			fmt.Printf("[INFO] cache warmup query skipped view=%s cache=%s column=%s params=%s field_names=%s watermark=%s\n", entry.view.Name, cacheLabel(entry.view), entry.column, entry.label, entry.fields, value)
+			return &EntryResult{View: entry.view.Name, Column: entry.column, Params: entry.label, FieldNames: entry.fields, Elapsed: time.Duration(0).String(), Skipped: true, Watermark: value}, nil
+		}
+		result, err := read(ctx, entry)
+		if err == nil && result != nil {
+			marks.set(key, value)
+			result.Watermark = value
+		}
+		return result, err
+	}
+}
+
+// save persists watermarks of the current run, keys which failed are re-warmed by the next run
+func (w *watermarks) save(ctx context.Context) {
+	if w == nil {
+		return
+	}
+	for _, marks := range w.index {
+		marks.current.UpdateTime = time.Now().UTC()
+		if err := marks.store.Save(ctx, marks.current); err != nil {
+			fmt.Printf("[WARN] cache warmup watermark save error view=%s error=%v\n", marks.view.Name, err)
+		}
+	}
+}
+
+func (m *viewWatermarks) unchanged(key, value string) bool {
+	previous, ok := m.previous.Keys[key]
+	if !ok || previous != value {
+		return false
+	}
+	m.set(key, value)
+	return true
+}
+
+func (m *viewWatermarks) set(key, value string) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	m.current.Keys[key] = value
+}
+
+func (m *viewWatermarks) value(ctx context.Context, entry *warmupEntry, query watermarkQueryFn) (string, error) {
+	watermark := m.view.Cache.Warmup.Watermark
+	var value string
+	if watermark.Column != "" {
+		columnValue, err := m.columnValue(ctx, entry, query)
+		if err != nil {
+			return "", err
+		}
+		value = columnValue
+	}
+	if watermark.Partition {
+		m.once.Do(func() {
+			m.partition, m.partitionErr = partitionWatermark(ctx, entry, m.view)
+		})
+		if m.partitionErr != nil {
+			return "", m.partitionErr
+		}
+		value += "@" + m.partition
+	}
+	return value, nil
+}
+
+// columnValue returns max watermark column value of cache key rows, source query projects only watermark column
+// with cache key criteria, thus it works for template views without table too
+func (m *viewWatermarks) columnValue(ctx context.Context, entry *warmupEntry, query watermarkQueryFn) (string, error) {
+	if entry.source == nil {
+		return "", fmt.Errorf("watermark source query was empty")
+	}
+	args, _ := json.Marshal(entry.source.Args)
+	key := entry.source.SQL + "\n" + string(args)
+	m.mux.Lock()
+	value, ok := m.probes[key]
+	m.mux.Unlock()
+	if ok {
+		return value, nil
+	}
+	SQL := "SELECT MAX(t." + m.view.Cache.Warmup.Watermark.Column + ") FROM (" + entry.source.SQL + ") t"
+	value, err := query(ctx, entry, SQL, entry.source.Args)
+	if err != nil {
+		return "", err
+	}
+	m.mux.Lock()
+	m.probes[key] = value
+	m.mux.Unlock()
+	return value, nil
+}
+
+// partitionWatermark returns view partitions fingerprint
+func partitionWatermark(ctx context.Context, entry *warmupEntry, aView *view.View) (string, error) {
+	partitioner := aView.Partitioned.Partitioner()
+	if partitioner == nil {
+		return "", fmt.Errorf("partitioner was empty for view %v", aView.Name)
+	}
+	db, err := DB(entry)
+	if err != nil {
+		return "", err
+	}
+	partitions, err := partitioner.Partitions(ctx, db, aView)
+	if err != nil {
+		return "", fmt.Errorf("failed to get partitions: %w", err)
+	}
+	data, err := json.Marshal(partitions)
+	if err != nil {
+		return "", err
+	}
+	return hash(string(data)), nil
+}
+
+func queryWatermark(ctx context.Context, entry *warmupEntry, SQL string, args []interface{}) (string, error) {
+	db, err := DB(entry)
+	if err != nil {
+		return "", err
+	}
+	var value interface{}
+	if err = db.QueryRowContext(ctx, SQL, args...).Scan(&value); err != nil {
+		return "", fmt.Errorf("failed to query watermark: %w", err)
+	}
+	switch actual := value.(type) {
+	case nil:
+		return "", nil
+	case []byte:
+		return string(actual), nil
+	case time.Time:
+		return actual.UTC().Format(time.RFC3339Nano), nil
+	}
+	return fmt.Sprintf("%v", value), nil
+}
+
+// watermarkKey identifies cache entry: indexed column, SQL and its arguments
+func watermarkKey(entry *warmupEntry) string {
+	args, _ := json.Marshal(entry.matcher.Args)
+	return hash(entry.column + "\n" + entry.matcher.SQL + "\n" + string(args))
+}
+
+// watermarkSource returns cache key query projecting only watermark column, or nil when column is not tracked
+func (c *matchersCollector) watermarkSource(ctx context.Context, input *view.CacheInput) *cache.ParmetrizedQuery {
+	if !watermarkEnabled(c.view) || c.view.Cache.Warmup.Watermark.Column == "" {
+		return nil
+	}
+	selector := input.Selector.CloneForSummary()
+	selector.Columns = []string{c.view.Cache.Warmup.Watermark.Column}
+	source, err := c.builder.CacheSQL(ctx, c.view, selector)
+	if err != nil {
+		fmt.Printf("[WARN] cache warmup watermark source error view=%s params=%s error=%v\n", c.view.Name, input.Label, err)
+		return nil
+	}
+	return source
+}
+
+func watermarkEnabled(aView *view.View) bool {
+	return aView != nil && aView.Cache != nil && aView.Cache.Warmup != nil && aView.Cache.Warmup.Watermark.Enabled()
+}
+
+func hash(text string) string {
+	sum := sha1.Sum([]byte(text))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,87 @@
+package warmup
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/viant/datly/view"
+	sqlcache "github.com/viant/sqlx/io/read/cache"
+)
+
+type memoryWatermarks struct {
+	state *view.WatermarkState
+}
+
+func (m *memoryWatermarks) Load(ctx context.Context) (*view.WatermarkState, error) {
+	if m.state == nil {
+		return view.NewWatermarkState(), nil
+	}
+	return m.state, nil
+}
+
+func (m *memoryWatermarks) Save(ctx context.Context, state *view.WatermarkState) error {
+	m.state = state
+	return nil
+}
+
+func TestWatermarks_Read(t *testing.T) {
+	aView := &view.View{Name: "events", Cache: &view.Cache{Warmup: &view.Warmup{IndexColumn: "ID", Watermark: &view.Watermark{Column: "UPDATED"}}}}
+	source := func(eventType string) *sqlcache.ParmetrizedQuery {
+		return &sqlcache.ParmetrizedQuery{SQL: "SELECT UPDATED FROM EVENTS WHERE TYPE = ?", Args: []interface{}{eventType}}
+	}
+	entries := []*warmupEntry{
+		{view: aView, column: "ID", label: "a", matcher: &sqlcache.ParmetrizedQuery{SQL: "SELECT * FROM EVENTS WHERE TYPE = ?", Args: []interface{}{"a"}}, source: source("a")},
+		{view: aView, column: "ID", label: "a", matcher: &sqlcache.ParmetrizedQuery{SQL: "SELECT COUNT(1) FROM EVENTS WHERE TYPE = ?", Args: []interface{}{"a"}}, source: source("a")},
+		{view: aView, column: "ID", label: "b", matcher: &sqlcache.ParmetrizedQuery{SQL: "SELECT * FROM EVENTS WHERE TYPE = ?", Args: []interface{}{"b"}}, source: source("b")},
+	}
+	updated := map[interface{}]string{"a": "2024-01-01", "b": "2024-01-01"}
+	probes := 0
+	query := func(ctx context.Context, entry *warmupEntry, SQL string, args []interface{}) (string, error) {
+		probes++
+		assert.Equal(t, "SELECT MAX(t.UPDATED) FROM (SELECT UPDATED FROM EVENTS WHERE TYPE = ?) t", SQL)
+		return updated[args[0]], nil
+	}
+	warmed := map[string]int{}
+	read := func(ctx context.Context, entry *warmupEntry) (*EntryResult, error) {
+		warmed[entry.label]++
+		return &EntryResult{View: entry.view.Name, Params: entry.label, GroupsWritten: 1}, nil
+	}
+	store := &memoryWatermarks{}
+	run := func() []*EntryResult {
+		previous, err := store.Load(context.Background())
+		require.NoError(t, err)
+		marks := &watermarks{index: map[*view.View]*viewWatermarks{aView: {view: aView, store: store, previous: previous, current: view.NewWatermarkState(), probes: map[string]string{}}}}
+		var results []*EntryResult
+		for _, entry := range entries {
+			result, err := marks.read(read, query)(context.Background(), entry)
+			require.NoError(t, err)
+			results = append(results, result)
+		}
+		marks.save(context.Background())
+		return results
+	}
+
+	results := run()
+	assert.Equal(t, map[string]int{"a": 2, "b": 1}, warmed)
+	assert.Equal(t, 2, probes, "cache key source should be probed once per run")
+	assert.False(t, results[0].Skipped)
+	assert.Equal(t, "2024-01-01", results[0].Watermark)
+
+	results = run()
+	assert.Equal(t, map[string]int{"a": 2, "b": 1}, warmed)
+	for _, result := range results {
+		assert.True(t, result.Skipped, fmt.Sprintf("%+v", result))
+	}
+
+	updated["b"] = "2024-01-02"
+	results = run()
+	assert.Equal(t, map[string]int{"a": 2, "b": 2}, warmed, "only changed key should be re-warmed")
+	assert.True(t, results[0].Skipped)
+	assert.True(t, results[1].Skipped)
+	assert.False(t, results[2].Skipped)
+	assert.Equal(t, "2024-01-02", results[2].Watermark)
+	assert.Len(t, store.state.Keys, 3)
+}